@@ -0,0 +1,124 @@
+package breaker
+
+import "sync"
+
+// actor Applies the state mutations in order on a single goroutine, so callers only take
+// the admission decision from the published view and hand the rest of the call over.
+// Unlike the dispatcher it never drops a message, a full mailbox holds the sender back.
+type actor struct {
+	mailbox chan func()
+	quit    chan struct{}
+	done    chan struct{}
+
+	once sync.Once
+}
+
+func newActor(size int) *actor {
+	a := &actor{
+		mailbox: make(chan func(), size),
+		quit:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go a.run()
+	return a
+}
+
+func (a *actor) run() {
+	defer close(a.done)
+	for {
+		select {
+		case fn := <-a.mailbox:
+			fn()
+		case <-a.quit:
+			for {
+				select {
+				case fn := <-a.mailbox:
+					fn()
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// send Queues fn on the mailbox, it is discarded once the actor stopped.
+func (a *actor) send(fn func()) {
+	select {
+	case a.mailbox <- fn:
+	case <-a.quit:
+	}
+}
+
+// call Runs fn on the actor and waits for it, returning right away once the actor stopped.
+func (a *actor) call(fn func()) {
+	ran := make(chan struct{})
+	select {
+	case a.mailbox <- func() { fn(); close(ran) }:
+	case <-a.quit:
+		return
+	}
+
+	select {
+	case <-ran:
+	case <-a.done:
+	}
+}
+
+// stop Applies what is left on the mailbox and waits for the actor to return.
+func (a *actor) stop() {
+	a.once.Do(func() { close(a.quit) })
+	<-a.done
+}
+
+// account Accounts the outcome on the actor when there is one, or right away otherwise.
+func (c *CircuitBreaker) account(outcome Outcome, labels map[string]string, weight uint64, measurements Measurements) {
+	if c.actor == nil {
+		c.incr(outcome, labels, weight, measurements)
+		return
+	}
+	c.actor.send(func() { c.incr(outcome, labels, weight, measurements) })
+}
+
+// settle Evaluates the transitions after a call on the actor when there is one, or right
+// away otherwise.
+func (c *CircuitBreaker) settle() {
+	if c.actor == nil {
+		c.afterExecute()
+		return
+	}
+	c.actor.send(c.afterExecute)
+}
+
+// mutate Applies fn to the state on the actor when there is one, which owns the state so
+// nothing is locked, or under the state lock otherwise. It must not be called from the actor.
+func (c *CircuitBreaker) mutate(fn func()) {
+	if c.actor != nil {
+		c.actor.call(fn)
+		return
+	}
+
+	c.state.mu.Lock()
+	defer c.state.mu.Unlock()
+	fn()
+}
+
+// lockState Takes the state lock for the mutations running on the actor when there is one,
+// or posted by the timers and the calls otherwise, returning how to release it.
+func (c *CircuitBreaker) lockState() (unlock func()) {
+	if c.actor != nil {
+		return func() {}
+	}
+
+	c.state.mu.Lock()
+	return c.state.mu.Unlock
+}
+
+// onActor Returns fn posting itself to the actor when there is one, for the timers to
+// rotate the frames and leave the open state in order with the calls.
+func (c *CircuitBreaker) onActor(fn func()) func() {
+	if c.actor == nil {
+		return fn
+	}
+	return func() { c.actor.send(fn) }
+}
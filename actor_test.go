@@ -0,0 +1,92 @@
+package breaker
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreakerActor(t *testing.T) {
+	cb, cancel, err := New(
+		WithActor(16),
+		WithCanTrip(func(summary Counts) bool { return summary.Fail > 4 }),
+		WithOpenDuration(1),
+		WithHalfOpenEvaluation(1, 1),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = cb.Execute(fixtureCircuitCall(errCall))
+		}()
+	}
+	wg.Wait()
+
+	require.Eventually(t, func() bool { return cb.State() == Open }, time.Second, time.Millisecond*10)
+	assert.Equal(t, Counts{Total: 5, Fail: 5}, cb.Counts())
+	assert.ErrorIs(t, cb.Execute(fixtureCircuitCall(nil)), ErrOpenCircuit)
+
+	require.Eventually(t, func() bool { return cb.State() == HalfOpen }, time.Second*2, time.Millisecond*10)
+	require.NoError(t, cb.Execute(fixtureCircuitCall(nil)))
+	require.Eventually(t, func() bool { return cb.State() == Closed }, time.Second, time.Millisecond*10)
+}
+
+func TestActorStopAppliesMailbox(t *testing.T) {
+	a := newActor(8)
+	applied := 0
+	for i := 0; i < 8; i++ {
+		a.send(func() { applied++ })
+	}
+	a.stop()
+	assert.Equal(t, 8, applied)
+
+	a.send(func() { applied++ })
+	assert.Equal(t, 8, applied)
+}
+
+func TestBreakerActorMutations(t *testing.T) {
+	cb, cancel, err := New(WithActor(4), WithOpenDuration(1))
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				_ = cb.Execute(fixtureCircuitCall(nil))
+			}
+		}()
+	}
+
+	cb.Trip()
+	assert.Equal(t, Open, cb.State())
+	cb.ProbeNow()
+	assert.Equal(t, HalfOpen, cb.State())
+	cb.Reset()
+	assert.Equal(t, Closed, cb.State())
+
+	require.NoError(t, cb.SetMode(ModeForcedOpen))
+	assert.Equal(t, ModeForcedOpen, cb.Mode())
+	cb.Pause()
+	cb.Resume()
+	assert.Equal(t, ModeForcedOpen, cb.Mode())
+	require.NoError(t, cb.SetMode(ModeNormal))
+
+	cb.SetExternalHealth(false, "maintenance")
+	assert.Equal(t, Open, cb.State())
+	cb.SetExternalHealth(true, "")
+	assert.Equal(t, Closed, cb.State())
+	wg.Wait()
+
+	cancel()
+	cb.Trip()
+	assert.Equal(t, Closed, cb.State())
+}
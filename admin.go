@@ -0,0 +1,127 @@
+package breaker
+
+import "fmt"
+
+type Mode string
+
+const (
+	// ModeNormal Lets the trip predicates drive the state.
+	ModeNormal Mode = "normal"
+	// ModeForcedOpen Rejects every call regardless of the state.
+	ModeForcedOpen Mode = "forced-open"
+	// ModeForcedClosed Admits and counts every call but never changes the state.
+	ModeForcedClosed Mode = "forced-closed"
+	// ModePaused Passes every call through without counting it, keeping the window as is.
+	ModePaused Mode = "paused"
+)
+
+func (c *CircuitBreaker) State() State {
+	return c.stateCopy()
+}
+
+// Counts Returns the counts summed over the rolling window.
+func (c *CircuitBreaker) Counts() Counts {
+	c.expireStale()
+	return c.summaryCopy()
+}
+
+func (c *CircuitBreaker) Mode() Mode {
+	return c.load().mode
+}
+
+func (c *CircuitBreaker) SetMode(mode Mode) error {
+	switch mode {
+	case ModeNormal, ModeForcedOpen, ModeForcedClosed, ModePaused:
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidMode, mode)
+	}
+
+	c.mutate(func() { c.setMode(mode) })
+	return nil
+}
+
+// setMode Changes the mode remembering the one left when pausing, the state lock must be held.
+func (c *CircuitBreaker) setMode(mode Mode) {
+	if mode == ModePaused && c.state.mode != ModePaused {
+		c.state.pausedFrom = c.state.mode
+	}
+	c.state.mode = mode
+	c.publish(func(v *view) { v.mode = mode })
+}
+
+// Pause Passes calls through without counting them until Resume, for planned maintenance
+// of the dependency where failures are expected.
+func (c *CircuitBreaker) Pause() {
+	c.mutate(func() { c.setMode(ModePaused) })
+}
+
+// Resume Restores the mode the breaker had before Pause.
+func (c *CircuitBreaker) Resume() {
+	c.mutate(func() {
+		if c.state.mode == ModePaused {
+			c.setMode(c.state.pausedFrom)
+		}
+	})
+}
+
+// ProbeNow Moves an open circuit to half-open right away instead of waiting out the open
+// duration.
+func (c *CircuitBreaker) ProbeNow() {
+	c.mutate(func() {
+		if c.state.s != Open {
+			return
+		}
+		c.stopHalfOpenTimer()
+		c.toHalfOpen(ReasonManualProbe)
+	})
+}
+
+// Trip Opens the circuit as if the trip predicate had been met.
+func (c *CircuitBreaker) Trip() {
+	c.trip(ReasonManualTrip)
+}
+
+// Reset Closes the circuit and clears the rolling window.
+func (c *CircuitBreaker) Reset() {
+	c.mutate(func() {
+		c.clearWindow()
+		if c.state.s != Closed {
+			c.setState(Closed, ReasonManualReset)
+		}
+		c.openQueue.release()
+	})
+}
+
+// ResetCounts Clears the rolling window without changing the state nor its timers, so
+// past failures stop weighing on the trip predicate.
+func (c *CircuitBreaker) ResetCounts() {
+	c.mutate(func() {
+		c.clearWindow()
+		if c.state.s == HalfOpen {
+			c.addFrame()
+		}
+	})
+}
+
+func (c *CircuitBreaker) clearWindow() {
+	c.rollingWindow.mu.Lock()
+	defer c.rollingWindow.mu.Unlock()
+
+	c.rollingWindow.window = newWindow(c.clock.Now(), c.cfg.frames, c.cfg.windowFrame)
+	c.summary.counts = Counts{}
+	c.missedFrames.Store(0)
+	if c.strategy != nil {
+		c.strategy.Reset()
+	}
+	c.publishFrame()
+	c.publishSummary()
+}
+
+// LastTrial Returns the counts of the half-open trial that last closed the circuit, kept
+// apart from the window with WithSeparateHalfOpenStats.
+func (c *CircuitBreaker) LastTrial() Counts {
+	if trial := c.lastTrial.Load(); trial != nil {
+		return *trial
+	}
+	return Counts{}
+}
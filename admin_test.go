@@ -0,0 +1,175 @@
+package breaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreakerTripAndReset(t *testing.T) {
+	cb, cancel, err := New(
+		WithWindowFrameThreshold(10),
+		WithWindowRollThreshold(30),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	syncFeedCircuitBreakerHelper(cb, []error{nil, errCall}, false)
+
+	cb.Trip()
+	assert.Equal(t, Open, cb.State())
+	assert.ErrorIs(t, cb.Execute(fixtureCircuitCall(nil)), ErrOpenCircuit)
+
+	cb.Reset()
+	gotWindow := windowCountsHelper(cb)
+	assert.Equal(t, Closed, cb.State())
+	assert.Equal(t, Counts{}, cb.Counts())
+	assert.Equal(t, make([]Counts, 3, 5), gotWindow)
+	assert.Equal(t, cap(make([]Counts, 3, 5)), cap(gotWindow))
+	assert.NoError(t, cb.Execute(fixtureCircuitCall(nil)))
+}
+
+func TestBreakerModes(t *testing.T) {
+	cb, cancel, err := New(
+		WithCanTrip(func(summary Counts) bool { return summary.Fail > 0 }),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	assert.Equal(t, ModeNormal, cb.Mode())
+	assert.ErrorIs(t, cb.SetMode("sideways"), ErrInvalidMode)
+
+	require.NoError(t, cb.SetMode(ModeForcedOpen))
+	assert.ErrorIs(t, cb.Execute(fixtureCircuitCall(nil)), ErrOpenCircuit)
+	assert.Equal(t, Counts{}, cb.Counts())
+
+	require.NoError(t, cb.SetMode(ModeForcedClosed))
+	assert.ErrorIs(t, cb.Execute(fixtureCircuitCall(errCall)), errCall)
+	assert.Equal(t, Closed, cb.State())
+	assert.Equal(t, Counts{Total: 1, Fail: 1}, cb.Counts())
+
+	require.NoError(t, cb.SetMode(ModeNormal))
+	assert.ErrorIs(t, cb.Execute(fixtureCircuitCall(errCall)), errCall)
+	assert.Equal(t, Open, cb.State())
+}
+
+func TestBreakerPauseAndResume(t *testing.T) {
+	cb, cancel, err := New(
+		WithCanTrip(func(summary Counts) bool { return summary.Fail > 1 }),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	syncFeedCircuitBreakerHelper(cb, []error{nil, errCall}, false)
+	require.NoError(t, cb.SetMode(ModeForcedClosed))
+
+	cb.Pause()
+	cb.Pause()
+	assert.Equal(t, ModePaused, cb.Mode())
+	syncFeedCircuitBreakerHelper(cb, []error{errCall, errCall, errCall}, false)
+	assert.ErrorIs(t, cb.Execute(fixtureCircuitCall(errCall)), errCall)
+	assert.Equal(t, Closed, cb.State())
+	assert.Equal(t, Counts{Total: 2, Fail: 1, Success: 1}, cb.Counts())
+
+	cb.Resume()
+	assert.Equal(t, ModeForcedClosed, cb.Mode())
+	cb.Resume()
+	assert.Equal(t, ModeForcedClosed, cb.Mode())
+
+	require.NoError(t, cb.SetMode(ModeNormal))
+	require.NoError(t, cb.SetMode(ModePaused))
+	cb.Resume()
+	assert.Equal(t, ModeNormal, cb.Mode())
+}
+
+func TestBreakerResetCounts(t *testing.T) {
+	cb, cancel, err := New(
+		WithWindowFrameThreshold(10),
+		WithWindowRollThreshold(30),
+		WithOpenDuration(1),
+		WithHalfOpenEvaluation(2, 1),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	syncFeedCircuitBreakerHelper(cb, []error{nil, errCall}, false)
+	cb.ResetCounts()
+	assert.Equal(t, Closed, cb.State())
+	assert.Equal(t, Counts{}, cb.Counts())
+	assert.Equal(t, make([]Counts, 3, 5), windowCountsHelper(cb))
+
+	cb.Trip()
+	cb.ResetCounts()
+	assert.Equal(t, Open, cb.State())
+	assert.True(t, cb.onHalfOpenTimeout.Load())
+
+	time.Sleep(cb.cfg.openDuration + (time.Millisecond * 200))
+	require.Equal(t, HalfOpen, cb.State())
+	syncFeedCircuitBreakerHelper(cb, []error{nil}, false)
+	cb.ResetCounts()
+	assert.Equal(t, HalfOpen, cb.State())
+	assert.Len(t, windowCountsHelper(cb), 4)
+
+	syncFeedCircuitBreakerHelper(cb, []error{nil, nil}, false)
+	assert.Equal(t, Closed, cb.State())
+	assert.Equal(t, Counts{Total: 2, Success: 2}, cb.Counts())
+	assert.Len(t, windowCountsHelper(cb), 3)
+}
+
+func TestBreakerProbeNow(t *testing.T) {
+	cb, cancel, err := New()
+	require.NoError(t, err)
+	defer cancel()
+
+	cb.ProbeNow()
+	assert.Equal(t, Closed, cb.State())
+
+	cb.Trip()
+	cb.ProbeNow()
+	assert.Equal(t, HalfOpen, cb.State())
+	assert.Equal(t, ReasonManualProbe, cb.Transitions(1)[0].Reason)
+}
+
+func TestBreakerSeparateHalfOpenStats(t *testing.T) {
+	tt := []struct {
+		name     string
+		input    []option
+		counts   Counts
+		expected Counts
+	}{
+		{
+			name:     "folds_trial_into_window",
+			input:    nil,
+			counts:   Counts{Total: 4, Fail: 1, Success: 3},
+			expected: Counts{},
+		},
+		{
+			name:     "keeps_trial_apart",
+			input:    []option{WithSeparateHalfOpenStats()},
+			counts:   Counts{Total: 2, Fail: 1, Success: 1},
+			expected: Counts{Total: 2, Success: 2},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			cb, cancel, err := New(append([]option{
+				WithCanTrip(func(summary Counts) bool { return false }),
+				WithHalfOpenEvaluation(2, 1),
+			}, tc.input...)...)
+			require.NoError(t, err)
+			defer cancel()
+
+			syncFeedCircuitBreakerHelper(cb, []error{nil, errCall}, false)
+			cb.Trip()
+			cb.ProbeNow()
+			syncFeedCircuitBreakerHelper(cb, []error{nil, nil}, false)
+
+			require.Equal(t, Closed, cb.State())
+			assert.Equal(t, tc.counts, cb.Counts())
+			assert.Equal(t, tc.expected, cb.LastTrial())
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package breaker
+
+import "math"
+
+const (
+	_anomalyFrames    = 3
+	_anomalyDeviation = 1
+)
+
+// anomalyDetector Trips on a current frame whose failure rate sits z standard deviations
+// above the rates of the complete frames of the window. Unlike the tuner it keeps no memory
+// past the window, reacting to a deviation from the recent behaviour of the dependency
+// however noisy. Until the window holds enough frames with volume it defers to fallback.
+type anomalyDetector struct {
+	cb       *CircuitBreaker
+	z        float64
+	fallback canTrip
+}
+
+func (d *anomalyDetector) canTrip(summary Counts) bool {
+	current, rates := d.rates()
+	if len(rates) < _anomalyFrames {
+		return d.fallback(summary)
+	}
+	if current.Total < _minimumVolume {
+		return false
+	}
+
+	var mean float64
+	for _, rate := range rates {
+		mean += rate
+	}
+	mean /= float64(len(rates))
+
+	var variance float64
+	for _, rate := range rates {
+		variance += (rate - mean) * (rate - mean)
+	}
+	// A pristine dependency would otherwise trip on its first failure.
+	deviation := math.Max(_anomalyDeviation, math.Sqrt(variance/float64(len(rates))))
+
+	return (current.FailureRate()-mean)/deviation >= d.z
+}
+
+// rates Returns the current frame and the failure rates of the complete frames holding
+// enough calls.
+func (d *anomalyDetector) rates() (Counts, []float64) {
+	d.cb.rollingWindow.mu.RLock()
+	defer d.cb.rollingWindow.mu.RUnlock()
+
+	window := d.cb.rollingWindow.window
+	if len(window) == 0 {
+		return Counts{}, nil
+	}
+
+	rates := make([]float64, 0, len(window)-1)
+	for _, f := range window[:len(window)-1] {
+		if f.Total >= _minimumVolume {
+			rates = append(rates, f.FailureRate())
+		}
+	}
+	return window[len(window)-1].Counts, rates
+}
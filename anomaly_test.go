@@ -0,0 +1,60 @@
+package breaker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreakerAnomalyDetection(t *testing.T) {
+	tt := []struct {
+		name      string
+		failures  int
+		successes int
+		expected  State
+	}{
+		{name: "tolerates_usual_deviation", failures: 7, successes: 13, expected: Closed},
+		{name: "trips_on_anomaly", failures: 9, successes: 11, expected: Open},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			cb, cancel, err := New(
+				WithCanTrip(func(summary Counts) bool { return false }),
+				WithAnomalyDetection(3),
+				WithWindowFrameThreshold(10),
+				WithWindowRollThreshold(50),
+			)
+			require.NoError(t, err)
+			defer cancel()
+
+			for _, failures := range []int{4, 6, 4, 6} {
+				calls := make([]error, 20)
+				for i := 20 - failures; i < 20; i++ {
+					calls[i] = errCall
+				}
+				syncFeedCircuitBreakerHelper(cb, calls, false)
+				cb.moveWindow()
+			}
+			require.Equal(t, Closed, cb.State())
+
+			calls := make([]error, tc.successes, tc.successes+tc.failures)
+			for i := 0; i < tc.failures; i++ {
+				calls = append(calls, errCall)
+			}
+			syncFeedCircuitBreakerHelper(cb, calls, false)
+			assert.Equal(t, tc.expected, cb.State())
+		})
+	}
+
+	cb, cancel, err := New(
+		WithCanTrip(func(summary Counts) bool { return summary.Fail > 0 }),
+		WithAnomalyDetection(3),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	assert.ErrorIs(t, cb.Execute(fixtureCircuitCall(errCall)), errCall)
+	assert.Equal(t, Open, cb.State())
+}
@@ -23,7 +23,7 @@ func BenchmarkExecute(b *testing.B) {
 		cb, cancel, err := New(
 			WithWindowFrameThreshold(1),
 			WithWindowRollThreshold(300),
-			WithHalfOpenThreshold(2),
+			WithOpenDuration(2),
 		)
 		require.NoError(b, err)
 		for _, err := range calls {
@@ -38,10 +38,25 @@ func BenchmarkMoveWindow(b *testing.B) {
 		cb, cancel, err := New(
 			WithWindowFrameThreshold(1),
 			WithWindowRollThreshold(300),
-			WithHalfOpenThreshold(2),
+			WithOpenDuration(2),
 		)
 		require.NoError(b, err)
 		cancel()
 		cb.moveWindow()
 	}
 }
+
+func BenchmarkExecuteParallel(b *testing.B) {
+	cb, cancel, err := New(
+		WithCanTrip(func(summary Counts) bool { return false }),
+	)
+	require.NoError(b, err)
+	defer cancel()
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = cb.Execute(fixtureCircuitCall(nil))
+		}
+	})
+}
@@ -1,8 +1,10 @@
 package breaker
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"runtime/trace"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -15,196 +17,770 @@ const (
 	HalfOpen State = "half-open"
 	Open     State = "open"
 
-	_windowRoll      = 300
-	_windowFrame     = 15
-	_halfOpenTimeout = 30
+	_windowRoll       = 300
+	_windowFrame      = 15
+	_openDuration     = 30
+	_halfOpenCalls    = 51
+	_halfOpenFailures = 1
+	_latencySamples   = 100
+	_transitions      = 100
+	_eventQueue       = 1024
+	_minimumVolume    = 10
 )
 
 var (
-	ErrNewCircuitBreaker = errors.New("failed to create circuit breaker")
-	ErrOpenCircuit       = errors.New("circuit open")
+	ErrNewCircuitBreaker    = errors.New("failed to create circuit breaker")
+	ErrOpenCircuit          = errors.New("circuit open")
+	ErrInsufficientDeadline = errors.New("insufficient deadline")
+	ErrCallShed             = errors.New("call shed")
+	ErrCallPanic            = errors.New("call panicked")
+	ErrInvalidMode          = errors.New("invalid mode")
+	ErrUnnamedBreaker       = errors.New("breaker has no name")
+	ErrDuplicateBreaker     = errors.New("breaker already registered")
+	ErrShutdown             = errors.New("circuit breaker shut down")
+	ErrProbeCoalesced       = errors.New("call coalesced into a half-open probe")
 )
 
 type (
 	circuitCall         func() error
+	contextCircuitCall  func(ctx context.Context) error
 	canTrip             func(summary Counts) bool
+	measuredCanTrip     func(summary Counts, measurements Measurements) bool
+	summaryCanTrip      func(summary Summary) bool
 	fromHalfOpenToState func(summary Counts) State
+	onFrameRoll         func(completed Counts, summary Counts)
+	interceptor         func(next circuitCall) circuitCall
+	rejectionErrorFunc  func(rejection Rejection) error
+	failSilentRecorder  func(err error)
+	classifier          func(err error, latency time.Duration) Outcome
 )
 
+// Breaker Is what call sites need from a circuit breaker, so they can be handed a
+// CircuitBreaker or a stand-in such as NoopBreaker.
+type Breaker interface {
+	Name() string
+	State() State
+	Execute(fn func() error) error
+	ExecuteContext(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+var _ Breaker = (*CircuitBreaker)(nil)
+
 type CircuitBreaker struct {
 	state             *state
 	onHalfOpenTimeout atomic.Bool
+	halfOpenTimer     Timer
+	frameTimer        Timer
+	stopped           bool
+	timerMu           sync.Mutex
+	missedFrames      atomic.Int64
+	executing         atomic.Int64
+	skippedRuns       atomic.Uint64
 
 	canTrip             canTrip
+	measuredCanTrip     measuredCanTrip
+	summaryCanTrip      summaryCanTrip
 	fromHalfOpenToState fromHalfOpenToState
-
-	cfg configuration
+	onFrameRoll         onFrameRoll
+	interceptors        []interceptor
+	observers           []Observer
+	metrics             *metrics
+	trace               bool
+	tuner               *tuner
+	collapseRatio       float64
+	spike               *spike
+	decay               decay
+	separateTrials      bool
+	lastTrial           atomic.Pointer[Counts]
+	thresholds          atomic.Pointer[Thresholds]
+	rejectionError      rejectionErrorFunc
+	failSilent          failSilentRecorder
+	classifier          classifier
+
+	name    string
+	opts    []option
+	tags    map[string]string
+	cfg     configuration
+	clock   Clock
+	created time.Time
 
 	rollingWindow *rollingWindow
 	summary       *summary
+	errorHistory  *ring[ErrorRecord]
+	transitions   *ring[Transition]
+	latencies     *samples
+	timeSaved     *timeSaved
+	openQueue     *openQueue
+	probes        *probeGroup
+	halfOpenRate  *tokenBucket
+	ramp          *ramp
+	frameHistory  *ring[Frame]
+	jitter        *jitter
+	random        func() float64
+	strategy      WindowStrategy
+	inflight      *inflight
+	drain         drain
+	fleet         *fleet
+	shared        *sharedState
+	discovery     *discovery
+	external      *external
+	chaos         *chaos
+	cooldown      *cooldown
+	flaps         *flapDetector
+	subscribers   *subscribers
+	view          atomic.Pointer[view]
+	dispatcher    *dispatcher
+	actor         *actor
+	stop          func()
 }
 
+// Counts Accounts the call outcomes, Total sums every outcome but the ignored ones.
 type Counts struct {
-	Total   uint64
-	Fail    uint64
-	Success uint64
+	Total   uint64 `json:"total"`
+	Fail    uint64 `json:"fail"`
+	Success uint64 `json:"success"`
+	Slow    uint64 `json:"slow"`
+	Ignored uint64 `json:"ignored"`
+}
+
+type frame struct {
+	Counts
+	start        time.Time
+	labels       LabelCounts
+	measurements Measurements
+	rejected     *atomic.Uint64
+}
+
+// newFrame Creates an empty frame starting at start, its rejection counter is shared by the
+// copies of the frame.
+func newFrame(start time.Time) frame {
+	return frame{start: start, rejected: new(atomic.Uint64)}
 }
 
 type rollingWindow struct {
-	window []Counts
+	window []frame
 
 	mu sync.RWMutex
 }
 
+// summary Sums the window frames, guarded by the rolling window lock.
 type summary struct {
 	counts Counts
-
-	mu sync.RWMutex
 }
 
 type state struct {
-	s State
+	s          State
+	mode       Mode
+	pausedFrom Mode
 
 	mu sync.RWMutex
 }
 
 type configuration struct {
-	windowRoll      time.Duration
-	windowFrame     time.Duration
-	halfOpenTimeout time.Duration
+	windowRoll   time.Duration
+	windowFrame  time.Duration
+	frames       int
+	openDuration time.Duration
+
+	deadlinePercentile float64
+	shedding           *shedding
+	degraded           *degradedShedding
 }
 
+// New Creates a circuit breaker, cancel stops its background work and is safe to call
+// more than once and from any goroutine.
 func New(opts ...option) (cb *CircuitBreaker, cancel func(), err error) {
 	cbOpts := &optionsConfiguration{
-		windowFrame:       _windowFrame,
-		windowRoll:        _windowRoll,
-		halfOpenThreshold: _halfOpenTimeout,
+		windowFrame:  _windowFrame,
+		windowRoll:   _windowRoll,
+		openDuration: _openDuration,
 
-		canTrip:             defaultCanTrip,
-		fromHalfOpenToState: defaultFromHalfOpenToState,
+		halfOpenCalls:    _halfOpenCalls,
+		halfOpenFailures: _halfOpenFailures,
+
+		eventQueue: _eventQueue,
+		clock:      realClock{},
+
+		canTrip:      defaultCanTrip,
+		classifier:   defaultClassifier,
+		healthPolicy: HealthExternalWins,
 	}
 
 	for _, opt := range opts {
 		if err = opt(cbOpts); err != nil {
-			return cb, cancel, fmt.Errorf("%w: %s", ErrNewCircuitBreaker, err)
+			return cb, cancel, fmt.Errorf("%w: %w", ErrNewCircuitBreaker, err)
 		}
 	}
 
-	if cbOpts.windowFrame > cbOpts.windowRoll {
-		return cb, cancel, fmt.Errorf("%w: invalid window threshold", ErrNewCircuitBreaker)
+	cbOpts.applyRules()
+
+	if len(cbOpts.tripOn) > 0 {
+		cbOpts.classifier = tripOnErrors(cbOpts.tripOn, cbOpts.classifier)
+	}
+
+	if cbOpts.fromHalfOpenToState == nil {
+		cbOpts.fromHalfOpenToState = halfOpenEvaluation(cbOpts.halfOpenCalls, cbOpts.halfOpenFailures)
+	}
+
+	if cbOpts.frames == 0 && cbOpts.windowFrame > cbOpts.windowRoll {
+		return cb, cancel, fmt.Errorf("%w: %w: frame can't be greater than roll", ErrNewCircuitBreaker, ErrInvalidWindowThreshold)
 	}
 
+	if cbOpts.fleetTransport != nil && cbOpts.name == "" {
+		return cb, cancel, fmt.Errorf("%w: %w: fleet propagation requires a name", ErrNewCircuitBreaker, ErrInvalidName)
+	}
+
+	if cbOpts.sharedState != nil && cbOpts.name == "" {
+		return cb, cancel, fmt.Errorf("%w: %w: shared state requires a name", ErrNewCircuitBreaker, ErrInvalidName)
+	}
+
+	if cbOpts.store != nil && cbOpts.name == "" {
+		return cb, cancel, fmt.Errorf("%w: %w: store requires a name", ErrNewCircuitBreaker, ErrInvalidName)
+	}
+
+	if cbOpts.discovery != nil && cbOpts.name == "" {
+		return cb, cancel, fmt.Errorf("%w: %w: discovery requires a name", ErrNewCircuitBreaker, ErrInvalidName)
+	}
+
+	windowRoll := (time.Second * time.Duration(cbOpts.windowRoll))
+	windowFrame := (time.Second * time.Duration(cbOpts.windowFrame))
 	frames := cbOpts.windowRoll / cbOpts.windowFrame
+	if cbOpts.frames > 0 {
+		windowRoll = cbOpts.window
+		windowFrame = cbOpts.window / time.Duration(cbOpts.frames)
+		frames = cbOpts.frames
+	}
+
 	cb = &CircuitBreaker{
+		name:    cbOpts.name,
+		opts:    append([]option{}, opts...),
+		tags:    cbOpts.tags,
+		clock:   cbOpts.clock,
+		created: cbOpts.clock.Now(),
 		cfg: configuration{
-			windowRoll:      (time.Second * time.Duration(cbOpts.windowRoll)),
-			windowFrame:     (time.Second * time.Duration(cbOpts.windowFrame)),
-			halfOpenTimeout: (time.Second * time.Duration(cbOpts.halfOpenThreshold)),
+			windowRoll:   windowRoll,
+			windowFrame:  windowFrame,
+			frames:       frames,
+			openDuration: (time.Second * time.Duration(cbOpts.openDuration)),
+
+			deadlinePercentile: cbOpts.deadlinePercentile,
+			shedding:           cbOpts.shedding,
+			degraded:           cbOpts.degraded,
 		},
 		canTrip:             cbOpts.canTrip,
+		measuredCanTrip:     cbOpts.measuredCanTrip,
+		summaryCanTrip:      cbOpts.summaryCanTrip,
 		fromHalfOpenToState: cbOpts.fromHalfOpenToState,
+		onFrameRoll:         cbOpts.onFrameRoll,
+		interceptors:        cbOpts.interceptors,
+		observers:           cbOpts.observers,
+		trace:               cbOpts.trace,
+		rejectionError:      cbOpts.rejectionError,
+		failSilent:          cbOpts.failSilent,
+		classifier:          cbOpts.classifier,
+		collapseRatio:       cbOpts.collapseRatio,
+		spike:               cbOpts.spike,
+		decay:               cbOpts.decay,
+		separateTrials:      cbOpts.separateTrials,
 
 		state: &state{
-			s: Closed,
+			s:    Closed,
+			mode: ModeNormal,
 		},
 		rollingWindow: &rollingWindow{
-			window: make([]Counts, frames, (frames + 2)),
+			window: newWindow(cbOpts.clock.Now(), frames, windowFrame),
 		},
 		summary: &summary{
 			counts: Counts{},
 		},
+		errorHistory: newRing[ErrorRecord](cbOpts.errorHistory),
+		frameHistory: newRing[Frame](cbOpts.historyRetention),
+		random:       newRandom(cbOpts.randSource),
+		transitions:  newRing[Transition](_transitions),
+		latencies:    newSamples(_latencySamples),
+		timeSaved:    &timeSaved{},
+		subscribers: &subscribers{
+			chans: make(map[chan Event]struct{}),
+		},
+		external:   &external{policy: cbOpts.healthPolicy},
+		dispatcher: newDispatcher(cbOpts.eventQueue),
 	}
+	if cbOpts.mailbox > 0 {
+		cb.actor = newActor(cbOpts.mailbox)
+	}
+	cb.jitter = newJitter(cbOpts.jitter, cb.random)
+	cb.view.Store(&view{state: Closed, mode: ModeNormal})
+	cb.halfOpenTimer = cb.clock.AfterFunc(cb.cfg.openDuration, cb.onActor(cb.halfOpen))
+	cb.halfOpenTimer.Stop()
+
+	cb.openQueue = newOpenQueue(cbOpts.openQueueMax, cbOpts.openQueueMaxWait, cb.clock)
 
-	cancelCh := make(chan struct{})
-	cancel = cancelFunc(cancelCh)
-	go cb.renewFrame(cancelCh)
+	if cbOpts.flapOpens > 0 {
+		cb.flaps = &flapDetector{opens: cbOpts.flapOpens, within: cbOpts.flapWithin, maxOpenDuration: cbOpts.flapMaxOpenDuration}
+	}
+
+	if cbOpts.recoveryCooldown > 0 {
+		cb.cooldown = &cooldown{duration: cbOpts.recoveryCooldown}
+	}
+
+	if cbOpts.chaos {
+		cb.chaos = &chaos{failureRate: cbOpts.chaosFailureRate, extraLatency: cbOpts.chaosExtraLatency, clock: cb.clock, random: cb.random}
+	}
+
+	if cbOpts.metricsSink != nil {
+		cb.metrics = newMetrics(cbOpts.metricsSink, cb)
+		cb.observers = append(cb.observers, cb.metrics)
+		cbOpts.metricsSink.SetGauge(MetricState, stateGauge[Closed], cb.metrics.breaker)
+	}
+
+	if cbOpts.probeCoalescing {
+		cb.probes = newProbeGroup(cbOpts.probeKey)
+	}
+
+	if cbOpts.adaptiveK > 0 {
+		cb.tuner = newTuner(cbOpts.adaptiveK, cbOpts.adaptiveMinRate, cbOpts.adaptiveMaxRate)
+		cb.canTrip = cb.tuner.canTrip
+	}
+
+	if cbOpts.anomalyZ > 0 {
+		cb.canTrip = (&anomalyDetector{cb: cb, z: cbOpts.anomalyZ, fallback: cb.canTrip}).canTrip
+	}
+
+	cb.strategy = cbOpts.strategy
+	if cbOpts.store != nil {
+		cb.strategy = newStoreWindow(cbOpts.store, cb.name, cb.cfg.windowFrame, cb.cfg.frames, cb.clock, func(err error) {
+			cb.emit(Event{Type: EventStoreError, Error: err.Error()})
+		})
+	}
+	cb.halfOpenRate = newTokenBucket(cbOpts.halfOpenRateEvery, cbOpts.halfOpenRateBurst)
+	if cbOpts.rampSteps > 0 {
+		cb.ramp = &ramp{interval: cbOpts.rampInterval, steps: cbOpts.rampSteps}
+		cb.fromHalfOpenToState = cb.ramp.evaluate
+	}
+
+	if cbOpts.cancelOnTrip {
+		cb.inflight = newInflight()
+	}
+
+	if cbOpts.fleetTransport != nil {
+		cb.fleet = newFleet(cb, cbOpts.fleetNode, cbOpts.fleetTransport, cbOpts.fleetQuorum)
+	}
+
+	if cbOpts.discovery != nil {
+		cb.discovery = &discovery{cb: cb, backend: cbOpts.discovery}
+		go cb.discovery.publish()
+	}
+
+	stopWatch := func() {}
+	if cbOpts.sharedState != nil {
+		cb.shared = newSharedState(cb, cbOpts.sharedState)
+		if stopWatch, err = cb.shared.watch(); err != nil {
+			cb.dispatcher.stop()
+			cb.halfOpenTimer.Stop()
+			if cb.actor != nil {
+				cb.actor.stop()
+			}
+			return nil, nil, fmt.Errorf("%w: %w: %w", ErrNewCircuitBreaker, ErrInvalidSharedState, err)
+		}
+	}
+
+	cb.timerMu.Lock()
+	cb.frameTimer = cb.clock.AfterFunc(cb.jitter.apply(cb.cfg.windowFrame), cb.onActor(cb.renewFrame))
+	cb.timerMu.Unlock()
+
+	var scheduled *profiles
+	if len(cbOpts.profiles) > 0 {
+		scheduled = newProfiles(cb, cbOpts.profiles)
+		scheduled.start()
+	}
+
+	cancel = cancelFunc(func() {
+		if scheduled != nil {
+			scheduled.stop()
+		}
+
+		cb.timerMu.Lock()
+		cb.stopped = true
+		cb.frameTimer.Stop()
+		cb.timerMu.Unlock()
+
+		cb.halfOpenTimer.Stop()
+		if sw, ok := cb.strategy.(*storeWindow); ok {
+			sw.stop()
+		}
+		stopWatch()
+		if cb.fleet != nil {
+			cb.fleet.close()
+		}
+		if cb.actor != nil {
+			cb.actor.stop()
+		}
+		cb.dispatcher.stop()
+	})
+	cb.stop = cancel
 
 	return cb, cancel, nil
 }
 
-func (c *CircuitBreaker) renewFrame(cancel <-chan struct{}) {
-	for {
-		select {
-		case <-time.After(c.cfg.windowFrame):
-			if c.stateCopy() != Closed {
-				return
-			}
-			c.moveWindow()
-		case <-cancel:
-			return
+func (c *CircuitBreaker) Name() string {
+	return c.name
+}
+
+// renewFrame Rolls the window every frame while closed, counting the frames missed otherwise
+// so they are caught up once the circuit closes again.
+func (c *CircuitBreaker) renewFrame() {
+	if c.stateCopy() == Closed {
+		collapsed := c.throughputCollapsed()
+		c.moveWindow()
+		if collapsed {
+			c.collapse()
 		}
+	} else {
+		c.missedFrames.Add(1)
+	}
+
+	c.timerMu.Lock()
+	defer c.timerMu.Unlock()
+	if !c.stopped {
+		c.frameTimer.Reset(c.jitter.apply(c.cfg.windowFrame))
+	}
+}
+
+// Labels Returns a copy of the labels the breaker was created with.
+func (c *CircuitBreaker) Labels() map[string]string {
+	if c.tags == nil {
+		return nil
+	}
+
+	labels := make(map[string]string, len(c.tags))
+	for key, value := range c.tags {
+		labels[key] = value
 	}
+	return labels
+}
+
+func (c *CircuitBreaker) Execute(fn func() error) error {
+	return c.execute(context.Background(), callOptions{priority: Normal}, func(context.Context) error {
+		return fn()
+	})
 }
 
-func (c *CircuitBreaker) Execute(fn circuitCall) error {
-	defer c.afterExecute()
+func (c *CircuitBreaker) ExecuteContext(ctx context.Context, fn func(ctx context.Context) error) error {
+	return c.execute(ctx, callOptions{priority: Normal}, fn)
+}
+
+func (c *CircuitBreaker) execute(ctx context.Context, opts callOptions, fn contextCircuitCall) (err error) {
+	if !c.drain.enter() {
+		return ErrShutdown
+	}
+	defer c.drain.leave()
+
+	defer func() { err = c.silence(err) }()
+	defer c.settle()
+
+	if c.trace {
+		var task *trace.Task
+		ctx, task = trace.NewTask(ctx, "breaker: "+c.name)
+		defer task.End()
+	}
+
+	publish := c.decide(ctx, &opts)
+	if opts.report != nil {
+		opts.report.State = c.load().state
+	}
+	if publish != nil {
+		publish()
+		defer publish()
+	}
 
 	if err := c.canExecute(); err != nil {
+		if err = c.awaitOpenQueue(err); err != nil {
+			return c.rejected(ctx, c.rejection(err))
+		}
+	}
+
+	if c.load().mode == ModePaused {
+		if opts.report != nil {
+			opts.report.ShortCircuited = false
+		}
+		err := c.intercept(ctx, func() error { return fn(ctx) })()
+		if classified, ok := err.(*classifiedError); ok {
+			return classified.err
+		}
 		return err
 	}
 
+	if err := c.canAdmitHalfOpen(); err != nil {
+		return c.rejected(ctx, c.rejection(err))
+	}
+
+	if err := c.canAdmitPriority(opts.priority); err != nil {
+		return c.rejected(ctx, err)
+	}
+
+	if err := c.canAdmitDegraded(opts.priority); err != nil {
+		return c.rejected(ctx, err)
+	}
+
+	if err := c.canMeetDeadline(ctx); err != nil {
+		return c.rejected(ctx, err)
+	}
+
+	if c.probes != nil && c.stateCopy() == HalfOpen {
+		return c.probes.do(ctx, func() error {
+			return c.call(ctx, opts, fn)
+		})
+	}
+
+	return c.call(ctx, opts, fn)
+}
+
+func (c *CircuitBreaker) call(ctx context.Context, opts callOptions, fn contextCircuitCall) error {
+	start := c.clock.Now()
+	c.executing.Add(1)
+	defer c.executing.Add(-1)
+	c.observeStart()
+
 	defer func() {
 		if r := recover(); r != nil {
-			c.incrFail()
+			c.account(OutcomeFailure, opts.labels, opts.weight, opts.measurements())
+			c.recordError(fmt.Errorf("%w: %v", ErrCallPanic, r))
+			c.observeEnd(OutcomeFailure, c.clock.Now().Sub(start))
 			panic(r)
 		}
 	}()
 
-	if err := fn(); err != nil {
-		c.incrFail()
-		return err
+	ctx, done := c.inflight.track(ctx)
+	defer done()
+
+	err := c.intercept(ctx, func() error { return fn(ctx) })()
+	latency := c.clock.Now().Sub(start)
+	c.latencies.push(latency)
+
+	outcome, err := c.classify(err, latency)
+	c.account(outcome, opts.labels, opts.weight, opts.measurements())
+	c.observeEnd(outcome, latency)
+	if c.trace {
+		trace.Log(ctx, "outcome", string(outcome))
+	}
+	if opts.report != nil {
+		opts.report.ShortCircuited = false
+		opts.report.Duration = latency
+		opts.report.Outcome = outcome
+	}
+	if outcome == OutcomeFailure && err != nil {
+		c.recordError(err)
 	}
 
-	c.incrSuccess()
-	return nil
+	return err
+}
+
+// intercept Wraps the call with the interceptors, the first registered being the outermost
+// and the chaos injection the innermost.
+func (c *CircuitBreaker) intercept(ctx context.Context, call circuitCall) circuitCall {
+	if c.chaos != nil {
+		call = c.chaos.intercept(ctx, call)
+	}
+	for i := len(c.interceptors) - 1; i >= 0; i-- {
+		call = c.interceptors[i](call)
+	}
+	return call
 }
 
 func (c *CircuitBreaker) afterExecute() {
-	c.state.mu.Lock()
-	defer c.state.mu.Unlock()
+	// Most calls change nothing, which the published view tells without the state lock.
+	switch v := c.load(); {
+	case v.mode != ModeNormal, v.state == Open, v.state == Closed && !c.mayTrip(v):
+		return
+	}
+
+	defer c.lockState()()
+
+	if c.state.mode != ModeNormal {
+		return
+	}
 
 	switch c.state.s {
 	case Closed:
-		if c.canTrip(c.summaryCopy()) {
-			c.state.s = Open
-			go c.waitHalfOpen()
+		if c.shouldTrip() {
+			c.open(ReasonFailureRate)
 		}
 
 	case HalfOpen:
 		switch c.fromHalfOpenToState(c.currentFrameCopy()) {
 		case Open:
-			if c.onHalfOpenTimeout.Load() {
-				return
-			}
-			go c.waitHalfOpen()
-
-			c.state.s = Open
-			c.popWindow()
+			c.open(ReasonHalfOpenFailure)
 
 		case Closed:
-			c.state.s = Closed
-			c.aggregateHalfOpenFrame()
+			c.close(ReasonProbeSuccess)
+		}
+	}
+}
+
+// close Moves an open or half-open circuit to closed, the state lock must be held.
+func (c *CircuitBreaker) close(reason Reason) {
+	switch c.state.s {
+	case HalfOpen:
+		c.setState(Closed, reason)
+		c.aggregateHalfOpenFrame()
+		c.cooldown.start(c.clock.Now())
+
+	case Open:
+		c.setState(Closed, reason)
+		c.catchUpFrames()
+	}
+}
+
+// untrip Closes the circuit regardless of the half-open predicate.
+func (c *CircuitBreaker) untrip(reason Reason) {
+	c.mutate(func() { c.close(reason) })
+}
+
+// open Moves a closed or half-open circuit to open, the state lock must be held.
+func (c *CircuitBreaker) open(reason Reason) {
+	switch c.state.s {
+	case Closed:
+		c.flapped()
+		c.setState(Open, reason)
+		c.waitHalfOpen()
+
+	case HalfOpen:
+		if c.actor == nil && c.onHalfOpenTimeout.Load() {
+			return
 		}
+		c.waitHalfOpen()
+
+		c.setState(Open, reason)
+		c.popWindow()
+	}
+}
+
+// trip Opens the circuit regardless of the trip predicate.
+func (c *CircuitBreaker) trip(reason Reason) {
+	c.mutate(func() { c.open(reason) })
+}
+
+// setState Changes the state and notifies the transition, the state lock must be held.
+func (c *CircuitBreaker) setState(to State, reason Reason) {
+	from := c.state.s
+	c.state.s = to
+	c.publish(func(v *view) {
+		v.state = to
+		if to != Open {
+			v.halfOpenAt = time.Time{}
+		}
+	})
+
+	if to == Open {
+		c.inflight.cancelAll()
+	}
+	if c.metrics != nil {
+		c.metrics.transition(from, to)
+	}
+
+	transition := Transition{
+		From:    from,
+		To:      to,
+		Reason:  reason,
+		Time:    c.clock.Now(),
+		Frame:   c.currentFrameCopy(),
+		Summary: c.summaryCopy(),
+	}
+	c.transitions.push(transition)
+
+	c.emit(Event{
+		Type:    EventTransition,
+		From:    transition.From,
+		To:      transition.To,
+		Reason:  transition.Reason,
+		Frame:   transition.Frame,
+		Summary: transition.Summary,
+	})
+
+	if c.fleet != nil {
+		go c.fleet.publish(to, reason)
+	}
+
+	if c.shared != nil {
+		c.shared.publish(to, reason)
+	}
+
+	if c.discovery != nil {
+		go c.discovery.publish()
 	}
 }
 
+// waitHalfOpen Schedules the move to half-open once the open duration elapses, rescheduling
+// the same timer when already pending.
 func (c *CircuitBreaker) waitHalfOpen() {
-	c.onHalfOpenTimeout.Store(true)
-	defer c.onHalfOpenTimeout.Store(false)
+	if c.actor == nil {
+		c.onHalfOpenTimeout.Store(true)
+	}
+	openDuration := c.jitter.apply(c.openDuration())
+	halfOpenAt := c.clock.Now().Add(openDuration)
+	c.publish(func(v *view) { v.halfOpenAt = halfOpenAt })
+	c.halfOpenTimer.Reset(openDuration)
+}
 
-	<-time.After(c.cfg.halfOpenTimeout)
+// TimeUntilHalfOpen Reports how long an open circuit keeps rejecting calls before probing,
+// zero when it isn't open.
+func (c *CircuitBreaker) TimeUntilHalfOpen() time.Duration {
+	v := c.load()
+	if v.state != Open {
+		return 0
+	}
 
-	c.state.mu.Lock()
-	defer c.state.mu.Unlock()
-	c.state.s = HalfOpen
+	if remaining := v.halfOpenAt.Sub(c.clock.Now()); remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+func (c *CircuitBreaker) halfOpen() {
+	// The actor applies the timer in order with the mutations, leaving only a timer that fired
+	// before the circuit reopened to skip.
+	if c.actor != nil && c.clock.Now().Before(c.load().halfOpenAt) {
+		return
+	}
+	if c.holdOpen() {
+		return
+	}
+	if c.actor == nil {
+		defer c.onHalfOpenTimeout.Store(false)
+	}
+
+	defer c.lockState()()
+	if c.state.s != Open {
+		return
+	}
+	c.toHalfOpen(ReasonOpenDurationElapsed)
+}
+
+// stopHalfOpenTimer Cancels the pending move to half-open, the state lock must be held.
+func (c *CircuitBreaker) stopHalfOpenTimer() {
+	if c.halfOpenTimer.Stop() {
+		c.onHalfOpenTimeout.Store(false)
+	}
+}
+
+// toHalfOpen Moves an open circuit to half-open, the state lock must be held.
+func (c *CircuitBreaker) toHalfOpen(reason Reason) {
+	c.setState(HalfOpen, reason)
 	c.addFrame()
+	c.halfOpenRate.fill(c.clock.Now())
+	c.ramp.reset(c.clock.Now())
+	c.openQueue.release()
 }
 
 func (c *CircuitBreaker) canExecute() error {
-	c.state.mu.RLock()
-	defer c.state.mu.RUnlock()
+	v := c.load()
 
-	if c.state.s == Open {
+	switch {
+	case v.mode == ModeForcedOpen:
+		return ErrOpenCircuit
+	case v.mode == ModeForcedClosed, v.mode == ModePaused:
+		return nil
+	case v.state == Open:
 		return ErrOpenCircuit
 	}
 
@@ -212,17 +788,53 @@ func (c *CircuitBreaker) canExecute() error {
 }
 
 func (c *CircuitBreaker) moveWindow() {
+	completed := c.currentFrameCopy()
+	if c.tuner != nil {
+		c.tuner.observe(completed)
+	}
 	c.decrSummary(c.unshiftFrame())
 	c.addFrame()
+
+	summary := c.summaryCopy()
+	if c.onFrameRoll != nil {
+		c.dispatcher.dispatch(func() { c.onFrameRoll(completed, summary) })
+	}
+
+	c.emit(Event{
+		Type:    EventFrameRoll,
+		Frame:   completed,
+		Summary: summary,
+	})
 }
 
+// aggregateHalfOpenFrame Folds the half-open frame into the current frame once the circuit
+// closes, only its rejections under separate trials.
 func (c *CircuitBreaker) aggregateHalfOpenFrame() {
 	halfOpenFrame := c.popFrame()
+	if c.separateTrials {
+		c.decrSummary(halfOpenFrame.Counts)
+		c.lastTrial.Store(&halfOpenFrame.Counts)
+	}
+	c.catchUpFrames()
+
 	c.rollingWindow.mu.Lock()
 	defer c.rollingWindow.mu.Unlock()
-	c.rollingWindow.window[(len(c.rollingWindow.window) - 1)].Total += halfOpenFrame.Total
-	c.rollingWindow.window[(len(c.rollingWindow.window) - 1)].Success += halfOpenFrame.Success
-	c.rollingWindow.window[(len(c.rollingWindow.window) - 1)].Fail += halfOpenFrame.Fail
+	c.rollingWindow.window[(len(c.rollingWindow.window) - 1)].rejected.Add(halfOpenFrame.rejected.Load())
+	if !c.separateTrials {
+		c.rollingWindow.window[(len(c.rollingWindow.window) - 1)].Counts = addCounts(c.rollingWindow.window[(len(c.rollingWindow.window)-1)].Counts, halfOpenFrame.Counts)
+		c.rollingWindow.window[(len(c.rollingWindow.window) - 1)].mergeLabels(halfOpenFrame.labels)
+		c.rollingWindow.window[(len(c.rollingWindow.window) - 1)].mergeMeasurements(halfOpenFrame.measurements)
+	}
+	c.publishFrame()
+}
+
+// catchUpFrames Rolls the frames that elapsed while the circuit wasn't closed, rolling more
+// frames than the window holds would only roll empty ones.
+func (c *CircuitBreaker) catchUpFrames() {
+	missed := min(c.missedFrames.Swap(0), int64(c.cfg.frames))
+	for i := int64(0); i < missed; i++ {
+		c.moveWindow()
+	}
 }
 
 // unshiftFrame Removes the first frame from the rolling window.
@@ -230,88 +842,123 @@ func (c *CircuitBreaker) unshiftFrame() Counts {
 	c.rollingWindow.mu.Lock()
 	defer c.rollingWindow.mu.Unlock()
 	defer func() {
-		c.rollingWindow.window = append(make([]Counts, 0, cap(c.rollingWindow.window)), c.rollingWindow.window[1:]...)
+		c.rollingWindow.window = append(make([]frame, 0, cap(c.rollingWindow.window)), c.rollingWindow.window[1:]...)
 	}()
 
-	return c.rollingWindow.window[0]
+	first := c.rollingWindow.window[0]
+	if c.frameHistory != nil {
+		end := c.clock.Now()
+		if len(c.rollingWindow.window) > 1 {
+			end = c.rollingWindow.window[1].start
+		}
+		c.frameHistory.push(Frame{
+			Counts:       first.Counts,
+			Labels:       first.labels.copy(),
+			Measurements: first.measurements.copy(),
+			Start:        first.start,
+			End:          end,
+			Complete:     true,
+		})
+	}
+
+	return first.Counts
+}
+
+// newWindow Creates the frames of an empty rolling window ending with the current frame.
+func newWindow(now time.Time, frames int, frameDuration time.Duration) []frame {
+	window := make([]frame, frames, (frames + 2))
+	for i := range window {
+		window[i] = newFrame(now.Add(-frameDuration * time.Duration(frames-1-i)))
+	}
+	return window
 }
 
 func (c *CircuitBreaker) addFrame() {
 	c.rollingWindow.mu.Lock()
 	defer c.rollingWindow.mu.Unlock()
-	c.rollingWindow.window = append(c.rollingWindow.window, Counts{})
+	c.rollingWindow.window = append(c.rollingWindow.window, newFrame(c.clock.Now()))
+	c.publishFrame()
 }
 
+// popWindow Discards the half-open frame, keeping its rejections on the frame before it.
 func (c *CircuitBreaker) popWindow() {
-	c.decrSummary(c.popFrame())
-}
+	halfOpenFrame := c.popFrame()
+	c.decrSummary(halfOpenFrame.Counts)
 
-// popFrame Removes the last frame from the rolling window.
-func (c *CircuitBreaker) popFrame() Counts {
 	c.rollingWindow.mu.Lock()
 	defer c.rollingWindow.mu.Unlock()
-	defer func() {
-		c.rollingWindow.window = c.rollingWindow.window[:(len(c.rollingWindow.window) - 1)]
-	}()
-
-	return c.rollingWindow.window[(len(c.rollingWindow.window) - 1)]
+	c.rollingWindow.window[(len(c.rollingWindow.window) - 1)].rejected.Add(halfOpenFrame.rejected.Load())
+	c.publishFrame()
 }
 
-func (c *CircuitBreaker) incrSuccess() {
+// popFrame Removes the last frame from the rolling window, the caller carrying its rejections
+// over.
+func (c *CircuitBreaker) popFrame() frame {
 	c.rollingWindow.mu.Lock()
-	c.summary.mu.Lock()
 	defer c.rollingWindow.mu.Unlock()
-	defer c.summary.mu.Unlock()
 
-	c.rollingWindow.window[(len(c.rollingWindow.window) - 1)].Total += 1
-	c.rollingWindow.window[(len(c.rollingWindow.window) - 1)].Success += 1
-	c.summary.counts.Total += 1
-	c.summary.counts.Success += 1
+	last := c.rollingWindow.window[(len(c.rollingWindow.window) - 1)]
+	c.rollingWindow.window = c.rollingWindow.window[:(len(c.rollingWindow.window) - 1)]
+	c.publishFrame()
+
+	return last
 }
 
-func (c *CircuitBreaker) incrFail() {
+// incr Accounts a call outcome weight times, a zero weight accounting it once, into the
+// current frame along with its measurements, the half-open frame while the circuit is
+// half-open, so both are kept or discarded together.
+func (c *CircuitBreaker) incr(outcome Outcome, labels map[string]string, weight uint64, measurements Measurements) {
 	c.rollingWindow.mu.Lock()
-	c.summary.mu.Lock()
 	defer c.rollingWindow.mu.Unlock()
-	defer c.summary.mu.Unlock()
 
-	c.rollingWindow.window[(len(c.rollingWindow.window) - 1)].Total += 1
-	c.rollingWindow.window[(len(c.rollingWindow.window) - 1)].Fail += 1
-	c.summary.counts.Fail += 1
-	c.summary.counts.Total += 1
+	weight = max(weight, 1)
+	incr := scaleCounts(outcome.counts(), weight)
+	now := c.clock.Now()
+	if c.strategy != nil {
+		c.strategy.Record(outcome, now, weight)
+	}
+	c.cooldown.record(incr, now)
+	c.expireStaleFrames(now)
+	c.rollingWindow.window[(len(c.rollingWindow.window) - 1)].Counts = addCounts(c.rollingWindow.window[(len(c.rollingWindow.window)-1)].Counts, incr)
+	c.rollingWindow.window[(len(c.rollingWindow.window)-1)].addLabels(labels, incr)
+	c.rollingWindow.window[(len(c.rollingWindow.window) - 1)].mergeMeasurements(measurements)
+	c.summary.counts = addCounts(c.summary.counts, incr)
+	c.publishFrame()
+	c.publishSummary()
 }
 
 func (c *CircuitBreaker) decrSummary(decr Counts) {
-	c.summary.mu.Lock()
-	defer c.summary.mu.Unlock()
+	c.rollingWindow.mu.Lock()
+	defer c.rollingWindow.mu.Unlock()
 
-	c.summary.counts.Fail -= decr.Fail
-	c.summary.counts.Success -= decr.Success
-	c.summary.counts.Total -= decr.Total
+	c.summary.counts = subCounts(c.summary.counts, decr)
+	c.publishSummary()
 }
 
 func (c *CircuitBreaker) stateCopy() State {
-	c.state.mu.RLock()
-	defer c.state.mu.RUnlock()
-	return c.state.s
+	return c.load().state
 }
 
 func (c *CircuitBreaker) summaryCopy() Counts {
-	c.summary.mu.RLock()
-	defer c.summary.mu.RUnlock()
-	return c.summary.counts
+	return c.load().summary
 }
 
 func (c *CircuitBreaker) currentFrameCopy() Counts {
-	c.rollingWindow.mu.RLock()
-	defer c.rollingWindow.mu.RUnlock()
-	return c.rollingWindow.window[(len(c.rollingWindow.window) - 1)]
+	return c.load().frame
+}
+
+// publishFrame Publishes the current frame counts, the rolling window lock must be held.
+func (c *CircuitBreaker) publishFrame() {
+	if len(c.rollingWindow.window) == 0 {
+		return
+	}
+
+	counts := c.rollingWindow.window[(len(c.rollingWindow.window) - 1)].Counts
+	c.publish(func(v *view) { v.frame = counts })
 }
 
-func (c *CircuitBreaker) windowCopy() []Counts {
-	c.rollingWindow.mu.RLock()
-	defer c.rollingWindow.mu.RUnlock()
-	cw := make([]Counts, len(c.rollingWindow.window), cap(c.rollingWindow.window))
-	copy(cw, c.rollingWindow.window)
-	return cw
+// publishSummary Publishes the summary counts, the rolling window lock must be held.
+func (c *CircuitBreaker) publishSummary() {
+	counts := c.summary.counts
+	c.publish(func(v *view) { v.summary = counts })
 }
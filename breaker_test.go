@@ -2,6 +2,8 @@ package breaker
 
 import (
 	"errors"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -17,7 +19,7 @@ func TestBreakerCreationSuccess(t *testing.T) {
 		summ              Counts
 		windowRoll        time.Duration
 		windowFrame       time.Duration
-		halfOpenTimeout   time.Duration
+		openDuration      time.Duration
 		window            []Counts
 	}
 
@@ -34,7 +36,7 @@ func TestBreakerCreationSuccess(t *testing.T) {
 				summ:              Counts{},
 				windowRoll:        time.Second * _windowRoll,
 				windowFrame:       time.Second * _windowFrame,
-				halfOpenTimeout:   time.Second * _halfOpenTimeout,
+				openDuration:      time.Second * _openDuration,
 				window:            make([]Counts, 20, 22),
 			},
 		},
@@ -43,14 +45,14 @@ func TestBreakerCreationSuccess(t *testing.T) {
 			input: []option{
 				WithWindowFrameThreshold(1000),
 				WithWindowRollThreshold(100000),
-				WithHalfOpenThreshold(10),
+				WithOpenDuration(10),
 			},
 			expected: expected{
 				onHalfOpenTimeout: false,
 				summ:              Counts{},
 				windowRoll:        time.Second * 100000,
 				windowFrame:       time.Second * 1000,
-				halfOpenTimeout:   time.Second * 10,
+				openDuration:      time.Second * 10,
 				window:            make([]Counts, 100, 102),
 			},
 		},
@@ -59,17 +61,61 @@ func TestBreakerCreationSuccess(t *testing.T) {
 			input: []option{
 				WithWindowFrameThreshold(222),
 				WithWindowRollThreshold(4759),
-				WithHalfOpenThreshold(21),
+				WithOpenDuration(21),
 			},
 			expected: expected{
 				onHalfOpenTimeout: false,
 				summ:              Counts{},
 				windowRoll:        time.Second * 4759,
 				windowFrame:       time.Second * 222,
-				halfOpenTimeout:   time.Second * 21,
+				openDuration:      time.Second * 21,
 				window:            make([]Counts, 21, 23),
 			},
 		},
+		{
+			name: "creates_with_window_and_frames",
+			input: []option{
+				WithWindow(5*time.Minute, 20),
+			},
+			expected: expected{
+				onHalfOpenTimeout: false,
+				summ:              Counts{},
+				windowRoll:        5 * time.Minute,
+				windowFrame:       15 * time.Second,
+				openDuration:      time.Second * _openDuration,
+				window:            make([]Counts, 20, 22),
+			},
+		},
+		{
+			name: "creates_with_window_overriding_thresholds",
+			input: []option{
+				WithWindowFrameThreshold(100),
+				WithWindow(time.Second, 4),
+				WithWindowRollThreshold(10),
+			},
+			expected: expected{
+				onHalfOpenTimeout: false,
+				summ:              Counts{},
+				windowRoll:        time.Second,
+				windowFrame:       250 * time.Millisecond,
+				openDuration:      time.Second * _openDuration,
+				window:            make([]Counts, 4, 6),
+			},
+		},
+		{
+			name: "creates_with_deprecated_half_open_threshold",
+			input: []option{
+				WithHalfOpenThreshold(10),
+			},
+			expected: expected{
+				onHalfOpenTimeout: false,
+				summ:              Counts{},
+				windowRoll:        time.Second * _windowRoll,
+				windowFrame:       time.Second * _windowFrame,
+				openDuration:      time.Second * 10,
+				window:            make([]Counts, 20, 22),
+			},
+		},
 		{
 			name: "creates_with_can_trip",
 			input: []option{
@@ -80,7 +126,7 @@ func TestBreakerCreationSuccess(t *testing.T) {
 				summ:              Counts{},
 				windowRoll:        time.Second * _windowRoll,
 				windowFrame:       time.Second * _windowFrame,
-				halfOpenTimeout:   time.Second * _halfOpenTimeout,
+				openDuration:      time.Second * _openDuration,
 				window:            make([]Counts, 20, 22),
 			},
 		},
@@ -94,7 +140,7 @@ func TestBreakerCreationSuccess(t *testing.T) {
 				summ:              Counts{},
 				windowRoll:        time.Second * _windowRoll,
 				windowFrame:       time.Second * _windowFrame,
-				halfOpenTimeout:   time.Second * _halfOpenTimeout,
+				openDuration:      time.Second * _openDuration,
 				window:            make([]Counts, 20, 22),
 			},
 		},
@@ -105,12 +151,12 @@ func TestBreakerCreationSuccess(t *testing.T) {
 			require.NoError(t, err)
 			cancel()
 
-			gotWindow := cb.windowCopy()
+			gotWindow := windowCountsHelper(cb)
 			assert.Equal(t, tc.expected.onHalfOpenTimeout, cb.onHalfOpenTimeout.Load())
 			assert.Equal(t, tc.expected.summ, cb.summaryCopy())
 			assert.Equal(t, tc.expected.windowRoll, cb.cfg.windowRoll)
 			assert.Equal(t, tc.expected.windowFrame, cb.cfg.windowFrame)
-			assert.Equal(t, tc.expected.halfOpenTimeout, cb.cfg.halfOpenTimeout)
+			assert.Equal(t, tc.expected.openDuration, cb.cfg.openDuration)
 			assert.ElementsMatch(t, tc.expected.window, gotWindow)
 			assert.Equal(t, len(tc.expected.window), len(gotWindow))
 			assert.Equal(t, cap(tc.expected.window), cap(gotWindow))
@@ -131,65 +177,521 @@ func TestBreakerCreationFails(t *testing.T) {
 			input: []option{
 				WithWindowFrameThreshold(100000),
 				WithWindowRollThreshold(1000),
-				WithHalfOpenThreshold(10),
+				WithOpenDuration(10),
 			},
-			expected: ErrNewCircuitBreaker,
+			expected: ErrInvalidWindowThreshold,
 		},
 		{
 			name: "fail_when_frame_threshold_is_zero",
 			input: []option{
 				WithWindowFrameThreshold(0),
 			},
-			expected: ErrNewCircuitBreaker,
+			expected: ErrInvalidWindowFrame,
 		},
 		{
 			name: "fail_when_frame_threshold_is_less_than_zero",
 			input: []option{
 				WithWindowFrameThreshold(-1000),
 			},
-			expected: ErrNewCircuitBreaker,
+			expected: ErrInvalidWindowFrame,
 		},
 		{
 			name: "fail_when_window_roll_threshold_is_zero",
 			input: []option{
 				WithWindowRollThreshold(0),
 			},
-			expected: ErrNewCircuitBreaker,
+			expected: ErrInvalidWindowRoll,
 		},
 		{
 			name: "fail_when_window_roll_threshold_is_less_than_zero",
 			input: []option{
 				WithWindowRollThreshold(-1000),
 			},
-			expected: ErrNewCircuitBreaker,
+			expected: ErrInvalidWindowRoll,
 		},
 		{
-			name: "fail_when_half_open_threshold_is_zero",
+			name: "fail_when_open_duration_is_zero",
+			input: []option{
+				WithOpenDuration(0),
+			},
+			expected: ErrInvalidOpenDuration,
+		},
+		{
+			name: "fail_when_open_duration_is_less_than_zero",
+			input: []option{
+				WithOpenDuration(-1000),
+			},
+			expected: ErrInvalidOpenDuration,
+		},
+		{
+			name: "fail_when_deprecated_half_open_threshold_is_zero",
 			input: []option{
 				WithHalfOpenThreshold(0),
 			},
-			expected: ErrNewCircuitBreaker,
+			expected: ErrInvalidHalfOpenThreshold,
+		},
+		{
+			name: "fail_when_window_frames_is_zero",
+			input: []option{
+				WithWindow(time.Minute, 0),
+			},
+			expected: ErrInvalidWindowThreshold,
+		},
+		{
+			name: "fail_when_window_frames_are_shorter_than_a_millisecond",
+			input: []option{
+				WithWindow(time.Millisecond, 2),
+			},
+			expected: ErrInvalidWindowThreshold,
+		},
+		{
+			name: "fail_when_leaky_bucket_capacity_is_zero",
+			input: []option{
+				WithLeakyBucket(0, time.Second),
+			},
+			expected: ErrInvalidLeakyBucket,
+		},
+		{
+			name: "fail_when_window_strategy_is_nil",
+			input: []option{
+				WithWindowStrategy(nil),
+			},
+			expected: ErrInvalidWindowStrategy,
+		},
+		{
+			name: "fail_when_half_open_rate_burst_is_zero",
+			input: []option{
+				WithHalfOpenRate(time.Second, 0),
+			},
+			expected: ErrInvalidHalfOpenRate,
+		},
+		{
+			name: "fail_when_half_open_evaluation_calls_is_zero",
+			input: []option{
+				WithHalfOpenEvaluation(0, 1),
+			},
+			expected: ErrInvalidHalfOpenEvaluation,
 		},
 		{
-			name: "fail_when_half_open_threshold_is_less_than_zero",
+			name: "fail_when_half_open_evaluation_failures_is_greater_than_calls",
 			input: []option{
-				WithHalfOpenThreshold(-1000),
+				WithHalfOpenEvaluation(2, 3),
 			},
-			expected: ErrNewCircuitBreaker,
+			expected: ErrInvalidHalfOpenEvaluation,
 		},
 		{
 			name: "fail_when_can_trip_callback_is_nil",
 			input: []option{
 				WithCanTrip(nil),
 			},
-			expected: ErrNewCircuitBreaker,
+			expected: ErrInvalidCanTrip,
 		},
 		{
 			name: "fail_when_from_half_open_to_state_callback_is_nil",
 			input: []option{
 				WithFromHalfOpenToState(nil),
 			},
-			expected: ErrNewCircuitBreaker,
+			expected: ErrInvalidFromHalfOpenToState,
+		},
+		{
+			name: "fail_when_on_frame_roll_callback_is_nil",
+			input: []option{
+				WithOnFrameRoll(nil),
+			},
+			expected: ErrInvalidOnFrameRoll,
+		},
+		{
+			name: "fail_when_deadline_percentile_is_zero",
+			input: []option{
+				WithDeadlineRejection(0),
+			},
+			expected: ErrInvalidDeadlinePercentile,
+		},
+		{
+			name: "fail_when_deadline_percentile_is_greater_than_hundred",
+			input: []option{
+				WithDeadlineRejection(100.1),
+			},
+			expected: ErrInvalidDeadlinePercentile,
+		},
+		{
+			name: "fail_when_low_shedding_rate_is_greater_than_normal",
+			input: []option{
+				WithPriorityShedding(60, 40),
+			},
+			expected: ErrInvalidPriorityShedding,
+		},
+		{
+			name: "fail_when_low_shedding_rate_is_zero",
+			input: []option{
+				WithPriorityShedding(0, 40),
+			},
+			expected: ErrInvalidPriorityShedding,
+		},
+		{
+			name: "fail_when_degraded_start_rate_is_hundred",
+			input: []option{
+				WithDegradedShedding(100, 50),
+			},
+			expected: ErrInvalidDegradedShedding,
+		},
+		{
+			name: "fail_when_degraded_max_shed_rate_is_zero",
+			input: []option{
+				WithDegradedShedding(20, 0),
+			},
+			expected: ErrInvalidDegradedShedding,
+		},
+		{
+			name: "fail_when_open_queue_size_is_zero",
+			input: []option{
+				WithOpenQueue(0, time.Second),
+			},
+			expected: ErrInvalidOpenQueue,
+		},
+		{
+			name: "fail_when_open_queue_max_wait_is_zero",
+			input: []option{
+				WithOpenQueue(1, 0),
+			},
+			expected: ErrInvalidOpenQueue,
+		},
+		{
+			name: "fail_when_interceptor_is_nil",
+			input: []option{
+				WithInterceptor(nil),
+			},
+			expected: ErrInvalidInterceptor,
+		},
+		{
+			name: "fail_when_name_is_empty",
+			input: []option{
+				WithName(""),
+			},
+			expected: ErrInvalidName,
+		},
+		{
+			name: "fail_when_fleet_has_no_name",
+			input: []option{
+				WithFleet("node-a", newFleetTransportHelper(), 1),
+			},
+			expected: ErrInvalidName,
+		},
+		{
+			name: "fail_when_fleet_quorum_is_zero",
+			input: []option{
+				WithName("payments"),
+				WithFleet("node-a", newFleetTransportHelper(), 0),
+			},
+			expected: ErrInvalidFleet,
+		},
+		{
+			name: "fail_when_shared_state_has_no_name",
+			input: []option{
+				WithSharedState(NewMemoryState()),
+			},
+			expected: ErrInvalidName,
+		},
+		{
+			name: "fail_when_classifier_is_nil",
+			input: []option{
+				WithClassifier(nil),
+			},
+			expected: ErrInvalidClassifier,
+		},
+		{
+			name: "fail_when_clock_is_nil",
+			input: []option{
+				WithClock(nil),
+			},
+			expected: ErrInvalidClock,
+		},
+		{
+			name: "fail_when_rule_pattern_is_malformed",
+			input: []option{
+				WithRules([]Rule{{Match: "payments.[", FailureRate: 30}}),
+			},
+			expected: ErrInvalidRules,
+		},
+		{
+			name: "fail_when_rule_failure_rate_is_out_of_range",
+			input: []option{
+				WithRules([]Rule{{Match: "payments.*", FailureRate: 130}}),
+			},
+			expected: ErrInvalidRules,
+		},
+		{
+			name: "fail_when_discovery_is_nil",
+			input: []option{
+				WithDiscovery(nil),
+			},
+			expected: ErrInvalidDiscovery,
+		},
+		{
+			name: "fail_when_observer_is_nil",
+			input: []option{
+				WithObserver(nil),
+			},
+			expected: ErrInvalidObserver,
+		},
+		{
+			name: "fail_when_measured_can_trip_is_nil",
+			input: []option{
+				WithMeasuredCanTrip(nil),
+			},
+			expected: ErrInvalidCanTrip,
+		},
+		{
+			name: "fail_when_store_is_nil",
+			input: []option{
+				WithStore(nil),
+			},
+			expected: ErrInvalidStore,
+		},
+		{
+			name: "fail_when_label_key_is_empty",
+			input: []option{
+				WithLabels(map[string]string{"": "checkout"}),
+			},
+			expected: ErrInvalidLabels,
+		},
+		{
+			name: "fail_when_adaptive_threshold_k_is_zero",
+			input: []option{
+				WithAdaptiveThreshold(0, 10, 60),
+			},
+			expected: ErrInvalidAdaptiveThreshold,
+		},
+		{
+			name: "fail_when_adaptive_threshold_bounds_are_inverted",
+			input: []option{
+				WithAdaptiveThreshold(3, 60, 10),
+			},
+			expected: ErrInvalidAdaptiveThreshold,
+		},
+		{
+			name: "fail_when_profile_schedule_is_malformed",
+			input: []option{
+				WithProfiles([]Profile{{Schedule: "* 9-17 * *", Thresholds: Thresholds{FailureRate: 20}}}),
+			},
+			expected: ErrInvalidSchedule,
+		},
+		{
+			name: "fail_when_profile_thresholds_are_invalid",
+			input: []option{
+				WithProfiles([]Profile{{Schedule: "* 9-17 * * 1-5", Thresholds: Thresholds{FailureRate: 120}}}),
+			},
+			expected: ErrInvalidThresholds,
+		},
+		{
+			name: "fail_when_throughput_collapse_ratio_is_not_a_fraction",
+			input: []option{
+				WithThroughputCollapse(1),
+			},
+			expected: ErrInvalidThroughputCollapse,
+		},
+		{
+			name: "fail_when_anomaly_z_is_zero",
+			input: []option{
+				WithAnomalyDetection(0),
+			},
+			expected: ErrInvalidAnomalyDetection,
+		},
+		{
+			name: "fail_when_trip_on_errors_is_empty",
+			input: []option{
+				WithTripOnErrors(),
+			},
+			expected: ErrInvalidTripOnErrors,
+		},
+		{
+			name: "fail_when_trip_on_errors_holds_nil",
+			input: []option{
+				WithTripOnErrors(errCall, nil),
+			},
+			expected: ErrInvalidTripOnErrors,
+		},
+		{
+			name: "fail_when_health_policy_is_unknown",
+			input: []option{
+				WithHealthPolicy("xor"),
+			},
+			expected: ErrInvalidHealthPolicy,
+		},
+		{
+			name: "fail_when_chaos_failure_rate_is_above_hundred",
+			input: []option{
+				WithChaos(120, 0),
+			},
+			expected: ErrInvalidChaos,
+		},
+		{
+			name: "fail_when_chaos_latency_is_negative",
+			input: []option{
+				WithChaos(10, -time.Second),
+			},
+			expected: ErrInvalidChaos,
+		},
+		{
+			name: "fail_when_hysteresis_recover_rate_reaches_trip_rate",
+			input: []option{
+				WithHysteresis(60, 60, 20),
+			},
+			expected: ErrInvalidHysteresis,
+		},
+		{
+			name: "fail_when_hysteresis_trial_is_empty",
+			input: []option{
+				WithHysteresis(60, 5, 0),
+			},
+			expected: ErrInvalidHysteresis,
+		},
+		{
+			name: "fail_when_recovery_cooldown_is_zero",
+			input: []option{
+				WithRecoveryCooldown(0),
+			},
+			expected: ErrInvalidRecoveryCooldown,
+		},
+		{
+			name: "fail_when_flap_detection_opens_is_one",
+			input: []option{
+				WithFlapDetection(1, time.Minute, time.Hour),
+			},
+			expected: ErrInvalidFlapDetection,
+		},
+		{
+			name: "fail_when_flap_detection_period_is_zero",
+			input: []option{
+				WithFlapDetection(3, 0, time.Hour),
+			},
+			expected: ErrInvalidFlapDetection,
+		},
+		{
+			name: "fail_when_failure_spike_multiplier_is_one",
+			input: []option{
+				WithFailureSpike(1, 20),
+			},
+			expected: ErrInvalidFailureSpike,
+		},
+		{
+			name: "fail_when_failure_spike_min_rate_is_zero",
+			input: []option{
+				WithFailureSpike(3, 0),
+			},
+			expected: ErrInvalidFailureSpike,
+		},
+		{
+			name: "fail_when_exponential_decay_factor_is_one",
+			input: []option{
+				WithExponentialDecay(1),
+			},
+			expected: ErrInvalidDecay,
+		},
+		{
+			name: "fail_when_actor_mailbox_is_zero",
+			input: []option{
+				WithActor(0),
+			},
+			expected: ErrInvalidActor,
+		},
+		{
+			name: "fail_when_summary_can_trip_is_nil",
+			input: []option{
+				WithSummaryCanTrip(nil),
+			},
+			expected: ErrInvalidCanTrip,
+		},
+		{
+			name: "fail_when_metrics_sink_is_nil",
+			input: []option{
+				WithMetricsSink(nil),
+			},
+			expected: ErrInvalidMetricsSink,
+		},
+		{
+			name: "fail_when_hystrix_error_percent_is_over_100",
+			input: []option{
+				WithHystrix(HystrixConfig{ErrorPercentThreshold: 101}),
+			},
+			expected: ErrInvalidHystrix,
+		},
+		{
+			name: "fail_when_key_func_is_nil",
+			input: []option{
+				WithKeyFunc(nil),
+			},
+			expected: ErrInvalidKeyFunc,
+		},
+		{
+			name: "fail_when_half_open_ramp_has_no_steps",
+			input: []option{
+				WithHalfOpenRamp(time.Second, 0),
+			},
+			expected: ErrInvalidHalfOpenRamp,
+		},
+		{
+			name: "fail_when_history_retention_is_zero",
+			input: []option{
+				WithHistoryRetention(0),
+			},
+			expected: ErrInvalidHistoryRetention,
+		},
+		{
+			name: "fail_when_jitter_is_one",
+			input: []option{
+				WithJitter(1),
+			},
+			expected: ErrInvalidJitter,
+		},
+		{
+			name: "fail_when_rand_source_is_nil",
+			input: []option{
+				WithRandSource(nil),
+			},
+			expected: ErrInvalidRandSource,
+		},
+		{
+			name: "fail_when_store_has_no_name",
+			input: []option{
+				WithStore(NewMemoryStore()),
+			},
+			expected: ErrInvalidName,
+		},
+		{
+			name: "fail_when_discovery_has_no_name",
+			input: []option{
+				WithDiscovery(newRecordingDiscovery()),
+			},
+			expected: ErrInvalidName,
+		},
+		{
+			name: "fail_when_event_queue_is_zero",
+			input: []option{
+				WithEventQueue(0),
+			},
+			expected: ErrInvalidEventQueue,
+		},
+		{
+			name: "fail_when_rejection_error_callback_is_nil",
+			input: []option{
+				WithRejectionError(nil),
+			},
+			expected: ErrInvalidRejectionError,
+		},
+		{
+			name: "fail_when_fail_silent_recorder_is_nil",
+			input: []option{
+				WithFailSilent(nil),
+			},
+			expected: ErrInvalidFailSilent,
+		},
+		{
+			name: "fail_when_error_history_is_zero",
+			input: []option{
+				WithErrorHistory(0),
+			},
+			expected: ErrInvalidErrorHistory,
 		},
 	}
 	for _, tc := range tt {
@@ -198,6 +700,7 @@ func TestBreakerCreationFails(t *testing.T) {
 
 			assert.Nil(t, cb)
 			assert.Nil(t, cancel)
+			assert.ErrorIs(t, err, ErrNewCircuitBreaker)
 			assert.ErrorIs(t, err, tc.expected)
 		})
 	}
@@ -215,7 +718,7 @@ func TestBreakerOpen(t *testing.T) {
 	cb, cancel, err := New(
 		WithWindowFrameThreshold(1000),
 		WithWindowRollThreshold(100000),
-		WithHalfOpenThreshold(10),
+		WithOpenDuration(10),
 	)
 	require.NoError(t, err)
 	defer cancel()
@@ -227,7 +730,7 @@ func TestBreakerOpen(t *testing.T) {
 
 	syncFeedCircuitBreakerHelper(cb, calls, false)
 
-	gotWindow := cb.windowCopy()
+	gotWindow := windowCountsHelper(cb)
 	assert.Equal(t, Open, cb.stateCopy())
 	assert.Equal(t, expectedCounts, cb.summaryCopy())
 	assert.Equal(t, expectedWindow[99], cb.currentFrameCopy())
@@ -236,6 +739,23 @@ func TestBreakerOpen(t *testing.T) {
 	assert.Equal(t, cap(expectedWindow), cap(gotWindow))
 }
 
+func TestBreakerCanTripOncePerCall(t *testing.T) {
+	var calls atomic.Int64
+	cb, cancel, err := New(WithCanTrip(func(summary Counts) bool {
+		calls.Add(1)
+		return summary.Fail >= 3
+	}))
+	require.NoError(t, err)
+	defer cancel()
+
+	syncFeedCircuitBreakerHelper(cb, []error{nil, nil, nil}, false)
+	assert.Zero(t, calls.Load())
+
+	syncFeedCircuitBreakerHelper(cb, []error{errCall, nil, errCall, errCall}, false)
+	assert.Equal(t, int64(4), calls.Load())
+	assert.Equal(t, Open, cb.State())
+}
+
 func TestBreakerClosedToHalfOpen(t *testing.T) {
 	calls := []error{
 		errCall, errCall, errCall, errCall, errCall, errCall, errCall,
@@ -253,16 +773,16 @@ func TestBreakerClosedToHalfOpen(t *testing.T) {
 	cb, cancel, err := New(
 		WithWindowFrameThreshold(10),
 		WithWindowRollThreshold(30),
-		WithHalfOpenThreshold(2),
+		WithOpenDuration(2),
 	)
 	require.NoError(t, err)
 	defer cancel()
 
 	syncFeedCircuitBreakerHelper(cb, calls, false)
 
-	time.Sleep(cb.cfg.halfOpenTimeout + (time.Millisecond * 500))
+	time.Sleep(cb.cfg.openDuration + (time.Millisecond * 500))
 
-	gotWindow := cb.windowCopy()
+	gotWindow := windowCountsHelper(cb)
 	assert.Equal(t, HalfOpen, cb.stateCopy())
 	assert.Equal(t, expectedCounts, cb.summaryCopy())
 	assert.ElementsMatch(t, expectedWindow, gotWindow)
@@ -290,7 +810,7 @@ func TestBreakerHalfOpenToOpen(t *testing.T) {
 	cb, cancel, err := New(
 		WithWindowFrameThreshold(10),
 		WithWindowRollThreshold(30),
-		WithHalfOpenThreshold(2),
+		WithOpenDuration(2),
 	)
 	require.NoError(t, err)
 	defer cancel()
@@ -299,13 +819,13 @@ func TestBreakerHalfOpenToOpen(t *testing.T) {
 
 	assert.Equal(t, Open, cb.stateCopy())
 
-	time.Sleep(cb.cfg.halfOpenTimeout + (time.Millisecond * 500))
+	time.Sleep(cb.cfg.openDuration + (time.Millisecond * 500))
 
 	assert.Equal(t, HalfOpen, cb.stateCopy())
 
 	err = cb.Execute(fixtureCircuitCall(errCall))
 
-	gotWindow := cb.windowCopy()
+	gotWindow := windowCountsHelper(cb)
 	assert.Equal(t, Open, cb.stateCopy())
 	assert.Equal(t, expectedCounts, cb.summary.counts)
 	assert.ElementsMatch(t, expectedWindow, gotWindow)
@@ -332,7 +852,7 @@ func TestBreakerHalfOpenToClosed(t *testing.T) {
 	cb, cancel, err := New(
 		WithWindowFrameThreshold(10),
 		WithWindowRollThreshold(30),
-		WithHalfOpenThreshold(2),
+		WithOpenDuration(2),
 	)
 	require.NoError(t, err)
 	defer cancel()
@@ -343,7 +863,7 @@ func TestBreakerHalfOpenToClosed(t *testing.T) {
 	assert.Equal(t, Open, cb.stateCopy())
 
 	// wait for half open
-	time.Sleep(cb.cfg.halfOpenTimeout + (time.Millisecond * 500))
+	time.Sleep(cb.cfg.openDuration + (time.Millisecond * 500))
 
 	assert.Equal(t, HalfOpen, cb.stateCopy())
 
@@ -351,11 +871,11 @@ func TestBreakerHalfOpenToClosed(t *testing.T) {
 	feedCircuitBreakerHelper(cb, halfOpenCalls, false)
 
 	// wait for close
-	time.Sleep(cb.cfg.halfOpenTimeout + (time.Millisecond * 500))
+	time.Sleep(cb.cfg.openDuration + (time.Millisecond * 500))
 
 	t.Logf("totals:\t%+v\n", cb.summaryCopy())
 
-	gotWindow := cb.windowCopy()
+	gotWindow := windowCountsHelper(cb)
 	assert.Equal(t, Closed, cb.stateCopy())
 	assert.Equal(t, expectedCounts, cb.summaryCopy())
 	assert.ElementsMatch(t, expectedWindow, gotWindow)
@@ -364,19 +884,106 @@ func TestBreakerHalfOpenToClosed(t *testing.T) {
 	assert.ErrorIs(t, err, nil)
 }
 
+func TestBreakerHalfOpenEvaluation(t *testing.T) {
+	tt := []struct {
+		name          string
+		halfOpenCalls []error
+		expected      State
+	}{
+		{
+			name:          "stays_half_open_under_calls",
+			halfOpenCalls: []error{errCall, nil},
+			expected:      HalfOpen,
+		},
+		{
+			name:          "closes_after_calls",
+			halfOpenCalls: []error{errCall, nil, nil},
+			expected:      Closed,
+		},
+		{
+			name:          "reopens_after_failures",
+			halfOpenCalls: []error{errCall, errCall},
+			expected:      Open,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			cb, cancel, err := New(
+				WithOpenDuration(1),
+				WithHalfOpenEvaluation(3, 2),
+			)
+			require.NoError(t, err)
+			defer cancel()
+
+			cb.Trip()
+			time.Sleep(cb.cfg.openDuration + (time.Millisecond * 200))
+			require.Equal(t, HalfOpen, cb.stateCopy())
+
+			syncFeedCircuitBreakerHelper(cb, tc.halfOpenCalls, false)
+			assert.Equal(t, tc.expected, cb.stateCopy())
+		})
+	}
+}
+
+func TestBreakerCancelStopsHalfOpenTimer(t *testing.T) {
+	cb, cancel, err := New(WithOpenDuration(1))
+	require.NoError(t, err)
+
+	cb.Trip()
+	assert.True(t, cb.onHalfOpenTimeout.Load())
+	cancel()
+
+	time.Sleep(cb.cfg.openDuration + (time.Millisecond * 200))
+	assert.Equal(t, Open, cb.stateCopy())
+}
+
+func TestBreakerCancelIsIdempotent(t *testing.T) {
+	cb, cancel, err := New(
+		WithWindowFrameThreshold(1),
+		WithWindowRollThreshold(3),
+		WithOpenDuration(1),
+	)
+	require.NoError(t, err)
+
+	cb.Trip()
+	time.Sleep(cb.cfg.windowFrame + (time.Millisecond * 200))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 3; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				cancel()
+			}()
+		}
+		wg.Wait()
+		cancel()
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("cancel blocked")
+	}
+}
+
 func TestBreakerWindowRollSize(t *testing.T) {
 	expectedWindow := make([]Counts, 10, 12)
 	cb, cancel, err := New(
 		WithWindowFrameThreshold(1),
 		WithWindowRollThreshold(10),
-		WithHalfOpenThreshold(2),
+		WithOpenDuration(2),
 	)
 	defer cancel()
 	require.NoError(t, err)
 
 	time.Sleep(time.Second * 20)
 
-	gotWindow := cb.windowCopy()
+	gotWindow := windowCountsHelper(cb)
 	assert.Equal(t, len(expectedWindow), len(gotWindow))
 	assert.Equal(t, cap(expectedWindow), cap(gotWindow))
 }
@@ -402,7 +1009,7 @@ func TestBreakerWindowRoll(t *testing.T) {
 	cb, cancel, err := New(
 		WithWindowFrameThreshold(1),
 		WithWindowRollThreshold(3),
-		WithHalfOpenThreshold(2),
+		WithOpenDuration(2),
 	)
 	require.NoError(t, err)
 	defer cancel()
@@ -412,7 +1019,7 @@ func TestBreakerWindowRoll(t *testing.T) {
 		time.Sleep(cb.cfg.windowFrame + (time.Millisecond * 500))
 	}
 
-	gotWindow := cb.windowCopy()
+	gotWindow := windowCountsHelper(cb)
 	assert.Equal(t, Closed, cb.stateCopy())
 	assert.Equal(t, expectedCounts, cb.summaryCopy())
 	assert.ElementsMatch(t, expectedWindow, gotWindow)
@@ -0,0 +1,257 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: admin.proto
+
+package adminpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	BreakerAdmin_ListBreakers_FullMethodName = "/breaker.admin.v1.BreakerAdmin/ListBreakers"
+	BreakerAdmin_GetStatus_FullMethodName    = "/breaker.admin.v1.BreakerAdmin/GetStatus"
+	BreakerAdmin_Trip_FullMethodName         = "/breaker.admin.v1.BreakerAdmin/Trip"
+	BreakerAdmin_Reset_FullMethodName        = "/breaker.admin.v1.BreakerAdmin/Reset"
+	BreakerAdmin_SetMode_FullMethodName      = "/breaker.admin.v1.BreakerAdmin/SetMode"
+)
+
+// BreakerAdminClient is the client API for BreakerAdmin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type BreakerAdminClient interface {
+	ListBreakers(ctx context.Context, in *ListBreakersRequest, opts ...grpc.CallOption) (*ListBreakersResponse, error)
+	GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*BreakerStatus, error)
+	Trip(ctx context.Context, in *TripRequest, opts ...grpc.CallOption) (*BreakerStatus, error)
+	Reset(ctx context.Context, in *ResetRequest, opts ...grpc.CallOption) (*BreakerStatus, error)
+	SetMode(ctx context.Context, in *SetModeRequest, opts ...grpc.CallOption) (*BreakerStatus, error)
+}
+
+type breakerAdminClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBreakerAdminClient(cc grpc.ClientConnInterface) BreakerAdminClient {
+	return &breakerAdminClient{cc}
+}
+
+func (c *breakerAdminClient) ListBreakers(ctx context.Context, in *ListBreakersRequest, opts ...grpc.CallOption) (*ListBreakersResponse, error) {
+	out := new(ListBreakersResponse)
+	err := c.cc.Invoke(ctx, BreakerAdmin_ListBreakers_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *breakerAdminClient) GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*BreakerStatus, error) {
+	out := new(BreakerStatus)
+	err := c.cc.Invoke(ctx, BreakerAdmin_GetStatus_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *breakerAdminClient) Trip(ctx context.Context, in *TripRequest, opts ...grpc.CallOption) (*BreakerStatus, error) {
+	out := new(BreakerStatus)
+	err := c.cc.Invoke(ctx, BreakerAdmin_Trip_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *breakerAdminClient) Reset(ctx context.Context, in *ResetRequest, opts ...grpc.CallOption) (*BreakerStatus, error) {
+	out := new(BreakerStatus)
+	err := c.cc.Invoke(ctx, BreakerAdmin_Reset_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *breakerAdminClient) SetMode(ctx context.Context, in *SetModeRequest, opts ...grpc.CallOption) (*BreakerStatus, error) {
+	out := new(BreakerStatus)
+	err := c.cc.Invoke(ctx, BreakerAdmin_SetMode_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BreakerAdminServer is the server API for BreakerAdmin service.
+// All implementations must embed UnimplementedBreakerAdminServer
+// for forward compatibility
+type BreakerAdminServer interface {
+	ListBreakers(context.Context, *ListBreakersRequest) (*ListBreakersResponse, error)
+	GetStatus(context.Context, *GetStatusRequest) (*BreakerStatus, error)
+	Trip(context.Context, *TripRequest) (*BreakerStatus, error)
+	Reset(context.Context, *ResetRequest) (*BreakerStatus, error)
+	SetMode(context.Context, *SetModeRequest) (*BreakerStatus, error)
+	mustEmbedUnimplementedBreakerAdminServer()
+}
+
+// UnimplementedBreakerAdminServer must be embedded to have forward compatible implementations.
+type UnimplementedBreakerAdminServer struct {
+}
+
+func (UnimplementedBreakerAdminServer) ListBreakers(context.Context, *ListBreakersRequest) (*ListBreakersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBreakers not implemented")
+}
+func (UnimplementedBreakerAdminServer) GetStatus(context.Context, *GetStatusRequest) (*BreakerStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStatus not implemented")
+}
+func (UnimplementedBreakerAdminServer) Trip(context.Context, *TripRequest) (*BreakerStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Trip not implemented")
+}
+func (UnimplementedBreakerAdminServer) Reset(context.Context, *ResetRequest) (*BreakerStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reset not implemented")
+}
+func (UnimplementedBreakerAdminServer) SetMode(context.Context, *SetModeRequest) (*BreakerStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMode not implemented")
+}
+func (UnimplementedBreakerAdminServer) mustEmbedUnimplementedBreakerAdminServer() {}
+
+// UnsafeBreakerAdminServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BreakerAdminServer will
+// result in compilation errors.
+type UnsafeBreakerAdminServer interface {
+	mustEmbedUnimplementedBreakerAdminServer()
+}
+
+func RegisterBreakerAdminServer(s grpc.ServiceRegistrar, srv BreakerAdminServer) {
+	s.RegisterService(&BreakerAdmin_ServiceDesc, srv)
+}
+
+func _BreakerAdmin_ListBreakers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBreakersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BreakerAdminServer).ListBreakers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BreakerAdmin_ListBreakers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BreakerAdminServer).ListBreakers(ctx, req.(*ListBreakersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BreakerAdmin_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BreakerAdminServer).GetStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BreakerAdmin_GetStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BreakerAdminServer).GetStatus(ctx, req.(*GetStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BreakerAdmin_Trip_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TripRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BreakerAdminServer).Trip(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BreakerAdmin_Trip_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BreakerAdminServer).Trip(ctx, req.(*TripRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BreakerAdmin_Reset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BreakerAdminServer).Reset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BreakerAdmin_Reset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BreakerAdminServer).Reset(ctx, req.(*ResetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BreakerAdmin_SetMode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetModeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BreakerAdminServer).SetMode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BreakerAdmin_SetMode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BreakerAdminServer).SetMode(ctx, req.(*SetModeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// BreakerAdmin_ServiceDesc is the grpc.ServiceDesc for BreakerAdmin service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BreakerAdmin_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "breaker.admin.v1.BreakerAdmin",
+	HandlerType: (*BreakerAdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListBreakers",
+			Handler:    _BreakerAdmin_ListBreakers_Handler,
+		},
+		{
+			MethodName: "GetStatus",
+			Handler:    _BreakerAdmin_GetStatus_Handler,
+		},
+		{
+			MethodName: "Trip",
+			Handler:    _BreakerAdmin_Trip_Handler,
+		},
+		{
+			MethodName: "Reset",
+			Handler:    _BreakerAdmin_Reset_Handler,
+		},
+		{
+			MethodName: "SetMode",
+			Handler:    _BreakerAdmin_SetMode_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "admin.proto",
+}
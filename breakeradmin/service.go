@@ -0,0 +1,93 @@
+// Package breakeradmin Implements the management operations of admin.proto over a breaker.Registry,
+// transport bindings only translate messages and delegate to Service.
+package breakeradmin
+
+//go:generate protoc --go_out=adminpb --go_opt=paths=source_relative --go-grpc_out=adminpb --go-grpc_opt=paths=source_relative admin.proto
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	breaker "github.com/gilbertovgl/go-circuit-breaker"
+)
+
+var ErrBreakerNotFound = errors.New("breaker not found")
+
+type Status struct {
+	Name   string         `json:"name"`
+	State  breaker.State  `json:"state"`
+	Mode   breaker.Mode   `json:"mode"`
+	Counts breaker.Counts `json:"counts"`
+}
+
+type Service struct {
+	registry *breaker.Registry
+}
+
+func NewService(registry *breaker.Registry) *Service {
+	return &Service{registry: registry}
+}
+
+func (s *Service) ListBreakers(ctx context.Context) ([]Status, error) {
+	breakers := s.registry.List()
+	statuses := make([]Status, 0, len(breakers))
+	for _, cb := range breakers {
+		statuses = append(statuses, status(cb))
+	}
+	return statuses, nil
+}
+
+func (s *Service) GetStatus(ctx context.Context, name string) (Status, error) {
+	cb, err := s.get(name)
+	if err != nil {
+		return Status{}, err
+	}
+	return status(cb), nil
+}
+
+func (s *Service) Trip(ctx context.Context, name string) (Status, error) {
+	cb, err := s.get(name)
+	if err != nil {
+		return Status{}, err
+	}
+	cb.Trip()
+	return status(cb), nil
+}
+
+func (s *Service) Reset(ctx context.Context, name string) (Status, error) {
+	cb, err := s.get(name)
+	if err != nil {
+		return Status{}, err
+	}
+	cb.Reset()
+	return status(cb), nil
+}
+
+func (s *Service) SetMode(ctx context.Context, name string, mode breaker.Mode) (Status, error) {
+	cb, err := s.get(name)
+	if err != nil {
+		return Status{}, err
+	}
+	if err = cb.SetMode(mode); err != nil {
+		return Status{}, err
+	}
+	return status(cb), nil
+}
+
+func (s *Service) get(name string) (*breaker.CircuitBreaker, error) {
+	cb, ok := s.registry.Get(name)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrBreakerNotFound, name)
+	}
+	return cb, nil
+}
+
+func status(cb *breaker.CircuitBreaker) Status {
+	return Status{
+		Name:   cb.Name(),
+		State:  cb.State(),
+		Mode:   cb.Mode(),
+		Counts: cb.Counts(),
+	}
+}
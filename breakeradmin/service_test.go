@@ -0,0 +1,51 @@
+package breakeradmin
+
+import (
+	"context"
+	"testing"
+
+	breaker "github.com/gilbertovgl/go-circuit-breaker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService(t *testing.T) {
+	registry := breaker.NewRegistry()
+	for _, name := range []string{"payments", "orders"} {
+		cb, cancel, err := breaker.New(breaker.WithName(name))
+		require.NoError(t, err)
+		t.Cleanup(cancel)
+		require.NoError(t, registry.Register(cb))
+	}
+	svc := NewService(registry)
+	ctx := context.Background()
+
+	statuses, err := svc.ListBreakers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []Status{
+		{Name: "orders", State: breaker.Closed, Mode: breaker.ModeNormal},
+		{Name: "payments", State: breaker.Closed, Mode: breaker.ModeNormal},
+	}, statuses)
+
+	got, err := svc.Trip(ctx, "payments")
+	require.NoError(t, err)
+	assert.Equal(t, breaker.Open, got.State)
+
+	got, err = svc.Reset(ctx, "payments")
+	require.NoError(t, err)
+	assert.Equal(t, breaker.Closed, got.State)
+
+	got, err = svc.SetMode(ctx, "orders", breaker.ModeForcedOpen)
+	require.NoError(t, err)
+	assert.Equal(t, breaker.ModeForcedOpen, got.Mode)
+
+	got, err = svc.GetStatus(ctx, "orders")
+	require.NoError(t, err)
+	assert.Equal(t, Status{Name: "orders", State: breaker.Closed, Mode: breaker.ModeForcedOpen}, got)
+
+	_, err = svc.SetMode(ctx, "orders", "sideways")
+	assert.ErrorIs(t, err, breaker.ErrInvalidMode)
+
+	_, err = svc.GetStatus(ctx, "missing")
+	assert.ErrorIs(t, err, ErrBreakerNotFound)
+}
@@ -0,0 +1,58 @@
+// Package breakerconsul Publishes breaker states into Consul so the routers and meshes
+// reading it can see which dependencies an instance considers down.
+package breakerconsul
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	breaker "github.com/gilbertovgl/go-circuit-breaker"
+)
+
+// KV Is a breaker.Discovery writing every state to the Consul KV store under
+// <Prefix>/<dependency> through the HTTP API.
+type KV struct {
+	// Address Is the Consul agent base URL, such as http://127.0.0.1:8500.
+	Address string
+	// Prefix Namespaces the keys, usually by service and instance.
+	Prefix string
+	// Token Is sent as the ACL token when set.
+	Token string
+	// Client Defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+var _ breaker.Discovery = (*KV)(nil)
+
+func (k *KV) Publish(ctx context.Context, dependency string, state breaker.State) error {
+	key := path.Join(k.Prefix, dependency)
+	endpoint := strings.TrimSuffix(k.Address, "/") + "/v1/kv/" + (&url.URL{Path: key}).EscapedPath()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, endpoint, strings.NewReader(string(state)))
+	if err != nil {
+		return err
+	}
+	if k.Token != "" {
+		req.Header.Set("X-Consul-Token", k.Token)
+	}
+
+	client := k.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("consul kv put %s: %s", key, resp.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package breakerconsul
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	breaker "github.com/gilbertovgl/go-circuit-breaker"
+)
+
+func TestKVPublish(t *testing.T) {
+	var gotPath, gotToken, gotBody string
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		body, _ := io.ReadAll(r.Body)
+		gotPath, gotToken, gotBody = r.URL.Path, r.Header.Get("X-Consul-Token"), string(body)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	kv := &KV{Address: server.URL + "/", Prefix: "checkout/instance-1/breakers", Token: "secret"}
+	require.NoError(t, kv.Publish(context.Background(), "payments", breaker.Open))
+	assert.Equal(t, "/v1/kv/checkout/instance-1/breakers/payments", gotPath)
+	assert.Equal(t, "secret", gotToken)
+	assert.Equal(t, "open", gotBody)
+
+	status = http.StatusForbidden
+	assert.Error(t, kv.Publish(context.Background(), "payments", breaker.Closed))
+}
@@ -0,0 +1,170 @@
+// Package breakerecho Guards Echo routes with circuit breakers, kept in its own module so the
+// core package doesn't depend on Echo.
+package breakerecho
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	breaker "github.com/gilbertovgl/go-circuit-breaker"
+)
+
+var (
+	ErrInvalidSource       = errors.New("invalid breaker source")
+	ErrInvalidKey          = errors.New("invalid key")
+	ErrInvalidErrorHandler = errors.New("invalid error handler")
+	ErrInvalidFailure      = errors.New("invalid failure predicate")
+	ErrInvalidRejection    = errors.New("invalid rejection status")
+)
+
+// Source Resolves the breaker guarding the requests under key, a breaker.Picker keeps one
+// per key and Single shares one across every key.
+type Source interface {
+	Breaker(key string) (*breaker.CircuitBreaker, error)
+}
+
+type single struct {
+	cb *breaker.CircuitBreaker
+}
+
+func (s single) Breaker(string) (*breaker.CircuitBreaker, error) {
+	return s.cb, nil
+}
+
+// Single Guards every route with cb.
+func Single(cb *breaker.CircuitBreaker) Source {
+	return single{cb: cb}
+}
+
+type Option func(cfg *config) error
+
+type config struct {
+	key             func(c echo.Context) string
+	errorHandler    func(c echo.Context, cb *breaker.CircuitBreaker, err error) error
+	failure         func(status int) bool
+	rejectionStatus int
+}
+
+// WithKey Sets which breaker of the source guards a request, the route pattern such as
+// /users/:id by default so every route gets its own. Keying by upstream instead, such as
+// from a path parameter, shares a breaker across the routes calling it.
+func WithKey(key func(c echo.Context) string) Option {
+	return func(cfg *config) error {
+		if key == nil {
+			return fmt.Errorf("%w: can't be <nil>", ErrInvalidKey)
+		}
+		cfg.key = key
+		return nil
+	}
+}
+
+// WithErrorHandler Sets what the requests the breaker turns away return. By default they get
+// a Retry-After header and an echo.HTTPError with the rejection status.
+func WithErrorHandler(handler func(c echo.Context, cb *breaker.CircuitBreaker, err error) error) Option {
+	return func(cfg *config) error {
+		if handler == nil {
+			return fmt.Errorf("%w: can't be <nil>", ErrInvalidErrorHandler)
+		}
+		cfg.errorHandler = handler
+		return nil
+	}
+}
+
+// WithFailureStatus Sets which response statuses count as failures, the 5xx by default.
+func WithFailureStatus(failure func(status int) bool) Option {
+	return func(cfg *config) error {
+		if failure == nil {
+			return fmt.Errorf("%w: can't be <nil>", ErrInvalidFailure)
+		}
+		cfg.failure = failure
+		return nil
+	}
+}
+
+// WithRejectionStatus Sets the status code the default error handler answers with, 503
+// Service Unavailable by default.
+func WithRejectionStatus(code int) Option {
+	return func(cfg *config) error {
+		if code < 100 || code > 599 {
+			return fmt.Errorf("%w: %d isn't a status code", ErrInvalidRejection, code)
+		}
+		cfg.rejectionStatus = code
+		return nil
+	}
+}
+
+// Middleware Runs the requests through the breaker source resolves for their key, counting
+// the failure statuses as failures. The status of a handler returning an error is the one
+// Echo answers with, that of the echo.HTTPError or 500 Internal Server Error.
+func Middleware(source Source, opts ...Option) (echo.MiddlewareFunc, error) {
+	if source == nil {
+		return nil, fmt.Errorf("%w: can't be <nil>", ErrInvalidSource)
+	}
+
+	cfg := &config{
+		key:             func(c echo.Context) string { return c.Path() },
+		failure:         func(status int) bool { return status >= http.StatusInternalServerError },
+		rejectionStatus: http.StatusServiceUnavailable,
+	}
+	cfg.errorHandler = cfg.defaultErrorHandler
+	for _, opt := range opts {
+		if err := opt(cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			cb, err := source.Breaker(cfg.key(c))
+			if err != nil {
+				return err
+			}
+
+			var handlerErr error
+			ran := false
+			err = cb.ExecuteContext(breaker.ContextWithDecision(c.Request().Context()), func(ctx context.Context) error {
+				ran = true
+				c.SetRequest(c.Request().WithContext(ctx))
+				handlerErr = next(c)
+				if cfg.failure(status(c, handlerErr)) {
+					return breaker.Classified(breaker.OutcomeFailure, nil)
+				}
+				return nil
+			})
+			if !ran {
+				if err == nil {
+					err = breaker.ErrProbeCoalesced
+				}
+				return cfg.errorHandler(c, cb, err)
+			}
+			return handlerErr
+		}
+	}, nil
+}
+
+func status(c echo.Context, err error) int {
+	if err == nil {
+		return c.Response().Status
+	}
+
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Code
+	}
+	return http.StatusInternalServerError
+}
+
+func (cfg *config) defaultErrorHandler(c echo.Context, cb *breaker.CircuitBreaker, err error) error {
+	seconds := int(math.Ceil(cb.TimeUntilHalfOpen().Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	c.Response().Header().Set("Retry-After", strconv.Itoa(seconds))
+	return echo.NewHTTPError(cfg.rejectionStatus, err.Error())
+}
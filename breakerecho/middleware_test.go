@@ -0,0 +1,156 @@
+package breakerecho
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	breaker "github.com/gilbertovgl/go-circuit-breaker"
+)
+
+func serve(e *echo.Echo, path string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	return rec
+}
+
+func TestMiddlewarePerRoute(t *testing.T) {
+	picker := breaker.NewPicker(
+		breaker.WithCanTrip(func(summary breaker.Counts) bool { return summary.Fail > 0 }),
+	)
+	defer picker.Close()
+
+	middleware, err := Middleware(picker)
+	require.NoError(t, err)
+
+	e := echo.New()
+	e.Use(middleware)
+	e.GET("/users/:id", func(c echo.Context) error { return errors.New("database down") })
+	e.GET("/health", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+
+	assert.Equal(t, http.StatusInternalServerError, serve(e, "/users/1").Code)
+
+	rec := serve(e, "/users/2")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.NotEmpty(t, rec.Header().Get("Retry-After"))
+
+	assert.Equal(t, http.StatusOK, serve(e, "/health").Code)
+
+	cb, err := picker.Breaker("/users/:id")
+	require.NoError(t, err)
+	assert.Equal(t, breaker.Open, cb.State())
+}
+
+func TestMiddlewareClientErrorsSucceed(t *testing.T) {
+	cb, cancel, err := breaker.New(
+		breaker.WithCanTrip(func(summary breaker.Counts) bool { return summary.Fail > 0 }),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	middleware, err := Middleware(Single(cb))
+	require.NoError(t, err)
+
+	e := echo.New()
+	e.Use(middleware)
+	e.GET("/", func(c echo.Context) error { return echo.NewHTTPError(http.StatusNotFound) })
+
+	assert.Equal(t, http.StatusNotFound, serve(e, "/").Code)
+	assert.Equal(t, breaker.Counts{Total: 1, Success: 1}, cb.Counts())
+}
+
+func TestMiddlewareErrorHandler(t *testing.T) {
+	cb, cancel, err := breaker.New()
+	require.NoError(t, err)
+	defer cancel()
+	cb.Trip()
+
+	middleware, err := Middleware(Single(cb), WithErrorHandler(func(c echo.Context, _ *breaker.CircuitBreaker, _ error) error {
+		return c.NoContent(http.StatusTeapot)
+	}))
+	require.NoError(t, err)
+
+	e := echo.New()
+	e.Use(middleware)
+	e.GET("/", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+
+	assert.Equal(t, http.StatusTeapot, serve(e, "/").Code)
+}
+
+func TestMiddlewareShutdown(t *testing.T) {
+	cb, cancel, err := breaker.New()
+	require.NoError(t, err)
+	defer cancel()
+	require.NoError(t, cb.Shutdown(context.Background()))
+
+	middleware, err := Middleware(Single(cb))
+	require.NoError(t, err)
+
+	ran := false
+	e := echo.New()
+	e.Use(middleware)
+	e.GET("/", func(c echo.Context) error {
+		ran = true
+		return c.NoContent(http.StatusOK)
+	})
+
+	assert.Equal(t, http.StatusServiceUnavailable, serve(e, "/").Code)
+	assert.False(t, ran)
+}
+
+func TestMiddlewareFails(t *testing.T) {
+	cb, cancel, err := breaker.New()
+	require.NoError(t, err)
+	defer cancel()
+
+	_, err = Middleware(nil)
+	assert.ErrorIs(t, err, ErrInvalidSource)
+
+	_, err = Middleware(Single(cb), WithRejectionStatus(42))
+	assert.ErrorIs(t, err, ErrInvalidRejection)
+
+	_, err = Middleware(Single(cb), WithErrorHandler(nil))
+	assert.ErrorIs(t, err, ErrInvalidErrorHandler)
+}
+
+func TestMiddlewareCoalescedProbe(t *testing.T) {
+	cb, cancel, err := breaker.New(breaker.WithProbeCoalescing(nil))
+	require.NoError(t, err)
+	defer cancel()
+	cb.Trip()
+	cb.ProbeNow()
+
+	middleware, err := Middleware(Single(cb))
+	require.NoError(t, err)
+
+	var handled atomic.Int64
+	started, release := make(chan struct{}), make(chan struct{})
+	e := echo.New()
+	e.Use(middleware)
+	e.GET("/", func(c echo.Context) error {
+		handled.Add(1)
+		close(started)
+		<-release
+		return c.NoContent(http.StatusOK)
+	})
+
+	probe := make(chan *httptest.ResponseRecorder, 1)
+	go func() { probe <- serve(e, "/") }()
+	<-started
+	coalesced := make(chan *httptest.ResponseRecorder, 1)
+	go func() { coalesced <- serve(e, "/") }()
+	time.Sleep(time.Millisecond * 50)
+	close(release)
+
+	assert.Equal(t, http.StatusOK, (<-probe).Code)
+	assert.Equal(t, http.StatusServiceUnavailable, (<-coalesced).Code)
+	assert.Equal(t, int64(1), handled.Load())
+}
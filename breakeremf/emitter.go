@@ -0,0 +1,142 @@
+// Package breakeremf Writes breaker metrics in CloudWatch Embedded Metric Format, so services
+// on Lambda or ECS get CloudWatch dashboards from their logs without a metrics sidecar.
+package breakeremf
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	breaker "github.com/gilbertovgl/go-circuit-breaker"
+)
+
+const (
+	_defaultNamespace = "CircuitBreaker"
+	_defaultInterval  = time.Minute
+)
+
+var stateValue = map[breaker.State]float64{breaker.Closed: 0, breaker.HalfOpen: 1, breaker.Open: 2}
+
+type metric struct {
+	Name string `json:"Name"`
+	Unit string `json:"Unit"`
+}
+
+// metrics Are the values of every document, State being 0 when closed, 1 when half-open and
+// 2 when open. The counts are those of the rolling window but ShortCircuits, counted since
+// the previous emission.
+var metrics = []metric{
+	{Name: "State", Unit: "None"},
+	{Name: "Calls", Unit: "Count"},
+	{Name: "Failures", Unit: "Count"},
+	{Name: "Slow", Unit: "Count"},
+	{Name: "FailureRate", Unit: "Percent"},
+	{Name: "InFlight", Unit: "Count"},
+	{Name: "ShortCircuits", Unit: "Count"},
+}
+
+// Emitter Writes a document per breaker of Registry every Interval, with the breaker name
+// as the Breaker dimension and its labels as further dimensions, but those named like
+// Breaker or a metric.
+type Emitter struct {
+	// Registry Holds the breakers to emit.
+	Registry *breaker.Registry
+	// Namespace Is the CloudWatch namespace, CircuitBreaker by default.
+	Namespace string
+	// Writer Receives one JSON document per line, os.Stdout by default as the Lambda and ECS
+	// log drivers forward it to CloudWatch Logs.
+	Writer io.Writer
+	// Interval Defaults to a minute.
+	Interval time.Duration
+
+	shortCircuits map[string]uint64
+
+	mu sync.Mutex
+}
+
+// Run Emits every interval until ctx is done, returning the first write error.
+func (e *Emitter) Run(ctx context.Context) error {
+	interval := e.Interval
+	if interval <= 0 {
+		interval = _defaultInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := e.Emit(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Emit Writes the current metrics of every breaker once.
+func (e *Emitter) Emit() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.shortCircuits == nil {
+		e.shortCircuits = make(map[string]uint64)
+	}
+	w := e.Writer
+	if w == nil {
+		w = os.Stdout
+	}
+	namespace := e.Namespace
+	if namespace == "" {
+		namespace = _defaultNamespace
+	}
+
+	enc := json.NewEncoder(w)
+	for _, cb := range e.Registry.List() {
+		snapshot := cb.Snapshot()
+		if err := enc.Encode(e.document(namespace, snapshot)); err != nil {
+			return err
+		}
+		e.shortCircuits[snapshot.Name] = snapshot.ShortCircuits
+	}
+	return nil
+}
+
+func (e *Emitter) document(namespace string, snapshot breaker.Snapshot) map[string]any {
+	doc := map[string]any{
+		"Breaker":       snapshot.Name,
+		"State":         stateValue[snapshot.State],
+		"Calls":         snapshot.Summary.Total,
+		"Failures":      snapshot.Summary.Fail,
+		"Slow":          snapshot.Summary.Slow,
+		"FailureRate":   snapshot.Summary.FailureRate(),
+		"InFlight":      snapshot.InFlight,
+		"ShortCircuits": snapshot.ShortCircuits - e.shortCircuits[snapshot.Name],
+	}
+
+	labels := make([]string, 0, len(snapshot.Labels))
+	for key := range snapshot.Labels {
+		if _, taken := doc[key]; !taken && key != "_aws" {
+			labels = append(labels, key)
+		}
+	}
+	sort.Strings(labels)
+	for _, key := range labels {
+		doc[key] = snapshot.Labels[key]
+	}
+
+	doc["_aws"] = map[string]any{
+		"Timestamp": time.Now().UnixMilli(),
+		"CloudWatchMetrics": []any{map[string]any{
+			"Namespace":  namespace,
+			"Dimensions": [][]string{append([]string{"Breaker"}, labels...)},
+			"Metrics":    metrics,
+		}},
+	}
+	return doc
+}
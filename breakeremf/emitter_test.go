@@ -0,0 +1,77 @@
+package breakeremf
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	breaker "github.com/gilbertovgl/go-circuit-breaker"
+)
+
+func TestEmitterEmit(t *testing.T) {
+	cb, cancel, err := breaker.New(
+		breaker.WithName("payments"),
+		breaker.WithLabels(map[string]string{"Team": "billing", "Tier": "1", "State": "ignored"}),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	registry := breaker.NewRegistry()
+	require.NoError(t, registry.Register(cb))
+
+	require.NoError(t, cb.Execute(func() error { return nil }))
+	require.Error(t, cb.Execute(func() error { return errors.New("failed") }))
+	cb.Trip()
+	require.Error(t, cb.Execute(func() error { return nil }))
+
+	var out bytes.Buffer
+	e := &Emitter{Registry: registry, Namespace: "checkout", Writer: &out}
+	require.NoError(t, e.Emit())
+
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(out.Bytes(), &doc))
+	assert.Equal(t, "payments", doc["Breaker"])
+	assert.Equal(t, "billing", doc["Team"])
+	assert.Equal(t, "1", doc["Tier"])
+	assert.Equal(t, 2.0, doc["State"])
+	assert.Equal(t, 2.0, doc["Calls"])
+	assert.Equal(t, 1.0, doc["Failures"])
+	assert.Equal(t, 50.0, doc["FailureRate"])
+	assert.Equal(t, 1.0, doc["ShortCircuits"])
+
+	directive := doc["_aws"].(map[string]any)["CloudWatchMetrics"].([]any)[0].(map[string]any)
+	assert.Equal(t, "checkout", directive["Namespace"])
+	assert.Equal(t, []any{[]any{"Breaker", "Team", "Tier"}}, directive["Dimensions"])
+	assert.Len(t, directive["Metrics"], len(metrics))
+
+	out.Reset()
+	require.NoError(t, e.Emit())
+	require.NoError(t, json.Unmarshal(out.Bytes(), &doc))
+	assert.Equal(t, 0.0, doc["ShortCircuits"])
+}
+
+func TestEmitterRun(t *testing.T) {
+	cb, cancel, err := breaker.New(breaker.WithName("payments"))
+	require.NoError(t, err)
+	defer cancel()
+
+	registry := breaker.NewRegistry()
+	require.NoError(t, registry.Register(cb))
+
+	var out bytes.Buffer
+	e := &Emitter{Registry: registry, Writer: &out, Interval: time.Millisecond * 20}
+
+	ctx, stop := context.WithTimeout(context.Background(), time.Millisecond*70)
+	defer stop()
+	require.NoError(t, e.Run(ctx))
+
+	lines := bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n"))
+	assert.GreaterOrEqual(t, len(lines), 2)
+	assert.Contains(t, string(lines[0]), `"Namespace":"CircuitBreaker"`)
+}
@@ -0,0 +1,73 @@
+// Package breakeretcd Shares breaker trip decisions through etcd, kept in its own module so
+// the core package doesn't depend on the etcd client.
+package breakeretcd
+
+import (
+	"context"
+	"math"
+	"path"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+
+	breaker "github.com/gilbertovgl/go-circuit-breaker"
+)
+
+// State Is a breaker.SharedState keeping the decision of every breaker under <prefix>/<name>
+// attached to a lease of its ttl, so a decision nobody renews expires with it. etcd orders
+// the writes, every instance watching the key sees the decisions in the same order.
+//
+//	client, _ := clientv3.New(clientv3.Config{Endpoints: []string{"etcd:2379"}})
+//	cb, cancel, _ := breaker.New(breaker.WithName("payments"), breaker.WithSharedState(breakeretcd.New(client, "/breakers")))
+type State struct {
+	client *clientv3.Client
+	prefix string
+}
+
+var _ breaker.SharedState = (*State)(nil)
+
+func New(client *clientv3.Client, prefix string) *State {
+	return &State{client: client, prefix: prefix}
+}
+
+func (s *State) key(name string) string {
+	return path.Join(s.prefix, name)
+}
+
+// Store Writes state under a lease of ttl rounded up to the second, etcd leases counting in
+// seconds.
+func (s *State) Store(ctx context.Context, name string, state breaker.State, ttl time.Duration) error {
+	lease, err := s.client.Grant(ctx, int64(math.Max(1, math.Ceil(ttl.Seconds()))))
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Put(ctx, s.key(name), string(state), clientv3.WithLease(lease.ID))
+	return err
+}
+
+// Watch Delivers the stored decision, if any, then every decision written after it until
+// ctx is done. Expired decisions deliver nothing, the instances following them probing once
+// their open duration elapses.
+func (s *State) Watch(ctx context.Context, name string, fn func(state breaker.State)) error {
+	resp, err := s.client.Get(ctx, s.key(name))
+	if err != nil {
+		return err
+	}
+	if len(resp.Kvs) > 0 {
+		fn(breaker.State(resp.Kvs[0].Value))
+	}
+
+	events := s.client.Watch(ctx, s.key(name), clientv3.WithRev(resp.Header.Revision+1))
+	go func() {
+		for wresp := range events {
+			for _, event := range wresp.Events {
+				if event.Type == clientv3.EventTypePut {
+					fn(breaker.State(event.Kv.Value))
+				}
+			}
+		}
+	}()
+
+	return nil
+}
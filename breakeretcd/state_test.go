@@ -0,0 +1,101 @@
+package breakeretcd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/server/v3/embed"
+
+	breaker "github.com/gilbertovgl/go-circuit-breaker"
+)
+
+func freeURL(t *testing.T) url.URL {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	return url.URL{Scheme: "http", Host: l.Addr().String()}
+}
+
+func newClient(t *testing.T) *clientv3.Client {
+	cfg := embed.NewConfig()
+	cfg.Dir = t.TempDir()
+	cfg.LogLevel = "error"
+	client, peer := freeURL(t), freeURL(t)
+	cfg.ListenClientUrls, cfg.AdvertiseClientUrls = []url.URL{client}, []url.URL{client}
+	cfg.ListenPeerUrls, cfg.AdvertisePeerUrls = []url.URL{peer}, []url.URL{peer}
+	cfg.InitialCluster = fmt.Sprintf("%s=%s", cfg.Name, peer.String())
+
+	server, err := embed.StartEtcd(cfg)
+	require.NoError(t, err)
+	t.Cleanup(server.Close)
+	select {
+	case <-server.Server.ReadyNotify():
+	case <-time.After(time.Second * 10):
+		t.Fatal("etcd didn't start")
+	}
+
+	c, err := clientv3.New(clientv3.Config{Endpoints: []string{client.String()}, DialTimeout: time.Second * 5})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+	return c
+}
+
+func TestState(t *testing.T) {
+	state := New(newClient(t), "/breakers")
+	ctx := context.Background()
+
+	require.NoError(t, state.Store(ctx, "payments", breaker.Open, time.Minute))
+
+	got := make(chan breaker.State, 4)
+	watchCtx, stop := context.WithCancel(ctx)
+	defer stop()
+	require.NoError(t, state.Watch(watchCtx, "payments", func(s breaker.State) { got <- s }))
+	assert.Equal(t, breaker.Open, <-got)
+
+	require.NoError(t, state.Store(ctx, "orders", breaker.Open, time.Minute))
+	require.NoError(t, state.Store(ctx, "payments", breaker.Closed, time.Minute))
+	assert.Equal(t, breaker.Closed, <-got)
+
+	stop()
+	time.Sleep(time.Millisecond * 100)
+	require.NoError(t, state.Store(ctx, "payments", breaker.Open, time.Minute))
+	time.Sleep(time.Millisecond * 100)
+	assert.Empty(t, got)
+}
+
+func TestStateExpires(t *testing.T) {
+	state := New(newClient(t), "/breakers")
+	ctx := context.Background()
+
+	require.NoError(t, state.Store(ctx, "payments", breaker.Open, time.Millisecond))
+	assert.Eventually(t, func() bool {
+		resp, err := state.client.Get(ctx, state.key("payments"))
+		return err == nil && len(resp.Kvs) == 0
+	}, time.Second*5, time.Millisecond*100)
+}
+
+func TestStateSharesTrips(t *testing.T) {
+	state := New(newClient(t), "/breakers")
+	newBreaker := func() *breaker.CircuitBreaker {
+		cb, cancel, err := breaker.New(
+			breaker.WithName("payments"),
+			breaker.WithSharedState(state),
+			breaker.WithCanTrip(func(summary breaker.Counts) bool { return summary.Fail > 0 }),
+		)
+		require.NoError(t, err)
+		t.Cleanup(cancel)
+		return cb
+	}
+
+	a, b := newBreaker(), newBreaker()
+	_ = a.Execute(func() error { return fmt.Errorf("failed") })
+	require.Equal(t, breaker.Open, a.State())
+	assert.Eventually(t, func() bool { return b.State() == breaker.Open }, time.Second*5, time.Millisecond*20)
+}
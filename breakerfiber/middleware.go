@@ -0,0 +1,218 @@
+// Package breakerfiber Guards Fiber handlers and proxied upstreams with circuit breakers,
+// kept in its own module so the core package doesn't depend on Fiber nor fasthttp.
+//
+// A fiber.Ctx isn't a context.Context and is recycled once the handler returns, so the
+// breakers never see it: the calls run on its user context, which Context returns and the
+// breaker cancels on trip when built with breaker.WithCancelOnTrip.
+package breakerfiber
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/proxy"
+
+	breaker "github.com/gilbertovgl/go-circuit-breaker"
+)
+
+var (
+	ErrInvalidSource       = errors.New("invalid breaker source")
+	ErrInvalidKey          = errors.New("invalid key")
+	ErrInvalidErrorHandler = errors.New("invalid error handler")
+	ErrInvalidFailure      = errors.New("invalid failure predicate")
+	ErrInvalidRejection    = errors.New("invalid rejection status")
+	ErrInvalidUpstream     = errors.New("invalid upstream")
+)
+
+// Source Resolves the breaker guarding the requests under key, a breaker.Picker keeps one
+// per key and Single shares one across every key.
+type Source interface {
+	Breaker(key string) (*breaker.CircuitBreaker, error)
+}
+
+type single struct {
+	cb *breaker.CircuitBreaker
+}
+
+func (s single) Breaker(string) (*breaker.CircuitBreaker, error) {
+	return s.cb, nil
+}
+
+// Single Guards every route with cb.
+func Single(cb *breaker.CircuitBreaker) Source {
+	return single{cb: cb}
+}
+
+type Option func(cfg *config) error
+
+type config struct {
+	key             func(c *fiber.Ctx) string
+	errorHandler    func(c *fiber.Ctx, cb *breaker.CircuitBreaker, err error) error
+	failure         func(status int) bool
+	rejectionStatus int
+}
+
+func newConfig(key func(c *fiber.Ctx) string, opts []Option) (*config, error) {
+	cfg := &config{
+		key:             key,
+		failure:         func(status int) bool { return status >= http.StatusInternalServerError },
+		rejectionStatus: http.StatusServiceUnavailable,
+	}
+	cfg.errorHandler = cfg.defaultErrorHandler
+	for _, opt := range opts {
+		if err := opt(cfg); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+// WithKey Sets which breaker of the source guards a request, the request path by default
+// for Middleware. A middleware added with app.Use only sees its own route, so keying by the
+// route pattern such as /users/:id with c.Route().Path takes adding it to each route.
+func WithKey(key func(c *fiber.Ctx) string) Option {
+	return func(cfg *config) error {
+		if key == nil {
+			return fmt.Errorf("%w: can't be <nil>", ErrInvalidKey)
+		}
+		cfg.key = key
+		return nil
+	}
+}
+
+// WithErrorHandler Sets what the requests the breaker turns away return. By default they get
+// a Retry-After header and a fiber.Error with the rejection status.
+func WithErrorHandler(handler func(c *fiber.Ctx, cb *breaker.CircuitBreaker, err error) error) Option {
+	return func(cfg *config) error {
+		if handler == nil {
+			return fmt.Errorf("%w: can't be <nil>", ErrInvalidErrorHandler)
+		}
+		cfg.errorHandler = handler
+		return nil
+	}
+}
+
+// WithFailureStatus Sets which response statuses count as failures, the 5xx by default.
+func WithFailureStatus(failure func(status int) bool) Option {
+	return func(cfg *config) error {
+		if failure == nil {
+			return fmt.Errorf("%w: can't be <nil>", ErrInvalidFailure)
+		}
+		cfg.failure = failure
+		return nil
+	}
+}
+
+// WithRejectionStatus Sets the status code the default error handler answers with, 503
+// Service Unavailable by default.
+func WithRejectionStatus(code int) Option {
+	return func(cfg *config) error {
+		if code < 100 || code > 599 {
+			return fmt.Errorf("%w: %d isn't a status code", ErrInvalidRejection, code)
+		}
+		cfg.rejectionStatus = code
+		return nil
+	}
+}
+
+// Context Returns the context the breaker runs the current call on, to hand to the clients
+// the handler calls in place of the fiber.Ctx.
+func Context(c *fiber.Ctx) context.Context {
+	return c.UserContext()
+}
+
+// Execute Runs fn through cb on the user context of c, for handlers guarding a single call
+// rather than the whole request.
+func Execute(c *fiber.Ctx, cb *breaker.CircuitBreaker, fn func(ctx context.Context) error) error {
+	return cb.ExecuteContext(c.UserContext(), fn)
+}
+
+// Middleware Runs the requests through the breaker source resolves for their key, counting
+// the failure statuses as failures. The status of a handler returning an error is the one
+// Fiber answers with, that of the fiber.Error or 500 Internal Server Error.
+func Middleware(source Source, opts ...Option) (fiber.Handler, error) {
+	if source == nil {
+		return nil, fmt.Errorf("%w: can't be <nil>", ErrInvalidSource)
+	}
+	cfg, err := newConfig(func(c *fiber.Ctx) string { return c.Path() }, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(c *fiber.Ctx) error {
+		return cfg.guard(c, source, c.Next)
+	}, nil
+}
+
+// Proxy Forwards the requests to the address upstream returns for them, such as
+// http://payments:8080/charge, through the breaker source resolves for that address unless
+// keyed otherwise.
+func Proxy(source Source, upstream func(c *fiber.Ctx) string, opts ...Option) (fiber.Handler, error) {
+	if source == nil {
+		return nil, fmt.Errorf("%w: can't be <nil>", ErrInvalidSource)
+	}
+	if upstream == nil {
+		return nil, fmt.Errorf("%w: can't be <nil>", ErrInvalidUpstream)
+	}
+	cfg, err := newConfig(upstream, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(c *fiber.Ctx) error {
+		addr := upstream(c)
+		return cfg.guard(c, source, func() error { return proxy.Do(c, addr) })
+	}, nil
+}
+
+func (cfg *config) guard(c *fiber.Ctx, source Source, next func() error) error {
+	cb, err := source.Breaker(cfg.key(c))
+	if err != nil {
+		return err
+	}
+
+	var handlerErr error
+	ran := false
+	err = cb.ExecuteContext(breaker.ContextWithDecision(c.UserContext()), func(ctx context.Context) error {
+		ran = true
+		c.SetUserContext(ctx)
+		handlerErr = next()
+		if cfg.failure(status(c, handlerErr)) {
+			return breaker.Classified(breaker.OutcomeFailure, nil)
+		}
+		return nil
+	})
+	if !ran {
+		if err == nil {
+			err = breaker.ErrProbeCoalesced
+		}
+		return cfg.errorHandler(c, cb, err)
+	}
+	return handlerErr
+}
+
+func status(c *fiber.Ctx, err error) int {
+	if err == nil {
+		return c.Response().StatusCode()
+	}
+
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return fiberErr.Code
+	}
+	return http.StatusInternalServerError
+}
+
+func (cfg *config) defaultErrorHandler(c *fiber.Ctx, cb *breaker.CircuitBreaker, err error) error {
+	seconds := int(math.Ceil(cb.TimeUntilHalfOpen().Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	c.Set(fiber.HeaderRetryAfter, strconv.Itoa(seconds))
+	return fiber.NewError(cfg.rejectionStatus, err.Error())
+}
@@ -0,0 +1,189 @@
+package breakerfiber
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	breaker "github.com/gilbertovgl/go-circuit-breaker"
+)
+
+func serve(t *testing.T, app *fiber.App, path string) *http.Response {
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, path, nil))
+	require.NoError(t, err)
+	return resp
+}
+
+func TestMiddlewarePerPath(t *testing.T) {
+	picker := breaker.NewPicker(
+		breaker.WithCanTrip(func(summary breaker.Counts) bool { return summary.Fail > 0 }),
+	)
+	defer picker.Close()
+
+	middleware, err := Middleware(picker)
+	require.NoError(t, err)
+
+	app := fiber.New()
+	app.Use(middleware)
+	app.Get("/users/:id", func(c *fiber.Ctx) error { return errors.New("database down") })
+	app.Get("/health", func(c *fiber.Ctx) error { return c.SendStatus(http.StatusOK) })
+
+	assert.Equal(t, http.StatusInternalServerError, serve(t, app, "/users/1").StatusCode)
+
+	resp := serve(t, app, "/users/1")
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.NotEmpty(t, resp.Header.Get("Retry-After"))
+
+	assert.Equal(t, http.StatusInternalServerError, serve(t, app, "/users/2").StatusCode)
+	assert.Equal(t, http.StatusOK, serve(t, app, "/health").StatusCode)
+}
+
+func TestMiddlewarePerRoute(t *testing.T) {
+	picker := breaker.NewPicker(
+		breaker.WithCanTrip(func(summary breaker.Counts) bool { return summary.Fail > 0 }),
+	)
+	defer picker.Close()
+
+	middleware, err := Middleware(picker, WithKey(func(c *fiber.Ctx) string { return c.Route().Path }))
+	require.NoError(t, err)
+
+	app := fiber.New()
+	app.Get("/users/:id", middleware, func(c *fiber.Ctx) error { return errors.New("database down") })
+	app.Get("/health", middleware, func(c *fiber.Ctx) error { return c.SendStatus(http.StatusOK) })
+
+	assert.Equal(t, http.StatusInternalServerError, serve(t, app, "/users/1").StatusCode)
+	assert.Equal(t, http.StatusServiceUnavailable, serve(t, app, "/users/2").StatusCode)
+	assert.Equal(t, http.StatusOK, serve(t, app, "/health").StatusCode)
+
+	cb, err := picker.Breaker("/users/:id")
+	require.NoError(t, err)
+	assert.Equal(t, breaker.Open, cb.State())
+}
+
+func TestMiddlewareShutdown(t *testing.T) {
+	cb, cancel, err := breaker.New()
+	require.NoError(t, err)
+	defer cancel()
+	require.NoError(t, cb.Shutdown(context.Background()))
+
+	middleware, err := Middleware(Single(cb))
+	require.NoError(t, err)
+
+	ran := false
+	app := fiber.New()
+	app.Use(middleware)
+	app.Get("/", func(c *fiber.Ctx) error {
+		ran = true
+		return c.SendStatus(http.StatusOK)
+	})
+
+	assert.Equal(t, http.StatusServiceUnavailable, serve(t, app, "/").StatusCode)
+	assert.False(t, ran)
+}
+
+func TestExecute(t *testing.T) {
+	cb, cancel, err := breaker.New(breaker.WithCancelOnTrip())
+	require.NoError(t, err)
+	defer cancel()
+
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		return Execute(c, cb, func(ctx context.Context) error {
+			assert.NoError(t, ctx.Err())
+			return c.SendStatus(http.StatusAccepted)
+		})
+	})
+
+	assert.Equal(t, http.StatusAccepted, serve(t, app, "/").StatusCode)
+	assert.Equal(t, breaker.Counts{Total: 1, Success: 1}, cb.Counts())
+}
+
+func TestProxy(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer upstream.Close()
+
+	picker := breaker.NewPicker(
+		breaker.WithCanTrip(func(summary breaker.Counts) bool { return summary.Fail > 0 }),
+	)
+	defer picker.Close()
+
+	handler, err := Proxy(picker, func(c *fiber.Ctx) string { return upstream.URL })
+	require.NoError(t, err)
+
+	app := fiber.New()
+	app.Get("/", handler)
+
+	assert.Equal(t, http.StatusBadGateway, serve(t, app, "/").StatusCode)
+	assert.Equal(t, http.StatusServiceUnavailable, serve(t, app, "/").StatusCode)
+
+	cb, err := picker.Breaker(upstream.URL)
+	require.NoError(t, err)
+	assert.Equal(t, breaker.Open, cb.State())
+}
+
+func TestMiddlewareFails(t *testing.T) {
+	cb, cancel, err := breaker.New()
+	require.NoError(t, err)
+	defer cancel()
+
+	_, err = Middleware(nil)
+	assert.ErrorIs(t, err, ErrInvalidSource)
+
+	_, err = Middleware(Single(cb), WithRejectionStatus(42))
+	assert.ErrorIs(t, err, ErrInvalidRejection)
+
+	_, err = Proxy(Single(cb), nil)
+	assert.ErrorIs(t, err, ErrInvalidUpstream)
+}
+
+func TestMiddlewareCoalescedProbe(t *testing.T) {
+	cb, cancel, err := breaker.New(breaker.WithProbeCoalescing(nil))
+	require.NoError(t, err)
+	defer cancel()
+	cb.Trip()
+	cb.ProbeNow()
+
+	middleware, err := Middleware(Single(cb))
+	require.NoError(t, err)
+
+	var handled atomic.Int64
+	started, release := make(chan struct{}), make(chan struct{})
+	app := fiber.New()
+	app.Use(middleware)
+	app.Get("/", func(c *fiber.Ctx) error {
+		handled.Add(1)
+		close(started)
+		<-release
+		return c.SendStatus(http.StatusOK)
+	})
+
+	test := func(codes chan<- int) {
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/", nil))
+		if err != nil {
+			codes <- 0
+			return
+		}
+		codes <- resp.StatusCode
+	}
+	probe := make(chan int, 1)
+	go test(probe)
+	<-started
+	coalesced := make(chan int, 1)
+	go test(coalesced)
+	time.Sleep(time.Millisecond * 50)
+	close(release)
+
+	assert.Equal(t, http.StatusOK, <-probe)
+	assert.Equal(t, http.StatusServiceUnavailable, <-coalesced)
+	assert.Equal(t, int64(1), handled.Load())
+}
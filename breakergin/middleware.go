@@ -0,0 +1,162 @@
+// Package breakergin Guards Gin routes with circuit breakers, kept in its own module so the
+// core package doesn't depend on Gin.
+package breakergin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	breaker "github.com/gilbertovgl/go-circuit-breaker"
+)
+
+var (
+	ErrInvalidSource    = errors.New("invalid breaker source")
+	ErrInvalidKey       = errors.New("invalid key")
+	ErrInvalidAbort     = errors.New("invalid abort")
+	ErrInvalidFailure   = errors.New("invalid failure predicate")
+	ErrInvalidRejection = errors.New("invalid rejection status")
+)
+
+// Source Resolves the breaker guarding the requests under key, a breaker.Picker keeps one
+// per key and Single shares one across every key.
+type Source interface {
+	Breaker(key string) (*breaker.CircuitBreaker, error)
+}
+
+type single struct {
+	cb *breaker.CircuitBreaker
+}
+
+func (s single) Breaker(string) (*breaker.CircuitBreaker, error) {
+	return s.cb, nil
+}
+
+// Single Guards every route with cb.
+func Single(cb *breaker.CircuitBreaker) Source {
+	return single{cb: cb}
+}
+
+type Option func(cfg *config) error
+
+type config struct {
+	key             func(c *gin.Context) string
+	abort           func(c *gin.Context, cb *breaker.CircuitBreaker, err error)
+	failure         func(status int) bool
+	rejectionStatus int
+}
+
+// WithKey Sets which breaker of the source guards a request, the route pattern such as
+// /users/:id by default so every route gets its own, or "unmatched" for unknown routes.
+func WithKey(key func(c *gin.Context) string) Option {
+	return func(cfg *config) error {
+		if key == nil {
+			return fmt.Errorf("%w: can't be <nil>", ErrInvalidKey)
+		}
+		cfg.key = key
+		return nil
+	}
+}
+
+// WithAbort Sets how the requests the breaker turns away are answered, they must abort the
+// context. By default the rejection status is answered with a Retry-After header and the
+// error as JSON.
+func WithAbort(abort func(c *gin.Context, cb *breaker.CircuitBreaker, err error)) Option {
+	return func(cfg *config) error {
+		if abort == nil {
+			return fmt.Errorf("%w: can't be <nil>", ErrInvalidAbort)
+		}
+		cfg.abort = abort
+		return nil
+	}
+}
+
+// WithFailureStatus Sets which response statuses count as failures, the 5xx by default.
+func WithFailureStatus(failure func(status int) bool) Option {
+	return func(cfg *config) error {
+		if failure == nil {
+			return fmt.Errorf("%w: can't be <nil>", ErrInvalidFailure)
+		}
+		cfg.failure = failure
+		return nil
+	}
+}
+
+// WithRejectionStatus Sets the status code the default abort answers with, 503 Service
+// Unavailable by default.
+func WithRejectionStatus(code int) Option {
+	return func(cfg *config) error {
+		if code < 100 || code > 599 {
+			return fmt.Errorf("%w: %d isn't a status code", ErrInvalidRejection, code)
+		}
+		cfg.rejectionStatus = code
+		return nil
+	}
+}
+
+// Middleware Runs the requests through the breaker source resolves for their key, counting
+// the failure statuses as failures.
+func Middleware(source Source, opts ...Option) (gin.HandlerFunc, error) {
+	if source == nil {
+		return nil, fmt.Errorf("%w: can't be <nil>", ErrInvalidSource)
+	}
+
+	cfg := &config{
+		key:             routeKey,
+		failure:         func(status int) bool { return status >= http.StatusInternalServerError },
+		rejectionStatus: http.StatusServiceUnavailable,
+	}
+	cfg.abort = cfg.defaultAbort
+	for _, opt := range opts {
+		if err := opt(cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	return func(c *gin.Context) {
+		cb, err := source.Breaker(cfg.key(c))
+		if err != nil {
+			_ = c.Error(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		ran := false
+		err = cb.ExecuteContext(breaker.ContextWithDecision(c.Request.Context()), func(ctx context.Context) error {
+			ran = true
+			c.Request = c.Request.WithContext(ctx)
+			c.Next()
+			if cfg.failure(c.Writer.Status()) {
+				return breaker.Classified(breaker.OutcomeFailure, nil)
+			}
+			return nil
+		})
+		if !ran {
+			if err == nil {
+				err = breaker.ErrProbeCoalesced
+			}
+			cfg.abort(c, cb, err)
+		}
+	}, nil
+}
+
+func routeKey(c *gin.Context) string {
+	if route := c.FullPath(); route != "" {
+		return route
+	}
+	return "unmatched"
+}
+
+func (cfg *config) defaultAbort(c *gin.Context, cb *breaker.CircuitBreaker, err error) {
+	seconds := int(math.Ceil(cb.TimeUntilHalfOpen().Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	c.Header("Retry-After", strconv.Itoa(seconds))
+	c.AbortWithStatusJSON(cfg.rejectionStatus, gin.H{"error": err.Error()})
+}
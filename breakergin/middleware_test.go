@@ -0,0 +1,143 @@
+package breakergin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	breaker "github.com/gilbertovgl/go-circuit-breaker"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+func serve(router *gin.Engine, path string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	return rec
+}
+
+func TestMiddlewarePerRoute(t *testing.T) {
+	picker := breaker.NewPicker(
+		breaker.WithCanTrip(func(summary breaker.Counts) bool { return summary.Fail > 0 }),
+	)
+	defer picker.Close()
+
+	middleware, err := Middleware(picker)
+	require.NoError(t, err)
+
+	router := gin.New()
+	router.Use(middleware)
+	router.GET("/users/:id", func(c *gin.Context) { c.Status(http.StatusInternalServerError) })
+	router.GET("/health", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	assert.Equal(t, http.StatusInternalServerError, serve(router, "/users/1").Code)
+
+	rec := serve(router, "/users/2")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.NotEmpty(t, rec.Header().Get("Retry-After"))
+
+	assert.Equal(t, http.StatusOK, serve(router, "/health").Code)
+
+	cb, err := picker.Breaker("/users/:id")
+	require.NoError(t, err)
+	assert.Equal(t, breaker.Open, cb.State())
+}
+
+func TestMiddlewareAbort(t *testing.T) {
+	cb, cancel, err := breaker.New()
+	require.NoError(t, err)
+	defer cancel()
+	cb.Trip()
+
+	middleware, err := Middleware(Single(cb), WithAbort(func(c *gin.Context, _ *breaker.CircuitBreaker, _ error) {
+		c.AbortWithStatus(http.StatusTeapot)
+	}))
+	require.NoError(t, err)
+
+	router := gin.New()
+	router.Use(middleware)
+	router.GET("/", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	assert.Equal(t, http.StatusTeapot, serve(router, "/").Code)
+}
+
+func TestMiddlewareShutdown(t *testing.T) {
+	cb, cancel, err := breaker.New()
+	require.NoError(t, err)
+	defer cancel()
+	require.NoError(t, cb.Shutdown(context.Background()))
+
+	middleware, err := Middleware(Single(cb))
+	require.NoError(t, err)
+
+	ran := false
+	router := gin.New()
+	router.Use(middleware)
+	router.GET("/", func(c *gin.Context) {
+		ran = true
+		c.Status(http.StatusOK)
+	})
+
+	assert.Equal(t, http.StatusServiceUnavailable, serve(router, "/").Code)
+	assert.False(t, ran)
+}
+
+func TestMiddlewareFails(t *testing.T) {
+	cb, cancel, err := breaker.New()
+	require.NoError(t, err)
+	defer cancel()
+
+	_, err = Middleware(nil)
+	assert.ErrorIs(t, err, ErrInvalidSource)
+
+	_, err = Middleware(Single(cb), WithRejectionStatus(42))
+	assert.ErrorIs(t, err, ErrInvalidRejection)
+
+	_, err = Middleware(Single(cb), WithKey(nil))
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
+
+func TestMiddlewareCoalescedProbe(t *testing.T) {
+	cb, cancel, err := breaker.New(breaker.WithProbeCoalescing(nil))
+	require.NoError(t, err)
+	defer cancel()
+	cb.Trip()
+	cb.ProbeNow()
+
+	middleware, err := Middleware(Single(cb))
+	require.NoError(t, err)
+
+	var handled atomic.Int64
+	started, release := make(chan struct{}), make(chan struct{})
+	router := gin.New()
+	router.Use(middleware)
+	router.GET("/", func(c *gin.Context) {
+		handled.Add(1)
+		close(started)
+		<-release
+		c.Status(http.StatusOK)
+	})
+
+	probe := make(chan *httptest.ResponseRecorder, 1)
+	go func() { probe <- serve(router, "/") }()
+	<-started
+	coalesced := make(chan *httptest.ResponseRecorder, 1)
+	go func() { coalesced <- serve(router, "/") }()
+	time.Sleep(time.Millisecond * 50)
+	close(release)
+
+	assert.Equal(t, http.StatusOK, (<-probe).Code)
+	rec := <-coalesced
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Contains(t, rec.Body.String(), breaker.ErrProbeCoalesced.Error())
+	assert.Equal(t, int64(1), handled.Load())
+}
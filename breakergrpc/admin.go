@@ -0,0 +1,85 @@
+package breakergrpc
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	breaker "github.com/gilbertovgl/go-circuit-breaker"
+	"github.com/gilbertovgl/go-circuit-breaker/breakeradmin"
+	"github.com/gilbertovgl/go-circuit-breaker/breakeradmin/adminpb"
+)
+
+type adminServer struct {
+	adminpb.UnimplementedBreakerAdminServer
+	svc *breakeradmin.Service
+}
+
+// Admin Serves the breakeradmin operations over the registry as the BreakerAdmin service of admin.proto,
+// register it with adminpb.RegisterBreakerAdminServer.
+func Admin(registry *breaker.Registry) adminpb.BreakerAdminServer {
+	return &adminServer{svc: breakeradmin.NewService(registry)}
+}
+
+func (s *adminServer) ListBreakers(ctx context.Context, _ *adminpb.ListBreakersRequest) (*adminpb.ListBreakersResponse, error) {
+	statuses, err := s.svc.ListBreakers(ctx)
+	if err != nil {
+		return nil, adminError(err)
+	}
+	resp := &adminpb.ListBreakersResponse{Breakers: make([]*adminpb.BreakerStatus, 0, len(statuses))}
+	for _, st := range statuses {
+		resp.Breakers = append(resp.Breakers, breakerStatus(st))
+	}
+	return resp, nil
+}
+
+func (s *adminServer) GetStatus(ctx context.Context, req *adminpb.GetStatusRequest) (*adminpb.BreakerStatus, error) {
+	return adminReply(s.svc.GetStatus(ctx, req.GetName()))
+}
+
+func (s *adminServer) Trip(ctx context.Context, req *adminpb.TripRequest) (*adminpb.BreakerStatus, error) {
+	return adminReply(s.svc.Trip(ctx, req.GetName()))
+}
+
+func (s *adminServer) Reset(ctx context.Context, req *adminpb.ResetRequest) (*adminpb.BreakerStatus, error) {
+	return adminReply(s.svc.Reset(ctx, req.GetName()))
+}
+
+func (s *adminServer) SetMode(ctx context.Context, req *adminpb.SetModeRequest) (*adminpb.BreakerStatus, error) {
+	return adminReply(s.svc.SetMode(ctx, req.GetName(), breaker.Mode(req.GetMode())))
+}
+
+func adminReply(st breakeradmin.Status, err error) (*adminpb.BreakerStatus, error) {
+	if err != nil {
+		return nil, adminError(err)
+	}
+	return breakerStatus(st), nil
+}
+
+func adminError(err error) error {
+	switch {
+	case errors.Is(err, breakeradmin.ErrBreakerNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, breaker.ErrInvalidMode):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+func breakerStatus(st breakeradmin.Status) *adminpb.BreakerStatus {
+	return &adminpb.BreakerStatus{
+		Name:  st.Name,
+		State: string(st.State),
+		Mode:  string(st.Mode),
+		Counts: &adminpb.Counts{
+			Total:   st.Counts.Total,
+			Fail:    st.Counts.Fail,
+			Success: st.Counts.Success,
+			Slow:    st.Counts.Slow,
+			Ignored: st.Counts.Ignored,
+		},
+	}
+}
@@ -0,0 +1,105 @@
+package breakergrpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	breaker "github.com/gilbertovgl/go-circuit-breaker"
+	"github.com/gilbertovgl/go-circuit-breaker/breakeradmin/adminpb"
+)
+
+func adminClient(t *testing.T, registry *breaker.Registry) adminpb.BreakerAdminClient {
+	lis := bufconn.Listen(1 << 16)
+	srv := grpc.NewServer()
+	adminpb.RegisterBreakerAdminServer(srv, Admin(registry))
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return adminpb.NewBreakerAdminClient(conn)
+}
+
+func TestAdmin(t *testing.T) {
+	registry := breaker.NewRegistry()
+	for _, name := range []string{"payments", "orders"} {
+		cb, cancel, err := breaker.New(breaker.WithName(name))
+		require.NoError(t, err)
+		t.Cleanup(cancel)
+		require.NoError(t, registry.Register(cb))
+	}
+
+	client := adminClient(t, registry)
+	ctx := context.Background()
+
+	list, err := client.ListBreakers(ctx, &adminpb.ListBreakersRequest{})
+	require.NoError(t, err)
+	require.Len(t, list.GetBreakers(), 2)
+	assert.Equal(t, "orders", list.GetBreakers()[0].GetName())
+	assert.Equal(t, "payments", list.GetBreakers()[1].GetName())
+
+	got, err := client.Trip(ctx, &adminpb.TripRequest{Name: "payments"})
+	require.NoError(t, err)
+	assert.Equal(t, string(breaker.Open), got.GetState())
+
+	got, err = client.Reset(ctx, &adminpb.ResetRequest{Name: "payments"})
+	require.NoError(t, err)
+	assert.Equal(t, string(breaker.Closed), got.GetState())
+
+	got, err = client.SetMode(ctx, &adminpb.SetModeRequest{Name: "orders", Mode: string(breaker.ModeForcedOpen)})
+	require.NoError(t, err)
+	assert.Equal(t, string(breaker.ModeForcedOpen), got.GetMode())
+
+	got, err = client.GetStatus(ctx, &adminpb.GetStatusRequest{Name: "orders"})
+	require.NoError(t, err)
+	assert.Equal(t, string(breaker.ModeForcedOpen), got.GetMode())
+	assert.Equal(t, string(breaker.Closed), got.GetState())
+
+	_, err = client.SetMode(ctx, &adminpb.SetModeRequest{Name: "orders", Mode: "sideways"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.GetStatus(ctx, &adminpb.GetStatusRequest{Name: "missing"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestAdminCounts(t *testing.T) {
+	cb, cancel, err := breaker.New(breaker.WithName("payments"))
+	require.NoError(t, err)
+	t.Cleanup(cancel)
+	registry := breaker.NewRegistry()
+	require.NoError(t, registry.Register(cb))
+
+	failed := errors.New("failed")
+	for _, err := range []error{
+		nil,
+		failed,
+		breaker.Classified(breaker.OutcomeSlow, nil),
+		breaker.Classified(breaker.OutcomeIgnored, failed),
+		breaker.Classified(breaker.OutcomeIgnored, failed),
+	} {
+		_ = cb.Execute(func() error { return err })
+	}
+
+	got, err := adminClient(t, registry).GetStatus(context.Background(), &adminpb.GetStatusRequest{Name: "payments"})
+	require.NoError(t, err)
+	counts := cb.Counts()
+	assert.Equal(t, counts.Total, got.GetCounts().GetTotal())
+	assert.Equal(t, uint64(1), got.GetCounts().GetFail())
+	assert.Equal(t, uint64(1), got.GetCounts().GetSuccess())
+	assert.Equal(t, uint64(1), got.GetCounts().GetSlow())
+	assert.Equal(t, uint64(2), got.GetCounts().GetIgnored())
+}
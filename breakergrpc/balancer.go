@@ -0,0 +1,111 @@
+package breakergrpc
+
+import (
+	"sort"
+
+	"google.golang.org/grpc/balancer"
+	"google.golang.org/grpc/balancer/base"
+	"google.golang.org/grpc/status"
+
+	breaker "github.com/gilbertovgl/go-circuit-breaker"
+)
+
+// Balancer Builds a round-robin gRPC balancer named name that steers the calls through
+// picker, skipping the endpoints whose circuit is open and counting the status code of every
+// call into the endpoint breaker. Register it with balancer.Register and select it in the
+// service config; the breakers of the endpoints leaving the resolver state are dropped.
+func Balancer(name string, picker *breaker.Picker, opts ...ClientOption) (balancer.Builder, error) {
+	cfg, err := newClientConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &balancerBuilder{
+		Builder: base.NewBalancerBuilder(name, &pickerBuilder{picker: picker, cfg: cfg}, base.Config{HealthCheck: true}),
+		picker:  picker,
+	}, nil
+}
+
+type balancerBuilder struct {
+	balancer.Builder
+	picker *breaker.Picker
+}
+
+func (b *balancerBuilder) Build(cc balancer.ClientConn, opts balancer.BuildOptions) balancer.Balancer {
+	return &breakerBalancer{Balancer: b.Builder.Build(cc, opts), picker: b.picker}
+}
+
+type breakerBalancer struct {
+	balancer.Balancer
+	picker *breaker.Picker
+}
+
+// UpdateClientConnState Drops the breakers of the endpoints gone from the resolver state
+// before the base balancer shuts their connections down.
+func (b *breakerBalancer) UpdateClientConnState(state balancer.ClientConnState) error {
+	endpoints := make([]string, 0, len(state.ResolverState.Addresses))
+	for _, addr := range state.ResolverState.Addresses {
+		endpoints = append(endpoints, addr.Addr)
+	}
+	for _, endpoint := range state.ResolverState.Endpoints {
+		for _, addr := range endpoint.Addresses {
+			endpoints = append(endpoints, addr.Addr)
+		}
+	}
+	b.picker.Prune(endpoints)
+
+	return b.Balancer.UpdateClientConnState(state)
+}
+
+type pickerBuilder struct {
+	picker *breaker.Picker
+	cfg    *clientConfig
+}
+
+func (b *pickerBuilder) Build(info base.PickerBuildInfo) balancer.Picker {
+	if len(info.ReadySCs) == 0 {
+		return base.NewErrPicker(balancer.ErrNoSubConnAvailable)
+	}
+
+	p := &endpointPicker{
+		picker:    b.picker,
+		cfg:       b.cfg,
+		endpoints: make([]string, 0, len(info.ReadySCs)),
+		subConns:  make(map[string]balancer.SubConn, len(info.ReadySCs)),
+	}
+	for sc, sci := range info.ReadySCs {
+		p.endpoints = append(p.endpoints, sci.Address.Addr)
+		p.subConns[sci.Address.Addr] = sc
+	}
+	sort.Strings(p.endpoints)
+
+	return p
+}
+
+// endpointPicker Picks among the ready connections through the breaker.Picker, the calls
+// fail with Unavailable when every circuit is open.
+type endpointPicker struct {
+	picker    *breaker.Picker
+	cfg       *clientConfig
+	endpoints []string
+	subConns  map[string]balancer.SubConn
+}
+
+func (p *endpointPicker) Pick(balancer.PickInfo) (balancer.PickResult, error) {
+	endpoint, cb, err := p.picker.Pick(p.endpoints)
+	if err != nil {
+		return balancer.PickResult{}, &rejectionError{err: err}
+	}
+
+	return balancer.PickResult{
+		SubConn: p.subConns[endpoint],
+		Done: func(info balancer.DoneInfo) {
+			switch p.cfg.classify(status.Code(info.Err)) {
+			case breaker.OutcomeFailure:
+				cb.RecordFailure(info.Err)
+			case breaker.OutcomeSuccess:
+				cb.RecordSuccess()
+			}
+		},
+	}, nil
+}
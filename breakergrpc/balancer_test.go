@@ -0,0 +1,96 @@
+package breakergrpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/balancer"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/resolver/manual"
+	"google.golang.org/grpc/status"
+
+	breaker "github.com/gilbertovgl/go-circuit-breaker"
+)
+
+func serveHealth(t *testing.T, opts ...grpc.ServerOption) string {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	srv := grpc.NewServer(opts...)
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	return lis.Addr().String()
+}
+
+func TestBalancer(t *testing.T) {
+	down := serveHealth(t, grpc.UnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if info.FullMethod == healthpb.Health_Check_FullMethodName {
+			return nil, status.Error(codes.Unavailable, "down")
+		}
+		return handler(ctx, req)
+	}))
+	up := serveHealth(t)
+
+	picker := breaker.NewPicker(
+		breaker.WithOpenDuration(60),
+		breaker.WithCanTrip(func(summary breaker.Counts) bool { return summary.Fail > 0 }),
+	)
+	defer picker.Close()
+
+	builder, err := Balancer("breaker_round_robin_test", picker)
+	require.NoError(t, err)
+	balancer.Register(builder)
+
+	r := manual.NewBuilderWithScheme("breaker")
+	r.InitialState(resolver.State{Addresses: []resolver.Address{{Addr: down}, {Addr: up}}})
+	conn, err := grpc.NewClient(r.Scheme()+":///health",
+		grpc.WithResolvers(r),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultServiceConfig(`{"loadBalancingConfig": [{"breaker_round_robin_test": {}}]}`),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+	client := healthpb.NewHealthClient(conn)
+
+	state := func(endpoint string) breaker.State {
+		for _, cb := range picker.Breakers() {
+			if cb.Name() == endpoint {
+				return cb.State()
+			}
+		}
+		return ""
+	}
+
+	require.Eventually(t, func() bool {
+		_, _ = client.Check(context.Background(), &healthpb.HealthCheckRequest{}, grpc.WaitForReady(true))
+		return state(down) == breaker.Open
+	}, 5*time.Second, 10*time.Millisecond)
+
+	for i := 0; i < 5; i++ {
+		_, err = client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, breaker.Closed, state(up))
+
+	r.UpdateState(resolver.State{Addresses: []resolver.Address{{Addr: up}}})
+	assert.Equal(t, breaker.State(""), state(down))
+	require.Len(t, picker.Breakers(), 1)
+
+	for _, cb := range picker.Breakers() {
+		cb.Trip()
+	}
+	_, err = client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.ErrorIs(t, err, breaker.ErrOpenCircuit)
+}
@@ -0,0 +1,98 @@
+package breakergrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	breaker "github.com/gilbertovgl/go-circuit-breaker"
+)
+
+var ErrInvalidCodeClassifier = errors.New("invalid code classifier")
+
+type ClientOption func(cfg *clientConfig) error
+
+type clientConfig struct {
+	classify func(code codes.Code) breaker.Outcome
+}
+
+func newClientConfig(opts []ClientOption) (*clientConfig, error) {
+	cfg := &clientConfig{classify: DefaultCodeClassifier}
+	for _, opt := range opts {
+		if err := opt(cfg); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+// WithCodeClassifier Sets how the call status codes count, DefaultCodeClassifier by default
+// which fails on Unavailable, DeadlineExceeded or ResourceExhausted but not on NotFound nor
+// InvalidArgument.
+func WithCodeClassifier(classify func(code codes.Code) breaker.Outcome) ClientOption {
+	return func(cfg *clientConfig) error {
+		if classify == nil {
+			return fmt.Errorf("%w: can't be <nil>", ErrInvalidCodeClassifier)
+		}
+		cfg.classify = classify
+		return nil
+	}
+}
+
+// UnaryClientInterceptor Runs the unary calls through cb counting their status codes, the
+// calls the breaker turns away fail with Unavailable without reaching the server.
+func UnaryClientInterceptor(cb *breaker.CircuitBreaker, opts ...ClientOption) (grpc.UnaryClientInterceptor, error) {
+	cfg, err := newClientConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, callOpts ...grpc.CallOption) error {
+		var callErr error
+		ran := false
+		err := cb.ExecuteContext(ctx, func(ctx context.Context) error {
+			ran = true
+			callErr = invoker(ctx, method, req, reply, cc, callOpts...)
+			return cfg.classified(callErr)
+		})
+		if !ran {
+			return rejected(err)
+		}
+		return callErr
+	}, nil
+}
+
+// StreamClientInterceptor Runs the stream openings through cb counting their status codes,
+// the streams themselves aren't counted as they may live for long.
+func StreamClientInterceptor(cb *breaker.CircuitBreaker, opts ...ClientOption) (grpc.StreamClientInterceptor, error) {
+	cfg, err := newClientConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, callOpts ...grpc.CallOption) (grpc.ClientStream, error) {
+		var stream grpc.ClientStream
+		var callErr error
+		ran := false
+		err := cb.ExecuteContext(ctx, func(context.Context) error {
+			ran = true
+			stream, callErr = streamer(ctx, desc, cc, method, callOpts...)
+			return cfg.classified(callErr)
+		})
+		if !ran {
+			return nil, rejected(err)
+		}
+		return stream, callErr
+	}, nil
+}
+
+func (cfg *clientConfig) classified(err error) error {
+	if err == nil {
+		return nil
+	}
+	return breaker.Classified(cfg.classify(status.Code(err)), err)
+}
@@ -0,0 +1,132 @@
+package breakergrpc
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	breaker "github.com/gilbertovgl/go-circuit-breaker"
+	"github.com/gilbertovgl/go-circuit-breaker/breakertest"
+)
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	cb, cancel, err := breaker.New(
+		breaker.WithCanTrip(func(summary breaker.Counts) bool { return summary.Fail > 1 }),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	interceptor, err := UnaryClientInterceptor(cb)
+	require.NoError(t, err)
+
+	code := codes.OK
+	invoked := 0
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		invoked++
+		return status.Error(code, "call")
+	}
+	call := func() error {
+		return interceptor(context.Background(), "/payments.Payments/Charge", nil, nil, nil, invoker)
+	}
+
+	assert.NoError(t, call())
+	code = codes.NotFound
+	assert.Equal(t, codes.NotFound, status.Code(call()))
+	code = codes.Unavailable
+	assert.Equal(t, codes.Unavailable, status.Code(call()))
+	assert.Equal(t, codes.Unavailable, status.Code(call()))
+	assert.Equal(t, breaker.Counts{Total: 4, Fail: 2, Success: 2}, cb.Counts())
+	require.Equal(t, breaker.Open, cb.State())
+
+	err = call()
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.ErrorIs(t, err, breaker.ErrOpenCircuit)
+	assert.Equal(t, 4, invoked)
+}
+
+func TestUnaryClientInterceptorCodeClassifier(t *testing.T) {
+	cb, cancel, err := breaker.New()
+	require.NoError(t, err)
+	defer cancel()
+
+	interceptor, err := UnaryClientInterceptor(cb, WithCodeClassifier(func(code codes.Code) breaker.Outcome {
+		if code == codes.NotFound {
+			return breaker.OutcomeFailure
+		}
+		return DefaultCodeClassifier(code)
+	}))
+	require.NoError(t, err)
+
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return status.Error(codes.NotFound, "not found")
+	}
+	_ = interceptor(context.Background(), "/users.Users/Get", nil, nil, nil, invoker)
+	assert.Equal(t, breaker.Counts{Total: 1, Fail: 1}, cb.Counts())
+
+	_, err = UnaryClientInterceptor(cb, WithCodeClassifier(nil))
+	assert.ErrorIs(t, err, ErrInvalidCodeClassifier)
+}
+
+func TestStreamClientInterceptor(t *testing.T) {
+	cb, cancel, err := breaker.New()
+	require.NoError(t, err)
+	defer cancel()
+
+	interceptor, err := StreamClientInterceptor(cb)
+	require.NoError(t, err)
+
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return nil, status.Error(codes.Unavailable, "unavailable")
+	}
+	_, err = interceptor(context.Background(), &grpc.StreamDesc{}, nil, "/events.Events/Watch", streamer)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, breaker.Counts{Total: 1, Fail: 1}, cb.Counts())
+
+	cb.Trip()
+	_, err = interceptor(context.Background(), &grpc.StreamDesc{}, nil, "/events.Events/Watch", streamer)
+	assert.ErrorIs(t, err, breaker.ErrOpenCircuit)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestUnaryClientInterceptorCoalescedProbe(t *testing.T) {
+	cb, cancel, err := breaker.New(breaker.WithProbeCoalescing(nil))
+	require.NoError(t, err)
+	defer cancel()
+	require.NoError(t, breakertest.ForceState(cb, breaker.HalfOpen))
+
+	interceptor, err := UnaryClientInterceptor(cb)
+	require.NoError(t, err)
+
+	var invoked atomic.Int64
+	started, release := make(chan struct{}), make(chan struct{})
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		invoked.Add(1)
+		close(started)
+		<-release
+		return nil
+	}
+	call := func() error {
+		return interceptor(context.Background(), "/payments.Payments/Charge", nil, nil, nil, invoker)
+	}
+
+	probe := make(chan error, 1)
+	go func() { probe <- call() }()
+	<-started
+	coalesced := make(chan error, 1)
+	go func() { coalesced <- call() }()
+	time.Sleep(time.Millisecond * 50)
+	close(release)
+
+	assert.NoError(t, <-probe)
+	err = <-coalesced
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.ErrorIs(t, err, breaker.ErrProbeCoalesced)
+	assert.Equal(t, int64(1), invoked.Load())
+}
@@ -0,0 +1,68 @@
+// Package breakergrpc Guards gRPC clients and servers with circuit breakers classifying the
+// calls by status code, kept in its own module so the core package doesn't depend on grpc-go.
+package breakergrpc
+
+import (
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	breaker "github.com/gilbertovgl/go-circuit-breaker"
+)
+
+// DefaultCodeClassifier Counts the codes telling the server is unhealthy or overloaded as
+// failures, the codes blaming the request as successes and caller cancellations as ignored.
+func DefaultCodeClassifier(code codes.Code) breaker.Outcome {
+	switch code {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Internal, codes.Unknown, codes.DataLoss:
+		return breaker.OutcomeFailure
+	case codes.Canceled:
+		return breaker.OutcomeIgnored
+	default:
+		return breaker.OutcomeSuccess
+	}
+}
+
+// Classifier Builds a breaker classifier, to set with breaker.WithClassifier on breakers
+// guarding gRPC calls outside the interceptors, that classifies the status code of the call
+// error with classify, DefaultCodeClassifier when nil.
+func Classifier(classify func(code codes.Code) breaker.Outcome) func(err error, latency time.Duration) breaker.Outcome {
+	if classify == nil {
+		classify = DefaultCodeClassifier
+	}
+
+	return func(err error, _ time.Duration) breaker.Outcome {
+		if err == nil {
+			return breaker.OutcomeSuccess
+		}
+		return classify(status.Code(err))
+	}
+}
+
+// rejectionError Carries a breaker rejection as an Unavailable status, so gRPC clients back
+// off or retry elsewhere while errors.Is still finds breaker.ErrOpenCircuit and the like.
+type rejectionError struct {
+	err error
+}
+
+func (r *rejectionError) Error() string {
+	return r.err.Error()
+}
+
+func (r *rejectionError) Unwrap() error {
+	return r.err
+}
+
+func (r *rejectionError) GRPCStatus() *status.Status {
+	return status.New(codes.Unavailable, r.err.Error())
+}
+
+// rejected Carries the outcome of a call the breaker didn't run, a <nil> one meaning the call
+// shared the result of a half-open probe and gets no reply of its own.
+func rejected(err error) *rejectionError {
+	if err == nil {
+		err = breaker.ErrProbeCoalesced
+	}
+	return &rejectionError{err: err}
+}
@@ -0,0 +1,52 @@
+package breakergrpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	breaker "github.com/gilbertovgl/go-circuit-breaker"
+)
+
+func TestClassifier(t *testing.T) {
+	cb, cancel, err := breaker.New(breaker.WithClassifier(Classifier(nil)))
+	require.NoError(t, err)
+	defer cancel()
+
+	calls := []error{
+		nil,
+		status.Error(codes.Unavailable, "unavailable"),
+		status.Error(codes.DeadlineExceeded, "deadline exceeded"),
+		status.Error(codes.ResourceExhausted, "resource exhausted"),
+		status.Error(codes.NotFound, "not found"),
+		status.Error(codes.InvalidArgument, "invalid argument"),
+		status.Error(codes.Canceled, "canceled"),
+	}
+	for _, call := range calls {
+		_ = cb.Execute(func() error { return call })
+	}
+
+	assert.Equal(t, breaker.Counts{Total: 6, Fail: 3, Success: 3, Ignored: 1}, cb.Counts())
+}
+
+func TestClassifierCustom(t *testing.T) {
+	classify := Classifier(func(code codes.Code) breaker.Outcome {
+		if code == codes.NotFound {
+			return breaker.OutcomeFailure
+		}
+		return DefaultCodeClassifier(code)
+	})
+
+	assert.Equal(t, breaker.OutcomeFailure, classify(status.Error(codes.NotFound, "not found"), 0))
+	assert.Equal(t, breaker.OutcomeSuccess, classify(status.Error(codes.InvalidArgument, "invalid argument"), 0))
+	assert.Equal(t, breaker.OutcomeSuccess, classify(nil, 0))
+}
+
+func TestRejectionError(t *testing.T) {
+	err := &rejectionError{err: breaker.ErrOpenCircuit}
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.ErrorIs(t, err, breaker.ErrOpenCircuit)
+}
@@ -0,0 +1,25 @@
+module github.com/gilbertovgl/go-circuit-breaker/breakergrpc
+
+go 1.21.0
+
+require (
+	github.com/gilbertovgl/go-circuit-breaker v0.0.0
+	github.com/gilbertovgl/go-circuit-breaker/breakeradmin/adminpb v0.0.0
+	github.com/stretchr/testify v1.9.0
+	google.golang.org/grpc v1.64.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/net v0.26.0 // indirect
+	golang.org/x/sys v0.21.0 // indirect
+	golang.org/x/text v0.16.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240318140521-94a12d6c2237 // indirect
+	google.golang.org/protobuf v1.33.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/gilbertovgl/go-circuit-breaker => ../
+
+replace github.com/gilbertovgl/go-circuit-breaker/breakeradmin/adminpb => ../breakeradmin/adminpb
@@ -0,0 +1,127 @@
+package breakergrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	breaker "github.com/gilbertovgl/go-circuit-breaker"
+)
+
+var ErrInvalidSlow = errors.New("invalid slow threshold")
+
+// Overload Sheds the incoming requests of a server while its own handlers fail or slow down,
+// answering them with Unavailable so clients back off or retry elsewhere instead of piling
+// onto a melting service. It guards the server rather than a dependency, so its breaker
+// counts the handlers outcomes. The handlers read how it handled their request with
+// breaker.FromContext, stream handlers from the stream context.
+//
+//	overload, _ := breakergrpc.NewOverload(cb)
+//	grpc.NewServer(
+//		grpc.UnaryInterceptor(overload.UnaryServerInterceptor()),
+//		grpc.StreamInterceptor(overload.StreamServerInterceptor()),
+//	)
+type Overload struct {
+	cb       *breaker.CircuitBreaker
+	classify func(code codes.Code) breaker.Outcome
+	slow     time.Duration
+}
+
+type OverloadOption func(o *Overload) error
+
+// WithOverloadClassifier Sets how the handler status codes count, DefaultCodeClassifier by
+// default which only fails on the codes telling the server is unhealthy.
+func WithOverloadClassifier(classify func(code codes.Code) breaker.Outcome) OverloadOption {
+	return func(o *Overload) error {
+		if classify == nil {
+			return fmt.Errorf("%w: can't be <nil>", ErrInvalidCodeClassifier)
+		}
+		o.classify = classify
+		return nil
+	}
+}
+
+// WithSlowThreshold Counts the handlers outliving threshold as failures whatever their code,
+// so a server slowing down sheds before it starts failing.
+func WithSlowThreshold(threshold time.Duration) OverloadOption {
+	return func(o *Overload) error {
+		if threshold <= 0 {
+			return fmt.Errorf("%w: can't be less than equal zero", ErrInvalidSlow)
+		}
+		o.slow = threshold
+		return nil
+	}
+}
+
+// NewOverload Sheds through cb.
+func NewOverload(cb *breaker.CircuitBreaker, opts ...OverloadOption) (*Overload, error) {
+	o := &Overload{cb: cb, classify: DefaultCodeClassifier}
+	for _, opt := range opts {
+		if err := opt(o); err != nil {
+			return nil, err
+		}
+	}
+	return o, nil
+}
+
+// UnaryServerInterceptor Runs the unary handlers unless the server is shedding, when the
+// requests are answered Unavailable.
+func (o *Overload) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		var resp any
+		err := o.guard(ctx, func(ctx context.Context) error {
+			var err error
+			resp, err = handler(ctx, req)
+			return err
+		})
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor Runs the stream handlers unless the server is shedding, when the
+// streams are answered Unavailable. The stream counts once it ends, so long lived streams
+// weigh on the breaker late.
+func (o *Overload) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return o.guard(ss.Context(), func(ctx context.Context) error {
+			return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+		})
+	}
+}
+
+// serverStream Hands the stream handlers the context the breaker decorated.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func (o *Overload) guard(ctx context.Context, handler func(ctx context.Context) error) error {
+	var handlerErr error
+	ran := false
+	clock := o.cb.Clock()
+	err := o.cb.ExecuteContext(breaker.ContextWithDecision(ctx), func(ctx context.Context) error {
+		ran = true
+		start := clock.Now()
+		handlerErr = handler(ctx)
+		if o.slow > 0 && clock.Now().Sub(start) > o.slow {
+			return breaker.Classified(breaker.OutcomeFailure, nil)
+		}
+		if handlerErr == nil {
+			return nil
+		}
+		return breaker.Classified(o.classify(status.Code(handlerErr)), nil)
+	})
+	if !ran {
+		return rejected(err)
+	}
+	return handlerErr
+}
@@ -0,0 +1,147 @@
+package breakergrpc
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	breaker "github.com/gilbertovgl/go-circuit-breaker"
+	"github.com/gilbertovgl/go-circuit-breaker/breakertest"
+)
+
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (f *fakeServerStream) Context() context.Context {
+	return f.ctx
+}
+
+func TestOverload(t *testing.T) {
+	cb, cancel, err := breaker.New(
+		breaker.WithName("server"),
+		breaker.WithCanTrip(func(summary breaker.Counts) bool { return summary.Fail > 1 }),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	overload, err := NewOverload(cb)
+	require.NoError(t, err)
+	unary := overload.UnaryServerInterceptor()
+	stream := overload.StreamServerInterceptor()
+
+	resp, err := unary(context.Background(), "req", nil, func(ctx context.Context, req any) (any, error) { return "ok", nil })
+	require.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+
+	_, err = unary(context.Background(), "req", nil, func(ctx context.Context, req any) (any, error) {
+		return nil, status.Error(codes.NotFound, "not found")
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	for i := 0; i < 2; i++ {
+		err = stream(nil, &fakeServerStream{ctx: context.Background()}, nil, func(srv any, ss grpc.ServerStream) error {
+			decision, ok := breaker.FromContext(ss.Context())
+			assert.True(t, ok)
+			assert.Equal(t, "server", decision.Breaker)
+			return status.Error(codes.Internal, "internal")
+		})
+		assert.Equal(t, codes.Internal, status.Code(err))
+	}
+	assert.Equal(t, breaker.Counts{Total: 4, Fail: 2, Success: 2}, cb.Counts())
+	require.Equal(t, breaker.Open, cb.State())
+
+	_, err = unary(context.Background(), "req", nil, func(ctx context.Context, req any) (any, error) { return "ok", nil })
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.ErrorIs(t, err, breaker.ErrOpenCircuit)
+}
+
+func TestOverloadShutdown(t *testing.T) {
+	cb, cancel, err := breaker.New()
+	require.NoError(t, err)
+	defer cancel()
+	require.NoError(t, cb.Shutdown(context.Background()))
+
+	overload, err := NewOverload(cb)
+	require.NoError(t, err)
+
+	ran := false
+	_, err = overload.UnaryServerInterceptor()(context.Background(), "req", nil, func(ctx context.Context, req any) (any, error) {
+		ran = true
+		return "ok", nil
+	})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.ErrorIs(t, err, breaker.ErrShutdown)
+	assert.False(t, ran)
+}
+
+func TestOverloadCoalescedProbe(t *testing.T) {
+	cb, cancel, err := breaker.New(breaker.WithProbeCoalescing(nil))
+	require.NoError(t, err)
+	defer cancel()
+	require.NoError(t, breakertest.ForceState(cb, breaker.HalfOpen))
+
+	overload, err := NewOverload(cb)
+	require.NoError(t, err)
+	unary := overload.UnaryServerInterceptor()
+
+	var handled atomic.Int64
+	started, release := make(chan struct{}), make(chan struct{})
+	handler := func(ctx context.Context, req any) (any, error) {
+		handled.Add(1)
+		close(started)
+		<-release
+		return "ok", nil
+	}
+
+	probe := make(chan error, 1)
+	go func() { _, err := unary(context.Background(), "req", nil, handler); probe <- err }()
+	<-started
+	coalesced := make(chan error, 1)
+	go func() { _, err := unary(context.Background(), "req", nil, handler); coalesced <- err }()
+	time.Sleep(time.Millisecond * 50)
+	close(release)
+
+	assert.NoError(t, <-probe)
+	err = <-coalesced
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.ErrorIs(t, err, breaker.ErrProbeCoalesced)
+	assert.Equal(t, int64(1), handled.Load())
+}
+
+func TestOverloadSlowThreshold(t *testing.T) {
+	clock := breakertest.NewClock(time.Unix(0, 0))
+	cb, cancel, err := breaker.New(breaker.WithClock(clock))
+	require.NoError(t, err)
+	defer cancel()
+
+	overload, err := NewOverload(cb, WithSlowThreshold(time.Second))
+	require.NoError(t, err)
+
+	_, err = overload.UnaryServerInterceptor()(context.Background(), "req", nil, func(ctx context.Context, req any) (any, error) {
+		clock.Advance(time.Second * 2)
+		return "late", nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, breaker.Counts{Total: 1, Fail: 1}, cb.Counts())
+}
+
+func TestNewOverloadFails(t *testing.T) {
+	cb, cancel, err := breaker.New()
+	require.NoError(t, err)
+	defer cancel()
+
+	_, err = NewOverload(cb, WithSlowThreshold(0))
+	assert.ErrorIs(t, err, ErrInvalidSlow)
+
+	_, err = NewOverload(cb, WithOverloadClassifier(nil))
+	assert.ErrorIs(t, err, ErrInvalidCodeClassifier)
+}
@@ -0,0 +1,129 @@
+package breakerhttp
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	breaker "github.com/gilbertovgl/go-circuit-breaker"
+	"github.com/gilbertovgl/go-circuit-breaker/breakeradmin"
+)
+
+type modeRequest struct {
+	Mode breaker.Mode `json:"mode"`
+}
+
+// Admin Serves the breakeradmin operations over the registry as JSON:
+//
+//	GET  /breakers
+//	GET  /breakers/{name}
+//	GET  /breakers/{name}/window
+//	GET  /breakers/{name}/events
+//	POST /breakers/{name}/trip
+//	POST /breakers/{name}/reset
+//	POST /breakers/{name}/mode   {"mode": "forced-open"}
+//
+// The handler expects the /breakers prefix, mount it with http.StripPrefix when nested.
+func Admin(registry *breaker.Registry) http.Handler {
+	svc := breakeradmin.NewService(registry)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		if parts[0] != "breakers" || len(parts) > 3 {
+			http.NotFound(w, r)
+			return
+		}
+
+		if len(parts) == 1 {
+			if !allowMethod(w, r, http.MethodGet) {
+				return
+			}
+			statuses, err := svc.ListBreakers(r.Context())
+			writeJSON(w, statuses, err)
+			return
+		}
+
+		name := parts[1]
+		action := ""
+		if len(parts) == 3 {
+			action = parts[2]
+		}
+
+		switch action {
+		case "":
+			if allowMethod(w, r, http.MethodGet) {
+				status, err := svc.GetStatus(r.Context(), name)
+				writeJSON(w, status, err)
+			}
+
+		case "window", "events":
+			if !allowMethod(w, r, http.MethodGet) {
+				return
+			}
+			cb, ok := registry.Get(name)
+			if !ok {
+				http.Error(w, breakeradmin.ErrBreakerNotFound.Error(), http.StatusNotFound)
+				return
+			}
+			if action == "window" {
+				writeJSON(w, cb.WindowSnapshot(), nil)
+				return
+			}
+			EventStream(cb).ServeHTTP(w, r)
+
+		case "trip":
+			if allowMethod(w, r, http.MethodPost) {
+				status, err := svc.Trip(r.Context(), name)
+				writeJSON(w, status, err)
+			}
+
+		case "reset":
+			if allowMethod(w, r, http.MethodPost) {
+				status, err := svc.Reset(r.Context(), name)
+				writeJSON(w, status, err)
+			}
+
+		case "mode":
+			if !allowMethod(w, r, http.MethodPost) {
+				return
+			}
+			var req modeRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			status, err := svc.SetMode(r.Context(), name, req.Mode)
+			writeJSON(w, status, err)
+
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+func allowMethod(w http.ResponseWriter, r *http.Request, method string) bool {
+	if r.Method != method {
+		w.Header().Set("Allow", method)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, v any, err error) {
+	switch {
+	case errors.Is(err, breakeradmin.ErrBreakerNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	case errors.Is(err, breaker.ErrInvalidMode):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
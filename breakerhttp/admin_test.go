@@ -0,0 +1,116 @@
+package breakerhttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	breaker "github.com/gilbertovgl/go-circuit-breaker"
+	"github.com/gilbertovgl/go-circuit-breaker/breakeradmin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdmin(t *testing.T) {
+	registry := breaker.NewRegistry()
+	cb, cancel, err := breaker.New(breaker.WithName("payments"))
+	require.NoError(t, err)
+	defer cancel()
+	require.NoError(t, registry.Register(cb))
+
+	handler := Admin(registry)
+	serve := func(method, target, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(method, target, strings.NewReader(body)))
+		return rec
+	}
+
+	tt := []struct {
+		name           string
+		method         string
+		target         string
+		body           string
+		expectedCode   int
+		expectedStatus breakeradmin.Status
+	}{
+		{
+			name:           "get_status",
+			method:         http.MethodGet,
+			target:         "/breakers/payments",
+			expectedCode:   http.StatusOK,
+			expectedStatus: breakeradmin.Status{Name: "payments", State: breaker.Closed, Mode: breaker.ModeNormal},
+		},
+		{
+			name:           "trip",
+			method:         http.MethodPost,
+			target:         "/breakers/payments/trip",
+			expectedCode:   http.StatusOK,
+			expectedStatus: breakeradmin.Status{Name: "payments", State: breaker.Open, Mode: breaker.ModeNormal},
+		},
+		{
+			name:           "set_mode",
+			method:         http.MethodPost,
+			target:         "/breakers/payments/mode",
+			body:           `{"mode":"forced-open"}`,
+			expectedCode:   http.StatusOK,
+			expectedStatus: breakeradmin.Status{Name: "payments", State: breaker.Open, Mode: breaker.ModeForcedOpen},
+		},
+		{
+			name:           "reset",
+			method:         http.MethodPost,
+			target:         "/breakers/payments/reset",
+			expectedCode:   http.StatusOK,
+			expectedStatus: breakeradmin.Status{Name: "payments", State: breaker.Closed, Mode: breaker.ModeForcedOpen},
+		},
+		{
+			name:         "invalid_mode",
+			method:       http.MethodPost,
+			target:       "/breakers/payments/mode",
+			body:         `{"mode":"sideways"}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "trip_requires_post",
+			method:       http.MethodGet,
+			target:       "/breakers/payments/trip",
+			expectedCode: http.StatusMethodNotAllowed,
+		},
+		{
+			name:         "unknown_breaker",
+			method:       http.MethodGet,
+			target:       "/breakers/orders",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "unknown_action",
+			method:       http.MethodGet,
+			target:       "/breakers/payments/explode",
+			expectedCode: http.StatusNotFound,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := serve(tc.method, tc.target, tc.body)
+			require.Equal(t, tc.expectedCode, rec.Code)
+			if tc.expectedCode != http.StatusOK {
+				return
+			}
+
+			var got breakeradmin.Status
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(&got))
+			assert.Equal(t, tc.expectedStatus, got)
+		})
+	}
+
+	rec := serve(http.MethodGet, "/breakers", "")
+	var statuses []breakeradmin.Status
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&statuses))
+	assert.Len(t, statuses, 1)
+
+	rec = serve(http.MethodGet, "/breakers/payments/window", "")
+	var frames []breaker.Frame
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&frames))
+	assert.Len(t, frames, 20)
+}
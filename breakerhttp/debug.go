@@ -0,0 +1,103 @@
+package breakerhttp
+
+import (
+	"bytes"
+	"html/template"
+	"net/http"
+	"strings"
+
+	breaker "github.com/gilbertovgl/go-circuit-breaker"
+)
+
+var debugIndex = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html><head><title>breakers</title></head><body>
+<table>
+<tr><th>name</th><th>state</th><th>mode</th><th>failure rate</th></tr>
+{{range .}}<tr><td><a href="{{.Name}}">{{.Name}}</a></td><td>{{.State}}</td><td>{{.Mode}}</td><td>{{printf "%.1f%%" .Summary.FailureRate}}</td></tr>
+{{end}}</table>
+</body></html>
+`))
+
+var debugPage = template.Must(template.New("breaker").Parse(`<!DOCTYPE html>
+<html><head><title>{{.Name}}</title></head><body>
+<pre>{{.Dump}}</pre>
+</body></html>
+`))
+
+// Debug Serves the debug dump of the registry breakers, like net/http/pprof does profiles:
+//
+//	GET /            index of the breakers
+//	GET /{name}      debug dump of the breaker
+//
+// Pages render as HTML, or as JSON snapshots with ?format=json. The handler expects to be
+// mounted with its prefix stripped, such as
+//
+//	mux.Handle("/debug/breakers/", http.StripPrefix("/debug/breakers", Debug(registry)))
+func Debug(registry *breaker.Registry) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !allowMethod(w, r, http.MethodGet) {
+			return
+		}
+
+		name := strings.Trim(r.URL.Path, "/")
+		if name == "" {
+			breakers := registry.List()
+			snapshots := make([]breaker.Snapshot, 0, len(breakers))
+			for _, cb := range breakers {
+				snapshots = append(snapshots, cb.Snapshot())
+			}
+			if wantsJSON(r) {
+				writeJSON(w, snapshots, nil)
+				return
+			}
+			writeHTML(w, debugIndex, snapshots)
+			return
+		}
+
+		cb, ok := registry.Get(name)
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		serveDebug(w, r, cb)
+	})
+}
+
+// DebugBreaker Serves the debug dump of a single breaker, as HTML or as its JSON snapshot
+// with ?format=json.
+func DebugBreaker(cb *breaker.CircuitBreaker) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if allowMethod(w, r, http.MethodGet) {
+			serveDebug(w, r, cb)
+		}
+	})
+}
+
+func serveDebug(w http.ResponseWriter, r *http.Request, cb *breaker.CircuitBreaker) {
+	if wantsJSON(r) {
+		writeJSON(w, cb.Snapshot(), nil)
+		return
+	}
+
+	var dump bytes.Buffer
+	if err := cb.DebugDump(&dump); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeHTML(w, debugPage, struct{ Name, Dump string }{Name: cb.Name(), Dump: dump.String()})
+}
+
+func wantsJSON(r *http.Request) bool {
+	return r.URL.Query().Get("format") == "json"
+}
+
+func writeHTML(w http.ResponseWriter, tmpl *template.Template, data any) {
+	var page bytes.Buffer
+	if err := tmpl.Execute(&page, data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write(page.Bytes())
+}
@@ -0,0 +1,82 @@
+package breakerhttp
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	breaker "github.com/gilbertovgl/go-circuit-breaker"
+)
+
+func TestDebug(t *testing.T) {
+	registry := breaker.NewRegistry()
+	for _, name := range []string{"payments", "<orders>"} {
+		cb, cancel, err := breaker.New(breaker.WithName(name), breaker.WithErrorHistory(5))
+		require.NoError(t, err)
+		defer cancel()
+		require.NoError(t, registry.Register(cb))
+	}
+	payments, _ := registry.Get("payments")
+	_ = payments.Execute(func() error { return errCall })
+	payments.Trip()
+
+	mux := http.NewServeMux()
+	mux.Handle("/debug/breakers/", http.StripPrefix("/debug/breakers", Debug(registry)))
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	get := func(path string) (*http.Response, string) {
+		resp, err := http.Get(srv.URL + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp, string(body)
+	}
+
+	resp, body := get("/debug/breakers/")
+	assert.Equal(t, "text/html; charset=utf-8", resp.Header.Get("Content-Type"))
+	assert.Contains(t, body, `<a href="payments">payments</a>`)
+	assert.Contains(t, body, "&lt;orders&gt;")
+	assert.NotContains(t, body, "<orders>")
+
+	resp, body = get("/debug/breakers/?format=json")
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	var snapshots []breaker.Snapshot
+	require.NoError(t, json.Unmarshal([]byte(body), &snapshots))
+	require.Len(t, snapshots, 2)
+	assert.Equal(t, breaker.Open, snapshots[1].State)
+
+	_, body = get("/debug/breakers/payments")
+	assert.Contains(t, body, "<pre>breaker &#34;payments&#34;")
+	assert.Contains(t, body, errCall.Error())
+
+	resp, body = get("/debug/breakers/payments?format=json")
+	var snapshot breaker.Snapshot
+	require.NoError(t, json.Unmarshal([]byte(body), &snapshot))
+	assert.Equal(t, "payments", snapshot.Name)
+
+	resp, _ = get("/debug/breakers/unknown")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp, err := http.Post(srv.URL+"/debug/breakers/", "text/plain", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
+
+func TestDebugBreaker(t *testing.T) {
+	cb, cancel, err := breaker.New(breaker.WithName("payments"))
+	require.NoError(t, err)
+	defer cancel()
+
+	rec := httptest.NewRecorder()
+	DebugBreaker(cb).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "closed (normal for")
+}
@@ -0,0 +1,49 @@
+// Package breakerhttp Exposes circuit breakers over net/http.
+package breakerhttp
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	breaker "github.com/gilbertovgl/go-circuit-breaker"
+)
+
+const _eventsBuffer = 64
+
+// EventStream Streams the breaker events as server-sent events until the client disconnects.
+func EventStream(cb *breaker.CircuitBreaker) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+			return
+		}
+
+		events, unsubscribe := cb.Subscribe(_eventsBuffer)
+		defer unsubscribe()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		for {
+			select {
+			case event := <-events:
+				data, err := json.Marshal(event)
+				if err != nil {
+					return
+				}
+				if _, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data); err != nil {
+					return
+				}
+				flusher.Flush()
+
+			case <-r.Context().Done():
+				return
+			}
+		}
+	})
+}
@@ -0,0 +1,54 @@
+package breakerhttp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	breaker "github.com/gilbertovgl/go-circuit-breaker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errCall = errors.New("execute error")
+
+func TestEventStream(t *testing.T) {
+	cb, cancel, err := breaker.New(
+		breaker.WithName("payments"),
+		breaker.WithCanTrip(func(summary breaker.Counts) bool { return summary.Fail > 0 }),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	srv := httptest.NewServer(EventStream(cb))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	_ = cb.Execute(func() error { return errCall })
+
+	reader := bufio.NewReader(resp.Body)
+	eventLine, err := reader.ReadString('\n')
+	require.NoError(t, err)
+	dataLine, err := reader.ReadString('\n')
+	require.NoError(t, err)
+
+	assert.Equal(t, "event: transition\n", eventLine)
+
+	var event breaker.Event
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(dataLine, "data: ")), &event))
+	assert.Equal(t, breaker.EventTransition, event.Type)
+	assert.Equal(t, "payments", event.Breaker)
+	assert.Equal(t, breaker.Closed, event.From)
+	assert.Equal(t, breaker.Open, event.To)
+	assert.Equal(t, breaker.Counts{Total: 1, Fail: 1}, event.Summary)
+}
@@ -0,0 +1,55 @@
+package breakerhttp
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	breaker "github.com/gilbertovgl/go-circuit-breaker"
+)
+
+// Listing Is the line of a breaker in the registry listing.
+type Listing struct {
+	Name        string        `json:"name"`
+	State       breaker.State `json:"state"`
+	FailureRate float64       `json:"failure_rate"`
+	TimeInState time.Duration `json:"time_in_state"`
+	InFlight    int64         `json:"in_flight"`
+}
+
+// List Serves every registry breaker as JSON, filtered down to the states given as
+// ?state=open&state=half-open when any.
+func List(registry *breaker.Registry) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !allowMethod(w, r, http.MethodGet) {
+			return
+		}
+
+		states := map[breaker.State]bool{}
+		for _, s := range r.URL.Query()["state"] {
+			state := breaker.State(s)
+			if state != breaker.Closed && state != breaker.HalfOpen && state != breaker.Open {
+				http.Error(w, fmt.Sprintf("unknown state %q", s), http.StatusBadRequest)
+				return
+			}
+			states[state] = true
+		}
+
+		listings := []Listing{}
+		for _, cb := range registry.List() {
+			snapshot := cb.Snapshot()
+			if len(states) > 0 && !states[snapshot.State] {
+				continue
+			}
+			listings = append(listings, Listing{
+				Name:        snapshot.Name,
+				State:       snapshot.State,
+				FailureRate: snapshot.Summary.FailureRate(),
+				TimeInState: snapshot.TimeInState,
+				InFlight:    snapshot.InFlight,
+			})
+		}
+
+		writeJSON(w, listings, nil)
+	})
+}
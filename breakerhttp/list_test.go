@@ -0,0 +1,71 @@
+package breakerhttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	breaker "github.com/gilbertovgl/go-circuit-breaker"
+)
+
+func TestList(t *testing.T) {
+	registry := breaker.NewRegistry()
+	for _, name := range []string{"payments", "orders", "search"} {
+		cb, cancel, err := breaker.New(breaker.WithName(name))
+		require.NoError(t, err)
+		defer cancel()
+		require.NoError(t, registry.Register(cb))
+	}
+	payments, _ := registry.Get("payments")
+	_ = payments.Execute(func() error { return errCall })
+	_ = payments.Execute(func() error { return nil })
+	payments.Trip()
+	search, _ := registry.Get("search")
+	search.Trip()
+	search.ProbeNow()
+
+	tt := []struct {
+		name     string
+		query    string
+		status   int
+		expected []string
+	}{
+		{name: "lists_every_breaker", query: "", status: http.StatusOK, expected: []string{"orders", "payments", "search"}},
+		{name: "filters_by_state", query: "?state=open", status: http.StatusOK, expected: []string{"payments"}},
+		{name: "filters_by_states", query: "?state=open&state=half-open", status: http.StatusOK, expected: []string{"payments", "search"}},
+		{name: "ignores_repeated_states", query: "?state=closed&state=closed", status: http.StatusOK, expected: []string{"orders"}},
+		{name: "rejects_unknown_state", query: "?state=tripped", status: http.StatusBadRequest},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			List(registry).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/"+tc.query, nil))
+			require.Equal(t, tc.status, rec.Code)
+			if tc.status != http.StatusOK {
+				return
+			}
+
+			var listings []Listing
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &listings))
+			got := []string{}
+			for _, l := range listings {
+				got = append(got, l.Name)
+			}
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+
+	rec := httptest.NewRecorder()
+	List(registry).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/?state=open", nil))
+	var listings []Listing
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &listings))
+	require.Len(t, listings, 1)
+	assert.Equal(t, breaker.Open, listings[0].State)
+	assert.Equal(t, float64(50), listings[0].FailureRate)
+	assert.Less(t, listings[0].TimeInState, payments.Snapshot().Uptime)
+}
@@ -0,0 +1,204 @@
+package breakerhttp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	breaker "github.com/gilbertovgl/go-circuit-breaker"
+)
+
+var (
+	ErrInvalidRejectionStatus    = errors.New("invalid rejection status")
+	ErrInvalidResponseClassifier = errors.New("invalid response classifier")
+)
+
+type Option func(cfg *config) error
+
+type config struct {
+	rejectionStatus    int
+	responseClassifier func(resp *http.Response, err error) breaker.Outcome
+}
+
+func newConfig(opts []Option) (*config, error) {
+	cfg := &config{
+		rejectionStatus:    http.StatusServiceUnavailable,
+		responseClassifier: DefaultResponseClassifier,
+	}
+	for _, opt := range opts {
+		if err := opt(cfg); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+// WithRejectionStatus Sets the status code answered for calls rejected by an open circuit,
+// 503 Service Unavailable by default.
+func WithRejectionStatus(code int) Option {
+	return func(cfg *config) error {
+		if code < 100 || code > 599 {
+			return fmt.Errorf("%w: %d isn't a status code", ErrInvalidRejectionStatus, code)
+		}
+		cfg.rejectionStatus = code
+		return nil
+	}
+}
+
+// WithResponseClassifier Sets how the Transport round trips count toward the breaker, such
+// as ignoring 429 Too Many Requests rather than counting them as failures.
+func WithResponseClassifier(classifier func(resp *http.Response, err error) breaker.Outcome) Option {
+	return func(cfg *config) error {
+		if classifier == nil {
+			return fmt.Errorf("%w: can't be <nil>", ErrInvalidResponseClassifier)
+		}
+		cfg.responseClassifier = classifier
+		return nil
+	}
+}
+
+// DefaultResponseClassifier Counts transport errors, 5xx and 429 responses as failures and
+// any other response as a success, the client errors being the caller fault.
+func DefaultResponseClassifier(resp *http.Response, err error) breaker.Outcome {
+	switch {
+	case err != nil:
+		return breaker.OutcomeFailure
+	case resp.StatusCode >= http.StatusInternalServerError, resp.StatusCode == http.StatusTooManyRequests:
+		return breaker.OutcomeFailure
+	default:
+		return breaker.OutcomeSuccess
+	}
+}
+
+// Middleware Runs the requests through cb counting 5xx responses as failures, requests
+// the breaker turns away without running them are answered with the rejection status and a Retry-After header.
+// The handlers read how the breaker handled the request with breaker.FromContext.
+func Middleware(cb *breaker.CircuitBreaker, opts ...Option) (func(next http.Handler) http.Handler, error) {
+	cfg, err := newConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+			r = r.WithContext(breaker.ContextWithDecision(r.Context()))
+			ran := false
+			err := cb.ExecuteContext(r.Context(), func(context.Context) error {
+				ran = true
+				next.ServeHTTP(sw, r)
+				if sw.status >= http.StatusInternalServerError {
+					return breaker.Classified(breaker.OutcomeFailure, nil)
+				}
+				return nil
+			})
+			if !ran {
+				if err == nil {
+					err = breaker.ErrProbeCoalesced
+				}
+				setRetryAfter(w.Header(), cb)
+				http.Error(w, err.Error(), cfg.rejectionStatus)
+			}
+		})
+	}, nil
+}
+
+// Transport Runs the round trips of base through cb counting them as the response classifier
+// says, round trips rejected by the circuit get a synthesized response with the rejection
+// status and a Retry-After header.
+func Transport(cb *breaker.CircuitBreaker, base http.RoundTripper, opts ...Option) (http.RoundTripper, error) {
+	cfg, err := newConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &transport{cb: cb, base: base, cfg: cfg}, nil
+}
+
+type transport struct {
+	cb   *breaker.CircuitBreaker
+	base http.RoundTripper
+	cfg  *config
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var resp *http.Response
+	ran := false
+	err := t.cb.ExecuteContext(req.Context(), func(ctx context.Context) error {
+		ran = true
+		var err error
+		resp, err = t.base.RoundTrip(req)
+		return breaker.Classified(t.cfg.responseClassifier(resp, err), err)
+	})
+	if !ran && err == nil {
+		err = breaker.ErrProbeCoalesced
+	}
+
+	switch {
+	case breaker.IsRejection(err):
+		return t.rejection(req, err), nil
+	case err != nil:
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (t *transport) rejection(req *http.Request, err error) *http.Response {
+	header := make(http.Header)
+	header.Set("Content-Type", "text/plain; charset=utf-8")
+	setRetryAfter(header, t.cb)
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", t.cfg.rejectionStatus, http.StatusText(t.cfg.rejectionStatus)),
+		StatusCode:    t.cfg.rejectionStatus,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(err.Error())),
+		ContentLength: int64(len(err.Error())),
+		Request:       req,
+	}
+}
+
+// setRetryAfter Advertises the seconds left until the circuit probes again, rounded up and
+// at least one so clients never retry right away.
+func setRetryAfter(header http.Header, cb *breaker.CircuitBreaker) {
+	seconds := int(math.Ceil(cb.TimeUntilHalfOpen().Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	header.Set("Retry-After", strconv.Itoa(seconds))
+}
+
+type statusWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (s *statusWriter) WriteHeader(code int) {
+	if !s.wroteHeader {
+		s.status = code
+		s.wroteHeader = true
+	}
+	s.ResponseWriter.WriteHeader(code)
+}
+
+func (s *statusWriter) Write(b []byte) (int, error) {
+	s.wroteHeader = true
+	return s.ResponseWriter.Write(b)
+}
+
+func (s *statusWriter) Unwrap() http.ResponseWriter {
+	return s.ResponseWriter
+}
@@ -0,0 +1,305 @@
+package breakerhttp
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	breaker "github.com/gilbertovgl/go-circuit-breaker"
+	"github.com/gilbertovgl/go-circuit-breaker/breakertest"
+)
+
+func newTrippingBreaker(t *testing.T, clock *breakertest.Clock) *breaker.CircuitBreaker {
+	cb, cancel, err := breaker.New(
+		breaker.WithClock(clock),
+		breaker.WithOpenDuration(30),
+		breaker.WithCanTrip(func(summary breaker.Counts) bool { return summary.Fail > 0 }),
+	)
+	require.NoError(t, err)
+	t.Cleanup(cancel)
+	return cb
+}
+
+func TestMiddleware(t *testing.T) {
+	clock := breakertest.NewClock(time.Unix(0, 0))
+	cb := newTrippingBreaker(t, clock)
+
+	status := http.StatusOK
+	var decision breaker.Decision
+	middleware, err := Middleware(cb, WithRejectionStatus(http.StatusTooManyRequests))
+	require.NoError(t, err)
+	handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		decision, _ = breaker.FromContext(r.Context())
+		w.WriteHeader(status)
+	}))
+
+	serve := func() *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		return rec
+	}
+
+	assert.Equal(t, http.StatusOK, serve().Code)
+	assert.Equal(t, breaker.Closed, cb.State())
+	assert.Equal(t, cb.Name(), decision.Breaker)
+	assert.Equal(t, breaker.Closed, decision.State)
+
+	status = http.StatusBadGateway
+	assert.Equal(t, http.StatusBadGateway, serve().Code)
+	require.Equal(t, breaker.Open, cb.State())
+
+	clock.Advance(10*time.Second + time.Millisecond)
+	rec := serve()
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "20", rec.Header().Get("Retry-After"))
+}
+
+func TestMiddlewareRejections(t *testing.T) {
+	tt := []struct {
+		name   string
+		new    func() (*breaker.CircuitBreaker, func(), error)
+		reject func(cb *breaker.CircuitBreaker)
+	}{
+		{
+			name: "shed",
+			new: func() (*breaker.CircuitBreaker, func(), error) {
+				return breaker.New(
+					breaker.WithPriorityShedding(1, 1),
+					breaker.WithCanTrip(func(summary breaker.Counts) bool { return false }),
+				)
+			},
+			reject: func(cb *breaker.CircuitBreaker) {
+				for i := 0; i < 11; i++ {
+					_ = cb.Execute(func() error { return errors.New("failed") })
+				}
+			},
+		},
+		{
+			name: "shutdown",
+			new: func() (*breaker.CircuitBreaker, func(), error) {
+				return breaker.New()
+			},
+			reject: func(cb *breaker.CircuitBreaker) {
+				require.NoError(t, cb.Shutdown(context.Background()))
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			cb, cancel, err := tc.new()
+			require.NoError(t, err)
+			t.Cleanup(cancel)
+			tc.reject(cb)
+
+			ran := false
+			middleware, err := Middleware(cb)
+			require.NoError(t, err)
+			handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				ran = true
+			}))
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+			assert.False(t, ran)
+			assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+			assert.NotEmpty(t, rec.Header().Get("Retry-After"))
+		})
+	}
+}
+
+func TestTransport(t *testing.T) {
+	clock := breakertest.NewClock(time.Unix(0, 0))
+	cb := newTrippingBreaker(t, clock)
+
+	status := http.StatusOK
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	rt, err := Transport(cb, nil)
+	require.NoError(t, err)
+	client := &http.Client{Transport: rt}
+
+	resp, err := client.Get(srv.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	status = http.StatusInternalServerError
+	resp, err = client.Get(srv.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	require.Equal(t, breaker.Open, cb.State())
+
+	resp, err = client.Get(srv.URL)
+	require.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, "30", resp.Header.Get("Retry-After"))
+	assert.Equal(t, breaker.ErrOpenCircuit.Error(), string(body))
+	assert.Equal(t, 2, calls)
+}
+
+func TestRejectionStatusFails(t *testing.T) {
+	cb, cancel, err := breaker.New()
+	require.NoError(t, err)
+	defer cancel()
+
+	_, err = Middleware(cb, WithRejectionStatus(0))
+	assert.ErrorIs(t, err, ErrInvalidRejectionStatus)
+	_, err = Transport(cb, nil, WithRejectionStatus(600))
+	assert.ErrorIs(t, err, ErrInvalidRejectionStatus)
+}
+
+func TestTransportResponseClassifier(t *testing.T) {
+	ignoreTooManyRequests := func(resp *http.Response, err error) breaker.Outcome {
+		if err == nil && resp.StatusCode == http.StatusTooManyRequests {
+			return breaker.OutcomeIgnored
+		}
+		return DefaultResponseClassifier(resp, err)
+	}
+
+	tt := []struct {
+		name     string
+		opts     []Option
+		status   int
+		expected breaker.Counts
+	}{
+		{name: "server_error_fails", status: http.StatusBadGateway, expected: breaker.Counts{Total: 1, Fail: 1}},
+		{name: "too_many_requests_fails", status: http.StatusTooManyRequests, expected: breaker.Counts{Total: 1, Fail: 1}},
+		{name: "client_error_succeeds", status: http.StatusNotFound, expected: breaker.Counts{Total: 1, Success: 1}},
+		{name: "too_many_requests_ignored", opts: []Option{WithResponseClassifier(ignoreTooManyRequests)}, status: http.StatusTooManyRequests, expected: breaker.Counts{Ignored: 1}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			cb, cancel, err := breaker.New()
+			require.NoError(t, err)
+			defer cancel()
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+			}))
+			defer srv.Close()
+
+			rt, err := Transport(cb, nil, tc.opts...)
+			require.NoError(t, err)
+
+			resp, err := (&http.Client{Transport: rt}).Get(srv.URL)
+			require.NoError(t, err)
+			resp.Body.Close()
+			assert.Equal(t, tc.status, resp.StatusCode)
+			assert.Equal(t, tc.expected, cb.Counts())
+		})
+	}
+
+	cb, cancel, err := breaker.New()
+	require.NoError(t, err)
+	defer cancel()
+
+	rt, err := Transport(cb, nil)
+	require.NoError(t, err)
+	_, err = (&http.Client{Transport: rt}).Get("http://127.0.0.1:1")
+	assert.Error(t, err)
+	assert.Equal(t, breaker.Counts{Total: 1, Fail: 1}, cb.Counts())
+
+	_, err = Transport(cb, nil, WithResponseClassifier(nil))
+	assert.ErrorIs(t, err, ErrInvalidResponseClassifier)
+}
+
+// coalescedPair Runs first as the half-open probe and second while it is in flight, so the
+// breaker coalesces second into first.
+func coalescedPair(started, release chan struct{}, first, second func()) {
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() { defer wg.Done(); first() }()
+	<-started
+	go func() { defer wg.Done(); second() }()
+
+	time.Sleep(time.Millisecond * 50)
+	close(release)
+	wg.Wait()
+}
+
+func newCoalescingBreaker(t *testing.T) *breaker.CircuitBreaker {
+	cb, cancel, err := breaker.New(breaker.WithProbeCoalescing(nil))
+	require.NoError(t, err)
+	t.Cleanup(cancel)
+	require.NoError(t, breakertest.ForceState(cb, breaker.HalfOpen))
+	return cb
+}
+
+func TestMiddlewareCoalescedProbe(t *testing.T) {
+	cb := newCoalescingBreaker(t)
+	started, release := make(chan struct{}), make(chan struct{})
+	var calls atomic.Int64
+
+	middleware, err := Middleware(cb)
+	require.NoError(t, err)
+	handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		close(started)
+		<-release
+	}))
+
+	probe, coalesced := httptest.NewRecorder(), httptest.NewRecorder()
+	coalescedPair(started, release,
+		func() { handler.ServeHTTP(probe, httptest.NewRequest(http.MethodGet, "/", nil)) },
+		func() { handler.ServeHTTP(coalesced, httptest.NewRequest(http.MethodGet, "/", nil)) },
+	)
+
+	assert.Equal(t, int64(1), calls.Load())
+	assert.Equal(t, http.StatusOK, probe.Code)
+	assert.Equal(t, http.StatusServiceUnavailable, coalesced.Code)
+	assert.Contains(t, coalesced.Body.String(), breaker.ErrProbeCoalesced.Error())
+}
+
+func TestTransportCoalescedProbe(t *testing.T) {
+	cb := newCoalescingBreaker(t)
+	started, release := make(chan struct{}), make(chan struct{})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+	}))
+	defer srv.Close()
+
+	rt, err := Transport(cb, nil)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+	require.NoError(t, err)
+
+	var probe, coalesced *http.Response
+	var probeErr, coalescedErr error
+	coalescedPair(started, release,
+		func() { probe, probeErr = rt.RoundTrip(req.Clone(context.Background())) },
+		func() { coalesced, coalescedErr = rt.RoundTrip(req.Clone(context.Background())) },
+	)
+
+	require.NoError(t, probeErr)
+	probe.Body.Close()
+	assert.Equal(t, http.StatusOK, probe.StatusCode)
+
+	require.NoError(t, coalescedErr)
+	require.NotNil(t, coalesced)
+	body, _ := io.ReadAll(coalesced.Body)
+	coalesced.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, coalesced.StatusCode)
+	assert.Equal(t, breaker.ErrProbeCoalesced.Error(), string(body))
+}
@@ -0,0 +1,205 @@
+// Package breakerhystrix Mirrors the hystrix-go command API on top of circuit breakers kept
+// in a Registry, so services still on hystrix-go can switch by changing their import.
+package breakerhystrix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	breaker "github.com/gilbertovgl/go-circuit-breaker"
+)
+
+const (
+	_defaultTimeout               = time.Millisecond * 1000
+	_defaultMaxConcurrentRequests = 10
+)
+
+var (
+	// ErrCircuitOpen Is returned, or handed to the fallback, when the circuit rejects the run.
+	ErrCircuitOpen = breaker.ErrOpenCircuit
+	// ErrMaxConcurrency Is returned when the command already runs MaxConcurrentRequests times.
+	ErrMaxConcurrency = errors.New("max concurrency")
+	// ErrTimeout Is returned when the run outlives the command timeout, it keeps running in
+	// the background and its outcome is discarded.
+	ErrTimeout = errors.New("timeout")
+)
+
+// CommandConfig Is the hystrix-go command configuration, see breaker.HystrixConfig.
+type CommandConfig = breaker.HystrixConfig
+
+type command struct {
+	cb      *breaker.CircuitBreaker
+	cancel  func()
+	tickets chan struct{}
+	timeout time.Duration
+}
+
+// Commands Runs hystrix-go commands, each backed by a breaker registered under its name
+// the first time it runs.
+type Commands struct {
+	registry *breaker.Registry
+	configs  map[string]CommandConfig
+	commands map[string]*command
+
+	mu sync.Mutex
+}
+
+func New(registry *breaker.Registry) *Commands {
+	return &Commands{
+		registry: registry,
+		configs:  make(map[string]CommandConfig),
+		commands: make(map[string]*command),
+	}
+}
+
+// Configure Sets the configuration of several commands at once.
+func (c *Commands) Configure(cmds map[string]CommandConfig) {
+	for name, cfg := range cmds {
+		c.ConfigureCommand(name, cfg)
+	}
+}
+
+// ConfigureCommand Sets the configuration of the command name, which must happen before it
+// first runs as its breaker is built then.
+func (c *Commands) ConfigureCommand(name string, cfg CommandConfig) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.configs[name] = cfg
+}
+
+func (c *Commands) command(name string) (*command, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if cmd, ok := c.commands[name]; ok {
+		return cmd, nil
+	}
+
+	cfg := c.configs[name]
+	cb, cancel, err := breaker.New(breaker.WithName(name), breaker.WithHystrix(cfg))
+	if err != nil {
+		return nil, err
+	}
+	if err := c.registry.Register(cb); err != nil {
+		cancel()
+		return nil, err
+	}
+
+	cmd := &command{
+		cb:      cb,
+		cancel:  cancel,
+		tickets: make(chan struct{}, orDefault(cfg.MaxConcurrentRequests, _defaultMaxConcurrentRequests)),
+		timeout: _defaultTimeout,
+	}
+	if cfg.Timeout > 0 {
+		cmd.timeout = time.Millisecond * time.Duration(cfg.Timeout)
+	}
+	c.commands[name] = cmd
+
+	return cmd, nil
+}
+
+// Do Runs the command name synchronously, handing the error of run or of its rejection to
+// fallback when not nil.
+func (c *Commands) Do(name string, run func() error, fallback func(error) error) error {
+	var fallbackC func(context.Context, error) error
+	if fallback != nil {
+		fallbackC = func(_ context.Context, err error) error { return fallback(err) }
+	}
+	return c.DoC(context.Background(), name, func(context.Context) error { return run() }, fallbackC)
+}
+
+// DoC Runs the command name synchronously as Do does, with run given a context cancelled
+// once the command times out.
+func (c *Commands) DoC(ctx context.Context, name string, run func(context.Context) error, fallback func(context.Context, error) error) error {
+	cmd, err := c.command(name)
+	if err != nil {
+		return err
+	}
+	ctx = breaker.ContextWithDecision(ctx)
+
+	select {
+	case cmd.tickets <- struct{}{}:
+		defer func() { <-cmd.tickets }()
+		err = cmd.cb.ExecuteContext(ctx, cmd.timed(run))
+	default:
+		err = ErrMaxConcurrency
+	}
+
+	if err == nil || fallback == nil {
+		return err
+	}
+	breaker.MarkFallback(ctx)
+	if fallbackErr := fallback(ctx, err); fallbackErr != nil {
+		return fmt.Errorf("fallback failed with '%w'. run error was '%w'", fallbackErr, err)
+	}
+	return nil
+}
+
+// timed Bounds run by the command timeout, returning ErrTimeout without waiting for it once
+// exceeded.
+func (cmd *command) timed(run func(context.Context) error) func(context.Context) error {
+	return func(parent context.Context) error {
+		ctx, cancel := context.WithTimeout(parent, cmd.timeout)
+		defer cancel()
+
+		done := make(chan error, 1)
+		go func() { done <- run(ctx) }()
+
+		select {
+		case err := <-done:
+			return err
+		case <-ctx.Done():
+			if err := parent.Err(); err != nil {
+				return err
+			}
+			return ErrTimeout
+		}
+	}
+}
+
+// Go Runs the command name asynchronously, sending the error Do would return on the channel,
+// which receives nothing when the command succeeds.
+func (c *Commands) Go(name string, run func() error, fallback func(error) error) chan error {
+	errs := make(chan error, 1)
+	go func() {
+		if err := c.Do(name, run, fallback); err != nil {
+			errs <- err
+		}
+	}()
+	return errs
+}
+
+// GoC Runs the command name asynchronously as Go does, with run given a context cancelled
+// once the command times out.
+func (c *Commands) GoC(ctx context.Context, name string, run func(context.Context) error, fallback func(context.Context, error) error) chan error {
+	errs := make(chan error, 1)
+	go func() {
+		if err := c.DoC(ctx, name, run, fallback); err != nil {
+			errs <- err
+		}
+	}()
+	return errs
+}
+
+// Close Stops the breakers of the commands and removes them from the registry.
+func (c *Commands) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for name, cmd := range c.commands {
+		cmd.cancel()
+		c.registry.Remove(name)
+		delete(c.commands, name)
+	}
+}
+
+func orDefault(value, fallback int) int {
+	if value == 0 {
+		return fallback
+	}
+	return value
+}
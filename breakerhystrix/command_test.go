@@ -0,0 +1,78 @@
+package breakerhystrix
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	breaker "github.com/gilbertovgl/go-circuit-breaker"
+)
+
+var errRun = errors.New("run failed")
+
+func TestDo(t *testing.T) {
+	commands := New(breaker.NewRegistry())
+	defer commands.Close()
+	commands.ConfigureCommand("payments", CommandConfig{RequestVolumeThreshold: 2, ErrorPercentThreshold: 50})
+
+	require.NoError(t, commands.Do("payments", func() error { return nil }, nil))
+	assert.ErrorIs(t, commands.Do("payments", func() error { return errRun }, nil), errRun)
+
+	var fallbackErr error
+	require.NoError(t, commands.Do("payments", func() error { return nil }, func(err error) error {
+		fallbackErr = err
+		return nil
+	}))
+	assert.ErrorIs(t, fallbackErr, ErrCircuitOpen)
+
+	errFallback := errors.New("no fallback")
+	err := commands.Do("payments", func() error { return nil }, func(err error) error { return errFallback })
+	assert.ErrorIs(t, err, errFallback)
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+
+	cb, ok := commands.registry.Get("payments")
+	require.True(t, ok)
+	assert.Equal(t, breaker.Open, cb.State())
+}
+
+func TestDoTimeout(t *testing.T) {
+	commands := New(breaker.NewRegistry())
+	defer commands.Close()
+	commands.ConfigureCommand("slow", CommandConfig{Timeout: 10})
+
+	cancelled := make(chan struct{})
+	err := commands.DoC(context.Background(), "slow", func(ctx context.Context) error {
+		<-ctx.Done()
+		close(cancelled)
+		return ctx.Err()
+	}, nil)
+	assert.ErrorIs(t, err, ErrTimeout)
+	<-cancelled
+}
+
+func TestDoMaxConcurrency(t *testing.T) {
+	commands := New(breaker.NewRegistry())
+	defer commands.Close()
+	commands.ConfigureCommand("busy", CommandConfig{MaxConcurrentRequests: 1, Timeout: 1000})
+
+	release := make(chan struct{})
+	errs := commands.Go("busy", func() error {
+		<-release
+		return nil
+	}, nil)
+
+	require.Eventually(t, func() bool {
+		return errors.Is(commands.Do("busy", func() error { return nil }, nil), ErrMaxConcurrency)
+	}, time.Second, time.Millisecond*10)
+
+	close(release)
+	select {
+	case err := <-errs:
+		t.Fatalf("unexpected error %v", err)
+	case <-time.After(time.Millisecond * 50):
+	}
+}
@@ -0,0 +1,39 @@
+package breakerhystrix
+
+import (
+	"context"
+
+	breaker "github.com/gilbertovgl/go-circuit-breaker"
+)
+
+var std = New(breaker.NewRegistry())
+
+// Registry Returns the registry holding the breakers of the package level commands, to hand
+// to the admin tooling.
+func Registry() *breaker.Registry {
+	return std.registry
+}
+
+func Configure(cmds map[string]CommandConfig) {
+	std.Configure(cmds)
+}
+
+func ConfigureCommand(name string, cfg CommandConfig) {
+	std.ConfigureCommand(name, cfg)
+}
+
+func Do(name string, run func() error, fallback func(error) error) error {
+	return std.Do(name, run, fallback)
+}
+
+func DoC(ctx context.Context, name string, run func(context.Context) error, fallback func(context.Context, error) error) error {
+	return std.DoC(ctx, name, run, fallback)
+}
+
+func Go(name string, run func() error, fallback func(error) error) chan error {
+	return std.Go(name, run, fallback)
+}
+
+func GoC(ctx context.Context, name string, run func(context.Context) error, fallback func(context.Context, error) error) chan error {
+	return std.GoC(ctx, name, run, fallback)
+}
@@ -0,0 +1,130 @@
+// Package breakermemberlist Gossips breaker trips between the instances of a fleet over
+// hashicorp/memberlist, kept in its own module so the core package doesn't depend on it.
+package breakermemberlist
+
+import (
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+
+	"github.com/hashicorp/memberlist"
+
+	breaker "github.com/gilbertovgl/go-circuit-breaker"
+)
+
+// Transport Is a breaker.FleetTransport piggybacking the events on the memberlist gossip,
+// each event being retransmitted a few times per member so it reaches the whole fleet
+// without a broker.
+//
+//	transport, list, _ := breakermemberlist.New(memberlist.DefaultLANConfig())
+//	_, _ = list.Join(seeds)
+//	cb, cancel, _ := breaker.New(breaker.WithName("payments"), breaker.WithFleet(list.LocalNode().Name, transport, 2))
+type Transport struct {
+	list     atomic.Pointer[memberlist.Memberlist]
+	queue    *memberlist.TransmitLimitedQueue
+	handlers map[uint64]func(event breaker.FleetEvent)
+	next     uint64
+
+	mu sync.RWMutex
+}
+
+var (
+	_ breaker.FleetTransport = (*Transport)(nil)
+	_ memberlist.Delegate    = (*Transport)(nil)
+)
+
+// New Creates the memberlist from cfg with the transport as its delegate, which replaces any
+// delegate set on cfg. The caller joins the fleet and leaves it through the memberlist.
+func New(cfg *memberlist.Config) (*Transport, *memberlist.Memberlist, error) {
+	t := &Transport{handlers: make(map[uint64]func(event breaker.FleetEvent))}
+	t.queue = &memberlist.TransmitLimitedQueue{
+		NumNodes:       t.numNodes,
+		RetransmitMult: cfg.RetransmitMult,
+	}
+
+	cfg.Delegate = t
+	list, err := memberlist.Create(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	t.list.Store(list)
+
+	return t, list, nil
+}
+
+func (t *Transport) numNodes() int {
+	if list := t.list.Load(); list != nil {
+		return list.NumMembers()
+	}
+	return 1
+}
+
+// Broadcast Queues event for the next gossip rounds, replacing the event of the same breaker
+// and node still queued.
+func (t *Transport) Broadcast(event breaker.FleetEvent) error {
+	msg, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	t.queue.QueueBroadcast(&broadcast{key: event.Breaker + "/" + event.Node, msg: msg})
+	return nil
+}
+
+func (t *Transport) Subscribe(handler func(event breaker.FleetEvent)) func() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	id := t.next
+	t.next++
+	t.handlers[id] = handler
+
+	return func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		delete(t.handlers, id)
+	}
+}
+
+func (t *Transport) NotifyMsg(msg []byte) {
+	var event breaker.FleetEvent
+	if err := json.Unmarshal(msg, &event); err != nil {
+		return
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	for _, handler := range t.handlers {
+		handler(event)
+	}
+}
+
+func (t *Transport) GetBroadcasts(overhead, limit int) [][]byte {
+	return t.queue.GetBroadcasts(overhead, limit)
+}
+
+func (t *Transport) NodeMeta(limit int) []byte {
+	return nil
+}
+
+func (t *Transport) LocalState(join bool) []byte {
+	return nil
+}
+
+func (t *Transport) MergeRemoteState(buf []byte, join bool) {}
+
+// broadcast Is a queued event, a newer event of the same breaker and node invalidating it.
+type broadcast struct {
+	key string
+	msg []byte
+}
+
+func (b *broadcast) Invalidates(other memberlist.Broadcast) bool {
+	o, ok := other.(*broadcast)
+	return ok && o.key == b.key
+}
+
+func (b *broadcast) Message() []byte {
+	return b.msg
+}
+
+func (b *broadcast) Finished() {}
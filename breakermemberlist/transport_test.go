@@ -0,0 +1,62 @@
+package breakermemberlist
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/memberlist"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	breaker "github.com/gilbertovgl/go-circuit-breaker"
+)
+
+func newNode(t *testing.T, name string) (*Transport, *memberlist.Memberlist) {
+	cfg := memberlist.DefaultLocalConfig()
+	cfg.Name = name
+	cfg.BindAddr = "127.0.0.1"
+	cfg.BindPort = 0
+	cfg.LogOutput = io.Discard
+
+	transport, list, err := New(cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = list.Shutdown() })
+	return transport, list
+}
+
+func TestTransportPropagatesTrips(t *testing.T) {
+	transportA, listA := newNode(t, "node-a")
+	transportB, listB := newNode(t, "node-b")
+	_, err := listB.Join([]string{listA.LocalNode().Address()})
+	require.NoError(t, err)
+
+	cbA, cancelA, err := breaker.New(breaker.WithName("payments"), breaker.WithFleet("node-a", transportA, 1))
+	require.NoError(t, err)
+	defer cancelA()
+	cbB, cancelB, err := breaker.New(breaker.WithName("payments"), breaker.WithFleet("node-b", transportB, 1))
+	require.NoError(t, err)
+	defer cancelB()
+
+	cbA.Trip()
+	assert.Eventually(t, func() bool { return cbB.State() == breaker.Open }, time.Second*5, time.Millisecond*20)
+}
+
+func TestTransportUnsubscribe(t *testing.T) {
+	transport, _ := newNode(t, "node-a")
+
+	received := 0
+	unsubscribe := transport.Subscribe(func(breaker.FleetEvent) { received++ })
+	transport.NotifyMsg([]byte(`{"Breaker":"payments","Node":"node-b","State":"open"}`))
+	unsubscribe()
+	transport.NotifyMsg([]byte(`{"Breaker":"payments","Node":"node-b","State":"open"}`))
+	transport.NotifyMsg([]byte(`not json`))
+
+	assert.Equal(t, 1, received)
+}
+
+func TestBroadcastInvalidates(t *testing.T) {
+	older := &broadcast{key: "payments/node-a"}
+	assert.True(t, (&broadcast{key: "payments/node-a"}).Invalidates(older))
+	assert.False(t, (&broadcast{key: "orders/node-a"}).Invalidates(older))
+}
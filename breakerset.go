@@ -0,0 +1,110 @@
+package breaker
+
+import (
+	"sort"
+	"sync"
+)
+
+type setBreaker struct {
+	cb     *CircuitBreaker
+	cancel func()
+}
+
+// breakerSet Lazily creates a breaker per key from shared options, naming each after its key
+// behind an optional prefix.
+type breakerSet struct {
+	opts     []option
+	prefix   string
+	breakers map[string]setBreaker
+
+	mu sync.RWMutex
+}
+
+func newBreakerSet(opts []option) *breakerSet {
+	return &breakerSet{
+		opts:     opts,
+		breakers: make(map[string]setBreaker),
+	}
+}
+
+func (s *breakerSet) get(key string) (*CircuitBreaker, error) {
+	s.mu.RLock()
+	sb, ok := s.breakers[key]
+	s.mu.RUnlock()
+	if ok {
+		return sb.cb, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if sb, ok := s.breakers[key]; ok {
+		return sb.cb, nil
+	}
+
+	opts := append(append([]option{}, s.opts...), WithName(s.prefix+key))
+	cb, cancel, err := New(opts...)
+	if err != nil {
+		return nil, err
+	}
+	s.breakers[key] = setBreaker{cb: cb, cancel: cancel}
+
+	return cb, nil
+}
+
+// lookup Returns the breaker of key without creating it.
+func (s *breakerSet) lookup(key string) (*CircuitBreaker, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	sb, ok := s.breakers[key]
+	return sb.cb, ok
+}
+
+// configure Replaces the options of the breakers created from now on.
+func (s *breakerSet) configure(opts []option) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.opts = opts
+}
+
+func (s *breakerSet) len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.breakers)
+}
+
+func (s *breakerSet) list() []*CircuitBreaker {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	breakers := make([]*CircuitBreaker, 0, len(s.breakers))
+	for _, sb := range s.breakers {
+		breakers = append(breakers, sb.cb)
+	}
+	sort.Slice(breakers, func(i, j int) bool { return breakers[i].name < breakers[j].name })
+
+	return breakers
+}
+
+// retain Stops and forgets the breakers whose key isn't in keys.
+func (s *breakerSet) retain(keys []string) {
+	kept := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		kept[key] = struct{}{}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, sb := range s.breakers {
+		if _, ok := kept[key]; !ok {
+			sb.cancel()
+			delete(s.breakers, key)
+		}
+	}
+}
+
+func (s *breakerSet) close() {
+	s.retain(nil)
+}
@@ -0,0 +1,125 @@
+// Package breakersim Replays recorded or synthetic traffic through a breaker on virtual time,
+// reporting how it would have behaved so thresholds can be tuned offline.
+package breakersim
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	breaker "github.com/gilbertovgl/go-circuit-breaker"
+	"github.com/gilbertovgl/go-circuit-breaker/breakertest"
+)
+
+// ErrSimulated Is the error of the failing calls Generate produces.
+var ErrSimulated = errors.New("simulated failure")
+
+// Factory Creates the breaker under simulation, it must pass clock to breaker.WithClock.
+type Factory func(clock breaker.Clock) (*breaker.CircuitBreaker, func(), error)
+
+// Step Is one call of the replayed traffic, At being its offset from the start of the replay.
+type Step struct {
+	At      time.Duration
+	Err     error
+	Latency time.Duration
+}
+
+// Result Is how the breaker behaved over the replay.
+type Result struct {
+	Calls       int                  `json:"calls"`
+	Rejected    int                  `json:"rejected"`
+	Transitions []breaker.Transition `json:"transitions"`
+	TimeOpen    time.Duration        `json:"time_open"`
+	Duration    time.Duration        `json:"duration"`
+	Counts      breaker.Counts       `json:"counts"`
+}
+
+// Start Is the virtual time replays start at.
+var Start = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// Replay Runs steps, sorted by At, through the breaker newBreaker creates. Steps overlapping
+// the latency of the previous one run as soon as it returns.
+func Replay(newBreaker Factory, steps []Step) (Result, error) {
+	clock := breakertest.NewClock(Start)
+	cb, cancel, err := newBreaker(clock)
+	if err != nil {
+		return Result{}, err
+	}
+	defer cancel()
+
+	var result Result
+	for i, step := range steps {
+		if i > 0 && step.At < steps[i-1].At {
+			return Result{}, fmt.Errorf("step %d at %s precedes the previous one", i, step.At)
+		}
+		if at := Start.Add(step.At); at.After(clock.Now()) {
+			clock.Advance(at.Sub(clock.Now()))
+		}
+
+		admitted := false
+		_ = cb.Execute(func() error {
+			admitted = true
+			clock.Advance(step.Latency)
+			return step.Err
+		})
+
+		result.Calls++
+		if !admitted {
+			result.Rejected++
+		}
+		result.Transitions = appendNew(result.Transitions, cb.Transitions(0))
+	}
+
+	end := clock.Now()
+	result.Duration = end.Sub(Start)
+	result.TimeOpen = timeOpen(result.Transitions, end)
+	result.Counts = cb.Counts()
+	return result, nil
+}
+
+// appendNew Appends the transitions of history newer than the last one seen, history being
+// the bounded list the breaker keeps.
+func appendNew(seen, history []breaker.Transition) []breaker.Transition {
+	if len(seen) == 0 {
+		return append(seen, history...)
+	}
+
+	last := seen[len(seen)-1]
+	for i := len(history) - 1; i >= 0; i-- {
+		if history[i] == last {
+			return append(seen, history[i+1:]...)
+		}
+	}
+	return append(seen, history...)
+}
+
+func timeOpen(transitions []breaker.Transition, end time.Time) time.Duration {
+	var total time.Duration
+	var openedAt time.Time
+	for _, t := range transitions {
+		switch {
+		case t.To == breaker.Open && openedAt.IsZero():
+			openedAt = t.Time
+		case t.To != breaker.Open && !openedAt.IsZero():
+			total += t.Time.Sub(openedAt)
+			openedAt = time.Time{}
+		}
+	}
+	if !openedAt.IsZero() {
+		total += end.Sub(openedAt)
+	}
+	return total
+}
+
+// Generate Produces n calls every interval apart, failing with ErrSimulated those fail picks
+// by their index.
+func Generate(n int, every time.Duration, fail func(i int) bool) []Step {
+	steps := make([]Step, n)
+	for i := range steps {
+		steps[i].At = time.Duration(i) * every
+		if fail(i) {
+			steps[i].Err = ErrSimulated
+		}
+	}
+	return steps
+}
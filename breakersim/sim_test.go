@@ -0,0 +1,56 @@
+package breakersim
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	breaker "github.com/gilbertovgl/go-circuit-breaker"
+)
+
+func factory(clock breaker.Clock) (*breaker.CircuitBreaker, func(), error) {
+	return breaker.New(
+		breaker.WithClock(clock),
+		breaker.WithCanTrip(func(summary breaker.Counts) bool {
+			return summary.Total >= 5 && summary.FailureRate() >= 50
+		}),
+		breaker.WithOpenDuration(1),
+		breaker.WithHalfOpenEvaluation(1, 1),
+	)
+}
+
+func TestReplay(t *testing.T) {
+	steps := Generate(100, 100*time.Millisecond, func(i int) bool { return i >= 10 && i < 20 })
+
+	result, err := Replay(factory, steps)
+	require.NoError(t, err)
+
+	assert.Equal(t, 100, result.Calls)
+	assert.Equal(t, 9, result.Rejected)
+	assert.Equal(t, time.Second, result.TimeOpen)
+	assert.Equal(t, 9900*time.Millisecond, result.Duration)
+
+	require.Len(t, result.Transitions, 3)
+	assert.Equal(t, breaker.Open, result.Transitions[0].To)
+	assert.Equal(t, Start.Add(1900*time.Millisecond), result.Transitions[0].Time)
+	assert.Equal(t, breaker.HalfOpen, result.Transitions[1].To)
+	assert.Equal(t, breaker.Closed, result.Transitions[2].To)
+}
+
+func TestReplayLatency(t *testing.T) {
+	steps := []Step{
+		{At: 0, Latency: time.Second},
+		{At: 500 * time.Millisecond, Latency: time.Second},
+	}
+
+	result, err := Replay(factory, steps)
+	require.NoError(t, err)
+	assert.Equal(t, 2*time.Second, result.Duration)
+}
+
+func TestReplayUnorderedSteps(t *testing.T) {
+	_, err := Replay(factory, []Step{{At: time.Second}, {At: 0}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,79 @@
+// Package breakersql Classifies database call outcomes for circuit breakers, telling the
+// database being unreachable apart from the errors the queries themselves cause.
+package breakersql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net"
+	"strings"
+	"time"
+
+	breaker "github.com/gilbertovgl/go-circuit-breaker"
+)
+
+// Hook Classifies the errors of a specific driver, reporting false to leave err to the
+// next hook and then to the defaults.
+type Hook func(err error) (breaker.Outcome, bool)
+
+// sqlStater Is implemented by the errors of drivers exposing SQLSTATE codes, such as
+// pgconn.PgError.
+type sqlStater interface {
+	SQLState() string
+}
+
+// Classifier Builds a breaker classifier, to set with breaker.WithClassifier, consulting
+// hooks in order before the defaults: no rows, constraint violations and serialization
+// conflicts succeed, caller cancellations are ignored and connectivity errors, timeouts and
+// anything unrecognized fail.
+func Classifier(hooks ...Hook) func(err error, latency time.Duration) breaker.Outcome {
+	return func(err error, _ time.Duration) breaker.Outcome {
+		if err == nil {
+			return breaker.OutcomeSuccess
+		}
+
+		for _, hook := range hooks {
+			if outcome, ok := hook(err); ok {
+				return outcome
+			}
+		}
+
+		return classify(err)
+	}
+}
+
+func classify(err error) breaker.Outcome {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return breaker.OutcomeSuccess
+	case errors.Is(err, context.Canceled):
+		return breaker.OutcomeIgnored
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, driver.ErrBadConn), errors.Is(err, sql.ErrConnDone):
+		return breaker.OutcomeFailure
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return breaker.OutcomeFailure
+	}
+
+	var stater sqlStater
+	if errors.As(err, &stater) {
+		return classifySQLState(stater.SQLState())
+	}
+
+	return breaker.OutcomeFailure
+}
+
+// classifySQLState Fails the connection, resource and operator intervention classes, the
+// other classes being raised by the statements rather than the database health.
+func classifySQLState(state string) breaker.Outcome {
+	switch {
+	case strings.HasPrefix(state, "08"), strings.HasPrefix(state, "53"), strings.HasPrefix(state, "57"), strings.HasPrefix(state, "58"):
+		return breaker.OutcomeFailure
+	default:
+		return breaker.OutcomeSuccess
+	}
+}
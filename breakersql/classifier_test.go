@@ -0,0 +1,76 @@
+package breakersql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	breaker "github.com/gilbertovgl/go-circuit-breaker"
+)
+
+type stateError struct {
+	state string
+}
+
+func (s *stateError) Error() string {
+	return "ERROR (SQLSTATE " + s.state + ")"
+}
+
+func (s *stateError) SQLState() string {
+	return s.state
+}
+
+var errDuplicateKey = errors.New("duplicate key")
+
+func TestClassifier(t *testing.T) {
+	tt := []struct {
+		name     string
+		err      error
+		expected breaker.Outcome
+	}{
+		{name: "no_error", err: nil, expected: breaker.OutcomeSuccess},
+		{name: "no_rows", err: fmt.Errorf("find user: %w", sql.ErrNoRows), expected: breaker.OutcomeSuccess},
+		{name: "canceled", err: context.Canceled, expected: breaker.OutcomeIgnored},
+		{name: "timeout", err: context.DeadlineExceeded, expected: breaker.OutcomeFailure},
+		{name: "bad_conn", err: driver.ErrBadConn, expected: breaker.OutcomeFailure},
+		{name: "conn_done", err: sql.ErrConnDone, expected: breaker.OutcomeFailure},
+		{name: "network", err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}, expected: breaker.OutcomeFailure},
+		{name: "unique_violation", err: &stateError{state: "23505"}, expected: breaker.OutcomeSuccess},
+		{name: "serialization_failure", err: &stateError{state: "40001"}, expected: breaker.OutcomeSuccess},
+		{name: "connection_failure", err: &stateError{state: "08006"}, expected: breaker.OutcomeFailure},
+		{name: "too_many_connections", err: &stateError{state: "53300"}, expected: breaker.OutcomeFailure},
+		{name: "admin_shutdown", err: &stateError{state: "57P01"}, expected: breaker.OutcomeFailure},
+		{name: "unrecognized", err: errDuplicateKey, expected: breaker.OutcomeFailure},
+	}
+
+	classifier := Classifier()
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, classifier(tc.err, 0))
+		})
+	}
+}
+
+func TestClassifierHooks(t *testing.T) {
+	classifier := Classifier(
+		func(err error) (breaker.Outcome, bool) {
+			if errors.Is(err, errDuplicateKey) {
+				return breaker.OutcomeSuccess, true
+			}
+			return "", false
+		},
+		func(err error) (breaker.Outcome, bool) {
+			return breaker.OutcomeIgnored, errors.Is(err, errDuplicateKey) || errors.Is(err, sql.ErrNoRows)
+		},
+	)
+
+	assert.Equal(t, breaker.OutcomeSuccess, classifier(errDuplicateKey, 0))
+	assert.Equal(t, breaker.OutcomeIgnored, classifier(sql.ErrNoRows, 0))
+	assert.Equal(t, breaker.OutcomeFailure, classifier(driver.ErrBadConn, 0))
+}
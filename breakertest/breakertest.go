@@ -0,0 +1,37 @@
+package breakertest
+
+import (
+	"fmt"
+	"time"
+
+	breaker "github.com/gilbertovgl/go-circuit-breaker"
+)
+
+// ForceState Moves cb to state through its admin operations, forcing closed also clears
+// the rolling window.
+func ForceState(cb *breaker.CircuitBreaker, state breaker.State) error {
+	switch state {
+	case breaker.Closed:
+		cb.Reset()
+	case breaker.Open:
+		cb.Trip()
+	case breaker.HalfOpen:
+		cb.Trip()
+		cb.ProbeNow()
+	default:
+		return fmt.Errorf("unknown state %q", state)
+	}
+
+	return nil
+}
+
+// AdvanceFrames Advances clock by the given number of cb frames, rolling its window as
+// many times while closed.
+func AdvanceFrames(cb *breaker.CircuitBreaker, clock *Clock, frames int) {
+	clock.Advance(time.Duration(frames) * cb.Snapshot().Config.WindowFrame)
+}
+
+// ElapseOpenDuration Advances clock by the cb open duration, moving an open cb to half-open.
+func ElapseOpenDuration(cb *breaker.CircuitBreaker, clock *Clock) {
+	clock.Advance(cb.Snapshot().Config.OpenDuration)
+}
@@ -0,0 +1,135 @@
+package breakertest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	breaker "github.com/gilbertovgl/go-circuit-breaker"
+)
+
+var errCall = errors.New("call failed")
+
+func TestForceState(t *testing.T) {
+	cb, cancel, err := breaker.New()
+	require.NoError(t, err)
+	defer cancel()
+
+	tt := []breaker.State{breaker.Open, breaker.HalfOpen, breaker.Closed, breaker.HalfOpen}
+	for _, state := range tt {
+		require.NoError(t, ForceState(cb, state))
+		assert.Equal(t, state, cb.State())
+	}
+
+	assert.Error(t, ForceState(cb, breaker.State("unknown")))
+}
+
+func TestClockDrivesBreaker(t *testing.T) {
+	clock := NewClock(time.Unix(0, 0))
+	cb, cancel, err := breaker.New(
+		breaker.WithClock(clock),
+		breaker.WithWindowFrameThreshold(10),
+		breaker.WithWindowRollThreshold(30),
+		breaker.WithOpenDuration(60),
+		breaker.WithCanTrip(func(summary breaker.Counts) bool { return summary.Fail >= 2 }),
+		breaker.WithHalfOpenEvaluation(1, 1),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	assert.ErrorIs(t, cb.Execute(func() error { return errCall }), errCall)
+	AdvanceFrames(cb, clock, 3)
+	assert.Equal(t, breaker.Counts{}, cb.Counts())
+
+	assert.ErrorIs(t, cb.Execute(func() error { return errCall }), errCall)
+	assert.ErrorIs(t, cb.Execute(func() error { return errCall }), errCall)
+	require.Equal(t, breaker.Open, cb.State())
+
+	clock.Advance(59 * time.Second)
+	assert.Equal(t, breaker.Open, cb.State())
+	clock.Advance(time.Second)
+	require.Equal(t, breaker.HalfOpen, cb.State())
+
+	assert.NoError(t, cb.Execute(func() error { return nil }))
+	assert.Equal(t, breaker.Closed, cb.State())
+
+	transitions := cb.Transitions(0)
+	require.Len(t, transitions, 3)
+	assert.Equal(t, time.Unix(90, 0), transitions[1].Time)
+}
+
+func TestClockTimers(t *testing.T) {
+	clock := NewClock(time.Unix(0, 0))
+
+	fired := []int{}
+	first := clock.AfterFunc(2*time.Second, func() { fired = append(fired, 1) })
+	clock.AfterFunc(time.Second, func() { fired = append(fired, 2) })
+	stopped := clock.AfterFunc(time.Second, func() { fired = append(fired, 3) })
+
+	assert.True(t, stopped.Stop())
+	assert.False(t, stopped.Stop())
+
+	clock.Advance(3 * time.Second)
+	assert.Equal(t, []int{2, 1}, fired)
+	assert.Equal(t, time.Unix(3, 0), clock.Now())
+
+	assert.False(t, first.Reset(time.Second))
+	clock.Advance(time.Second)
+	assert.Equal(t, []int{2, 1, 1}, fired)
+}
+
+func TestClockDrivesDeadlineRejection(t *testing.T) {
+	clock := NewClock(time.Now().Add(time.Hour))
+	cb, cancel, err := breaker.New(
+		breaker.WithClock(clock),
+		breaker.WithDeadlineRejection(95),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	slowCall := func(ctx context.Context) error {
+		clock.Advance(50 * time.Millisecond)
+		return nil
+	}
+	require.NoError(t, cb.ExecuteContext(context.Background(), slowCall))
+
+	ctx, ctxCancel := context.WithDeadline(context.Background(), clock.Now().Add(10*time.Millisecond))
+	defer ctxCancel()
+	assert.ErrorIs(t, cb.ExecuteContext(ctx, slowCall), breaker.ErrInsufficientDeadline)
+
+	ctx, ctxCancel = context.WithDeadline(context.Background(), clock.Now().Add(time.Second))
+	defer ctxCancel()
+	assert.NoError(t, cb.ExecuteContext(ctx, slowCall))
+}
+
+func TestClockDrivesOpenQueue(t *testing.T) {
+	clock := NewClock(time.Unix(0, 0))
+	cb, cancel, err := breaker.New(
+		breaker.WithClock(clock),
+		breaker.WithOpenDuration(3600),
+		breaker.WithOpenQueue(1, time.Minute),
+	)
+	require.NoError(t, err)
+	defer cancel()
+	cb.Trip()
+
+	done := make(chan error, 1)
+	go func() { done <- cb.Execute(func() error { return nil }) }()
+
+	deadline := time.After(time.Second)
+	for {
+		select {
+		case err = <-done:
+			assert.ErrorIs(t, err, breaker.ErrOpenCircuit)
+			return
+		case <-deadline:
+			t.Fatal("open queue ignored the clock")
+		case <-time.After(time.Millisecond):
+			clock.Advance(time.Minute)
+		}
+	}
+}
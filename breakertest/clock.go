@@ -0,0 +1,93 @@
+// Package breakertest Drives circuit breakers deterministically in tests, without sleeping
+// through frames and open durations.
+package breakertest
+
+import (
+	"sync"
+	"time"
+
+	breaker "github.com/gilbertovgl/go-circuit-breaker"
+)
+
+// Clock Is a breaker.Clock that only moves when advanced, firing the due timers synchronously.
+type Clock struct {
+	now    time.Time
+	timers map[*timer]struct{}
+
+	mu sync.Mutex
+}
+
+func NewClock(start time.Time) *Clock {
+	return &Clock{
+		now:    start,
+		timers: make(map[*timer]struct{}),
+	}
+}
+
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *Clock) AfterFunc(d time.Duration, f func()) breaker.Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := &timer{clock: c, fn: f, when: c.now.Add(d)}
+	c.timers[t] = struct{}{}
+	return t
+}
+
+// Advance Moves the clock forward by d, running every timer falling due on the way in
+// order and on the calling goroutine.
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	target := c.now.Add(d)
+	c.mu.Unlock()
+
+	for {
+		c.mu.Lock()
+		var next *timer
+		for t := range c.timers {
+			if !t.when.After(target) && (next == nil || t.when.Before(next.when)) {
+				next = t
+			}
+		}
+		if next == nil {
+			c.now = target
+			c.mu.Unlock()
+			return
+		}
+		c.now = next.when
+		delete(c.timers, next)
+		c.mu.Unlock()
+
+		next.fn()
+	}
+}
+
+type timer struct {
+	clock *Clock
+	fn    func()
+	when  time.Time
+}
+
+func (t *timer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	_, active := t.clock.timers[t]
+	delete(t.clock.timers, t)
+	return active
+}
+
+func (t *timer) Reset(d time.Duration) bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	_, active := t.clock.timers[t]
+	t.when = t.clock.now.Add(d)
+	t.clock.timers[t] = struct{}{}
+	return active
+}
@@ -0,0 +1,62 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+var ErrChaos = errors.New("chaos injected failure")
+
+// chaos Injects failures and latency into the guarded calls once enabled, so the fallbacks
+// and alerts behind a tripping breaker can be exercised on purpose.
+type chaos struct {
+	failureRate  float64
+	extraLatency time.Duration
+	clock        Clock
+	random       func() float64
+	enabled      atomic.Bool
+}
+
+// intercept Delays the call by the extra latency, then fails it with ErrChaos at the failure
+// rate without running it. A ctx done during the delay stops it and fails the call with its
+// error.
+func (ch *chaos) intercept(ctx context.Context, call circuitCall) circuitCall {
+	return func() error {
+		if !ch.enabled.Load() {
+			return call()
+		}
+
+		if ch.extraLatency > 0 {
+			elapsed := make(chan struct{})
+			timer := ch.clock.AfterFunc(ch.extraLatency, func() { close(elapsed) })
+			select {
+			case <-elapsed:
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			}
+		}
+
+		if ch.random()*100 < ch.failureRate {
+			return ErrChaos
+		}
+		return call()
+	}
+}
+
+// EnableChaos Starts injecting the failures and latency set with WithChaos, it does nothing
+// on a breaker created without it.
+func (c *CircuitBreaker) EnableChaos() {
+	if c.chaos != nil {
+		c.chaos.enabled.Store(true)
+	}
+}
+
+// DisableChaos Stops injecting failures and latency.
+func (c *CircuitBreaker) DisableChaos() {
+	if c.chaos != nil {
+		c.chaos.enabled.Store(false)
+	}
+}
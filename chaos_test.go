@@ -0,0 +1,66 @@
+package breaker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreakerChaos(t *testing.T) {
+	cb, cancel, err := New(
+		WithCanTrip(func(summary Counts) bool { return false }),
+		WithChaos(100, 50*time.Millisecond),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	called := false
+	call := func() error {
+		called = true
+		return nil
+	}
+
+	assert.NoError(t, cb.Execute(call))
+	assert.True(t, called)
+
+	cb.EnableChaos()
+	called = false
+	start := time.Now()
+	assert.ErrorIs(t, cb.Execute(call), ErrChaos)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+	assert.False(t, called)
+	assert.Equal(t, Counts{Total: 2, Fail: 1, Success: 1}, cb.Counts())
+
+	cb.DisableChaos()
+	assert.NoError(t, cb.Execute(call))
+	assert.True(t, called)
+
+	plain, cancel, err := New()
+	require.NoError(t, err)
+	defer cancel()
+	plain.EnableChaos()
+	assert.NoError(t, plain.Execute(call))
+}
+
+func TestBreakerChaosLatencyCancelled(t *testing.T) {
+	cb, cancel, err := New(WithChaos(0, time.Hour))
+	require.NoError(t, err)
+	defer cancel()
+	cb.EnableChaos()
+
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancelCtx()
+
+	called := false
+	start := time.Now()
+	err = cb.ExecuteContext(ctx, func(context.Context) error {
+		called = true
+		return nil
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.False(t, called)
+}
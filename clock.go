@@ -0,0 +1,31 @@
+package breaker
+
+import "time"
+
+// Clock Tells the time and schedules the breaker timers, a fake one lets tests drive frames
+// and open durations without sleeping.
+type Clock interface {
+	Now() time.Time
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+type Timer interface {
+	Stop() bool
+	Reset(d time.Duration) bool
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) AfterFunc(d time.Duration, f func()) Timer {
+	return time.AfterFunc(d, f)
+}
+
+// Clock Returns the clock the breaker tells the time with, so the code wrapping its calls
+// measures them consistently with it.
+func (c *CircuitBreaker) Clock() Clock {
+	return c.clock
+}
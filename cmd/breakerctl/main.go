@@ -0,0 +1,221 @@
+// Command breakerctl Manages the breakers exposed by a breakerhttp.Admin handler.
+//
+//	breakerctl [-addr http://localhost:8080] list
+//	breakerctl status <name>
+//	breakerctl window <name>
+//	breakerctl trip <name>
+//	breakerctl reset <name>
+//	breakerctl mode <name> <normal|forced-open|forced-closed|paused>
+//	breakerctl tail <name>
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	breaker "github.com/gilbertovgl/go-circuit-breaker"
+	"github.com/gilbertovgl/go-circuit-breaker/breakeradmin"
+)
+
+var errUsage = errors.New("usage: breakerctl [-addr url] list | status <name> | window <name> | trip <name> | reset <name> | mode <name> <mode> | tail <name>")
+
+type client struct {
+	addr string
+	http *http.Client
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := run(ctx, os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("breakerctl", flag.ContinueOnError)
+	addr := flags.String("addr", "http://localhost:8080", "address the admin handler is served at")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	args = flags.Args()
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	c := &client{addr: strings.TrimSuffix(*addr, "/"), http: http.DefaultClient}
+	command, args := args[0], args[1:]
+
+	if command == "list" {
+		var statuses []breakeradmin.Status
+		if err := c.do(ctx, http.MethodGet, "", "", nil, &statuses); err != nil {
+			return err
+		}
+		return printStatuses(out, statuses...)
+	}
+
+	if len(args) == 0 {
+		return errUsage
+	}
+	name := args[0]
+
+	switch command {
+	case "status":
+		var status breakeradmin.Status
+		if err := c.do(ctx, http.MethodGet, name, "", nil, &status); err != nil {
+			return err
+		}
+		return printStatuses(out, status)
+
+	case "window":
+		var frames []breaker.Frame
+		if err := c.do(ctx, http.MethodGet, name, "window", nil, &frames); err != nil {
+			return err
+		}
+		return printFrames(out, frames)
+
+	case "trip", "reset":
+		var status breakeradmin.Status
+		if err := c.do(ctx, http.MethodPost, name, command, nil, &status); err != nil {
+			return err
+		}
+		return printStatuses(out, status)
+
+	case "mode":
+		if len(args) != 2 {
+			return errUsage
+		}
+		var status breakeradmin.Status
+		if err := c.do(ctx, http.MethodPost, name, "mode", map[string]string{"mode": args[1]}, &status); err != nil {
+			return err
+		}
+		return printStatuses(out, status)
+
+	case "tail":
+		return c.tail(ctx, name, out)
+	}
+
+	return errUsage
+}
+
+func (c *client) do(ctx context.Context, method, name, action string, body, v any) error {
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	resp, err := c.request(ctx, method, name, action, reqBody)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+func (c *client) tail(ctx context.Context, name string, out io.Writer) error {
+	resp, err := c.request(ctx, http.MethodGet, name, "events", nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data: ")
+		if !ok {
+			continue
+		}
+
+		var event breaker.Event
+		if err = json.Unmarshal([]byte(data), &event); err != nil {
+			return err
+		}
+		printEvent(out, event)
+	}
+
+	if ctx.Err() != nil {
+		return nil
+	}
+	return scanner.Err()
+}
+
+func (c *client) request(ctx context.Context, method, name, action string, body io.Reader) (*http.Response, error) {
+	target := c.addr + "/breakers"
+	if name != "" {
+		target += "/" + url.PathEscape(name)
+	}
+	if action != "" {
+		target += "/" + action
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		msg, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+
+	return resp, nil
+}
+
+func printStatuses(out io.Writer, statuses ...breakeradmin.Status) error {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tSTATE\tMODE\tTOTAL\tFAIL\tSUCCESS")
+	for _, s := range statuses {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\t%d\n", s.Name, s.State, s.Mode, s.Counts.Total, s.Counts.Fail, s.Counts.Success)
+	}
+	return w.Flush()
+}
+
+func printFrames(out io.Writer, frames []breaker.Frame) error {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "START\tEND\tTOTAL\tFAIL\tSUCCESS")
+	for _, f := range frames {
+		end := "current"
+		if f.Complete {
+			end = f.End.Format(time.TimeOnly)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\n", f.Start.Format(time.TimeOnly), end, f.Total, f.Fail, f.Success)
+	}
+	return w.Flush()
+}
+
+func printEvent(out io.Writer, event breaker.Event) {
+	at := event.Time.Format(time.TimeOnly)
+	switch event.Type {
+	case breaker.EventTransition:
+		fmt.Fprintf(out, "%s %s %s -> %s total=%d fail=%d\n", at, event.Type, event.From, event.To, event.Summary.Total, event.Summary.Fail)
+	default:
+		fmt.Fprintf(out, "%s %s frame total=%d fail=%d window total=%d fail=%d\n", at, event.Type, event.Frame.Total, event.Frame.Fail, event.Summary.Total, event.Summary.Fail)
+	}
+}
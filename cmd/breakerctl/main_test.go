@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	breaker "github.com/gilbertovgl/go-circuit-breaker"
+	"github.com/gilbertovgl/go-circuit-breaker/breakerhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	registry := breaker.NewRegistry()
+	cb, cancel, err := breaker.New(
+		breaker.WithName("payments"),
+		breaker.WithWindowFrameThreshold(10),
+		breaker.WithWindowRollThreshold(20),
+	)
+	require.NoError(t, err)
+	defer cancel()
+	require.NoError(t, registry.Register(cb))
+
+	srv := httptest.NewServer(breakerhttp.Admin(registry))
+	defer srv.Close()
+
+	runCmd := func(args ...string) (string, error) {
+		var out bytes.Buffer
+		err := run(context.Background(), append([]string{"-addr", srv.URL}, args...), &out)
+		return out.String(), err
+	}
+
+	got, err := runCmd("list")
+	require.NoError(t, err)
+	assert.Equal(t, "NAME      STATE   MODE    TOTAL  FAIL  SUCCESS\npayments  closed  normal  0      0     0\n", got)
+
+	got, err = runCmd("trip", "payments")
+	require.NoError(t, err)
+	assert.Contains(t, got, "payments  open")
+
+	got, err = runCmd("mode", "payments", "forced-closed")
+	require.NoError(t, err)
+	assert.Contains(t, got, "forced-closed")
+
+	_, err = runCmd("mode", "payments", "sideways")
+	assert.ErrorContains(t, err, "400 Bad Request")
+
+	_, err = runCmd("status", "missing")
+	assert.ErrorContains(t, err, "404 Not Found")
+
+	got, err = runCmd("window", "payments")
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(got), "\n")
+	assert.Len(t, lines, 3)
+	assert.Contains(t, lines[2], "current")
+
+	ctx, stop := context.WithTimeout(context.Background(), time.Millisecond*500)
+	defer stop()
+	var out bytes.Buffer
+	go func() {
+		time.Sleep(time.Millisecond * 100)
+		_, _ = runCmd("reset", "payments")
+	}()
+	require.NoError(t, run(ctx, []string{"-addr", srv.URL, "tail", "payments"}, &out))
+	assert.Contains(t, out.String(), "transition open -> closed")
+
+	assert.ErrorIs(t, run(context.Background(), nil, &out), errUsage)
+}
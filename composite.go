@@ -0,0 +1,71 @@
+package breaker
+
+import "context"
+
+// Composite Is a Breaker made of several breakers, such as a per-region and a global one,
+// a call is admitted only while none of them is open and its outcome is recorded into each.
+type Composite struct {
+	name     string
+	breakers []Breaker
+}
+
+var _ Breaker = (*Composite)(nil)
+
+func NewComposite(name string, breakers ...Breaker) *Composite {
+	return &Composite{name: name, breakers: breakers}
+}
+
+func (c *Composite) Name() string {
+	return c.name
+}
+
+// State Reports the most restrictive state among the breakers.
+func (c *Composite) State() State {
+	state := Closed
+	for _, b := range c.breakers {
+		switch b.State() {
+		case Open:
+			return Open
+		case HalfOpen:
+			state = HalfOpen
+		}
+	}
+
+	return state
+}
+
+func (c *Composite) Execute(fn func() error) error {
+	return c.ExecuteContext(context.Background(), func(context.Context) error {
+		return fn()
+	})
+}
+
+// ExecuteContext Rejects the call up front when any breaker is open, otherwise runs it
+// nested through every breaker. A breaker rejecting after the check, such as one limiting
+// its half-open calls, has its rejection recorded as ignored by the breakers wrapping it,
+// so one breaker's rejections never count as failures against the others.
+func (c *Composite) ExecuteContext(ctx context.Context, fn func(ctx context.Context) error) error {
+	for _, b := range c.breakers {
+		if b.State() == Open {
+			return b.ExecuteContext(ctx, fn)
+		}
+	}
+
+	call := fn
+	for i := len(c.breakers) - 1; i >= 0; i-- {
+		b, next, inner := c.breakers[i], call, i > 0
+		call = func(ctx context.Context) error {
+			err := b.ExecuteContext(ctx, next)
+			if inner && IsRejection(err) {
+				return Classified(OutcomeIgnored, err)
+			}
+			return err
+		}
+	}
+
+	err := call(ctx)
+	if classified, ok := err.(*classifiedError); ok {
+		return classified.err
+	}
+	return err
+}
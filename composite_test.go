@@ -0,0 +1,68 @@
+package breaker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComposite(t *testing.T) {
+	region, cancel, err := New(WithName("region"))
+	require.NoError(t, err)
+	defer cancel()
+
+	global, cancel, err := New(WithName("global"))
+	require.NoError(t, err)
+	defer cancel()
+
+	cb := NewComposite("payments", region, global)
+	assert.Equal(t, "payments", cb.Name())
+
+	assert.NoError(t, cb.Execute(fixtureCircuitCall(nil)))
+	assert.ErrorIs(t, cb.Execute(fixtureCircuitCall(errCall)), errCall)
+	assert.Equal(t, Counts{Total: 2, Fail: 1, Success: 1}, region.Counts())
+	assert.Equal(t, Counts{Total: 2, Fail: 1, Success: 1}, global.Counts())
+
+	calls := 0
+	global.Trip()
+	assert.Equal(t, Open, cb.State())
+	assert.ErrorIs(t, cb.Execute(func() error { calls++; return nil }), ErrOpenCircuit)
+	assert.Equal(t, 0, calls)
+	assert.Equal(t, Counts{Total: 2, Fail: 1, Success: 1}, region.Counts())
+
+	global.ProbeNow()
+	assert.Equal(t, HalfOpen, cb.State())
+
+	global.Reset()
+	assert.Equal(t, Closed, cb.State())
+	assert.NoError(t, cb.Execute(func() error { calls++; return nil }))
+	assert.Equal(t, 1, calls)
+}
+
+func TestCompositeIgnoresInnerRejections(t *testing.T) {
+	region, cancel, err := New(WithName("region"))
+	require.NoError(t, err)
+	defer cancel()
+
+	global, cancel, err := New(WithName("global"), WithDeadlineRejection(50))
+	require.NoError(t, err)
+	defer cancel()
+	global.latencies.push(time.Second)
+
+	ctx, ctxCancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+	defer ctxCancel()
+
+	calls := 0
+	call := func(ctx context.Context) error { calls++; return nil }
+
+	err = NewComposite("payments", region, global).ExecuteContext(ctx, call)
+	assert.Equal(t, ErrInsufficientDeadline, err)
+	assert.Equal(t, Counts{Ignored: 1}, region.Counts())
+
+	err = NewComposite("payments", NewNoopBreaker("noop"), global).ExecuteContext(ctx, call)
+	assert.Equal(t, ErrInsufficientDeadline, err)
+	assert.Equal(t, 0, calls)
+}
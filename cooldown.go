@@ -0,0 +1,50 @@
+package breaker
+
+import (
+	"sync"
+	"time"
+)
+
+// cooldown Accounts the calls made since the circuit recovered, for as long as the trip
+// predicate evaluates them in place of the window still holding the failures that opened it.
+type cooldown struct {
+	duration time.Duration
+	until    time.Time
+	counts   Counts
+
+	mu sync.Mutex
+}
+
+func (cd *cooldown) start(now time.Time) {
+	if cd == nil {
+		return
+	}
+
+	cd.mu.Lock()
+	defer cd.mu.Unlock()
+	cd.until = now.Add(cd.duration)
+	cd.counts = Counts{}
+}
+
+func (cd *cooldown) record(incr Counts, now time.Time) {
+	if cd == nil {
+		return
+	}
+
+	cd.mu.Lock()
+	defer cd.mu.Unlock()
+	if now.Before(cd.until) {
+		cd.counts = addCounts(cd.counts, incr)
+	}
+}
+
+// active Returns the counts since recovery while cooling down.
+func (cd *cooldown) active(now time.Time) (Counts, bool) {
+	if cd == nil {
+		return Counts{}, false
+	}
+
+	cd.mu.Lock()
+	defer cd.mu.Unlock()
+	return cd.counts, now.Before(cd.until)
+}
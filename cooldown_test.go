@@ -0,0 +1,70 @@
+package breaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCooldown(t *testing.T) {
+	now := time.Date(2024, time.January, 15, 10, 0, 0, 0, time.UTC)
+	cd := &cooldown{duration: time.Minute}
+
+	_, ok := cd.active(now)
+	assert.False(t, ok)
+
+	cd.start(now)
+	cd.record(Counts{Total: 1, Fail: 1}, now.Add(time.Second))
+	counts, ok := cd.active(now.Add(time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, Counts{Total: 1, Fail: 1}, counts)
+
+	cd.record(Counts{Total: 1, Fail: 1}, now.Add(time.Minute))
+	_, ok = cd.active(now.Add(time.Minute))
+	assert.False(t, ok)
+
+	cd.start(now.Add(time.Hour))
+	counts, _ = cd.active(now.Add(time.Hour))
+	assert.Equal(t, Counts{}, counts)
+}
+
+func TestBreakerRecoveryCooldown(t *testing.T) {
+	tt := []struct {
+		name     string
+		input    []option
+		expected State
+	}{
+		{
+			name:     "reopens_on_residual_failures",
+			input:    nil,
+			expected: Open,
+		},
+		{
+			name:     "evaluates_post_recovery_calls",
+			input:    []option{WithRecoveryCooldown(time.Minute)},
+			expected: Closed,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			cb, cancel, err := New(append([]option{
+				WithCanTrip(func(summary Counts) bool { return summary.Fail > 2 }),
+				WithHalfOpenEvaluation(1, 1),
+			}, tc.input...)...)
+			require.NoError(t, err)
+			defer cancel()
+
+			syncFeedCircuitBreakerHelper(cb, []error{errCall, errCall, errCall}, false)
+			require.Equal(t, Open, cb.State())
+			cb.ProbeNow()
+			syncFeedCircuitBreakerHelper(cb, []error{nil}, false)
+			require.Equal(t, Closed, cb.State())
+
+			syncFeedCircuitBreakerHelper(cb, []error{errCall}, false)
+			assert.Equal(t, tc.expected, cb.State())
+		})
+	}
+}
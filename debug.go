@@ -0,0 +1,78 @@
+package breaker
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+)
+
+const _debugTransitions = 10
+
+// DebugDump Writes a human readable report of the breaker: its configuration, state, summary,
+// every frame of the window, the recent transitions and the recent errors.
+func (c *CircuitBreaker) DebugDump(w io.Writer) error {
+	snapshot := c.Snapshot()
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	p := &debugPrinter{w: tw}
+
+	p.printf("breaker %q\n\n", snapshot.Name)
+	p.printf("state\t%s (%s for %s)\n", snapshot.State, snapshot.Mode, snapshot.TimeInState.Round(time.Millisecond))
+	if snapshot.State == Open {
+		p.printf("half-open in\t%s\n", c.TimeUntilHalfOpen().Round(time.Millisecond))
+	}
+	p.printf("uptime\t%s\n", snapshot.Uptime.Round(time.Millisecond))
+	p.printf("in flight\t%d\n", snapshot.InFlight)
+	p.printf("window\t%s in %s frames\n", snapshot.Config.WindowRoll, snapshot.Config.WindowFrame)
+	p.printf("open duration\t%s\n", snapshot.Config.OpenDuration)
+	if snapshot.Config.DeadlinePercentile > 0 {
+		p.printf("deadline percentile\t%g\n", snapshot.Config.DeadlinePercentile)
+	}
+	p.printf("short circuits\t%d (saved %s)\n", snapshot.ShortCircuits, snapshot.TimeSaved.Round(time.Millisecond))
+	if snapshot.SkippedRuns > 0 {
+		p.printf("skipped runs\t%d\n", snapshot.SkippedRuns)
+	}
+	p.printf("summary\t%s\n\n", debugCounts(snapshot.Summary))
+
+	p.printf("frames\n")
+	for _, f := range snapshot.Window {
+		end := "current"
+		if f.Complete {
+			end = f.End.Format(time.RFC3339Nano)
+		}
+		p.printf("  %s\t%s\t%s\n", f.Start.Format(time.RFC3339Nano), end, debugCounts(f.Counts))
+	}
+
+	p.printf("\ntransitions\n")
+	for _, t := range c.Transitions(_debugTransitions) {
+		p.printf("  %s\t%s -> %s\t%s\t%s\n", t.Time.Format(time.RFC3339Nano), t.From, t.To, t.Reason, debugCounts(t.Summary))
+	}
+
+	p.printf("\nerrors\n")
+	for _, e := range c.RecentErrors() {
+		p.printf("  %s\t%v\n", e.Time.Format(time.RFC3339Nano), e.Err)
+	}
+
+	if p.err != nil {
+		return p.err
+	}
+	return tw.Flush()
+}
+
+func debugCounts(c Counts) string {
+	return fmt.Sprintf("total=%d fail=%d success=%d slow=%d ignored=%d failure-rate=%.1f%%", c.Total, c.Fail, c.Success, c.Slow, c.Ignored, c.FailureRate())
+}
+
+// debugPrinter Keeps the first write error so the report reads as a sequence of prints.
+type debugPrinter struct {
+	w   io.Writer
+	err error
+}
+
+func (p *debugPrinter) printf(format string, args ...any) {
+	if p.err != nil {
+		return
+	}
+	_, p.err = fmt.Fprintf(p.w, format, args...)
+}
@@ -0,0 +1,51 @@
+package breaker
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestBreakerDebugDump(t *testing.T) {
+	cb, cancel, err := New(
+		WithName("payments"),
+		WithErrorHistory(5),
+		WithWindowFrameThreshold(10),
+		WithWindowRollThreshold(30),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	syncFeedCircuitBreakerHelper(cb, []error{nil, errCall}, false)
+	cb.Trip()
+
+	var buf bytes.Buffer
+	require.NoError(t, cb.DebugDump(&buf))
+	dump := buf.String()
+
+	for _, expected := range []string{
+		`breaker "payments"`,
+		"open (normal for",
+		"half-open in",
+		"30s in 10s frames",
+		"total=2 fail=1 success=1 slow=0 ignored=0 failure-rate=50.0%",
+		"closed -> open",
+		string(ReasonManualTrip),
+		errCall.Error(),
+	} {
+		assert.Contains(t, dump, expected)
+	}
+	assert.Equal(t, 3, strings.Count(dump[strings.Index(dump, "frames\n"):strings.Index(dump, "transitions\n")], "\n  "))
+
+	assert.Error(t, cb.DebugDump(failingWriter{}))
+}
@@ -0,0 +1,41 @@
+package breaker
+
+import "math"
+
+// decay Weighs a frame by its age in frames, zero for the current one, out of the window.
+type decay func(age, frames int) float64
+
+func linearDecay(age, frames int) float64 {
+	return float64(frames-age) / float64(frames)
+}
+
+func exponentialDecay(factor float64) decay {
+	return func(age, _ int) float64 {
+		return math.Pow(factor, float64(age))
+	}
+}
+
+// decayedCounts Sums the window frames weighed by their age, rounding to whole calls.
+func (c *CircuitBreaker) decayedCounts() Counts {
+	c.rollingWindow.mu.RLock()
+	defer c.rollingWindow.mu.RUnlock()
+
+	window := c.rollingWindow.window
+	var total, fail, success, slow, ignored float64
+	for i, f := range window {
+		w := c.decay(len(window)-1-i, len(window))
+		total += w * float64(f.Total)
+		fail += w * float64(f.Fail)
+		success += w * float64(f.Success)
+		slow += w * float64(f.Slow)
+		ignored += w * float64(f.Ignored)
+	}
+
+	return Counts{
+		Total:   uint64(math.Round(total)),
+		Fail:    uint64(math.Round(fail)),
+		Success: uint64(math.Round(success)),
+		Slow:    uint64(math.Round(slow)),
+		Ignored: uint64(math.Round(ignored)),
+	}
+}
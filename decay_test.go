@@ -0,0 +1,50 @@
+package breaker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreakerDecay(t *testing.T) {
+	tt := []struct {
+		name     string
+		input    []option
+		expected Counts
+	}{
+		{
+			name:     "sums_frames_evenly_by_default",
+			input:    nil,
+			expected: Counts{Total: 30, Fail: 12, Success: 18},
+		},
+		{
+			name:     "linear",
+			input:    []option{WithLinearDecay()},
+			expected: Counts{Total: 20, Fail: 5, Success: 15},
+		},
+		{
+			name:     "exponential",
+			input:    []option{WithExponentialDecay(0.5)},
+			expected: Counts{Total: 18, Fail: 4, Success: 14},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			cb, cancel, err := New(append([]option{
+				WithCanTrip(func(summary Counts) bool { return false }),
+				WithWindowFrameThreshold(10),
+				WithWindowRollThreshold(30),
+			}, tc.input...)...)
+			require.NoError(t, err)
+			defer cancel()
+
+			feedFrameHelper(cb, 10, 0)
+			feedFrameHelper(cb, 2, 8)
+			syncFeedCircuitBreakerHelper(cb, make([]error, 10), false)
+
+			assert.Equal(t, tc.expected, cb.tripCounts())
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package breaker
+
+import (
+	"context"
+	"sync"
+)
+
+// Decision Describes how a breaker handled the call of a request, for the handlers and
+// loggers down or up the chain to annotate responses with. Breaker and State are set when
+// the call arrives, the rest once it returns.
+type Decision struct {
+	Report
+	// Breaker Is the name of the breaker that handled the call.
+	Breaker string
+}
+
+type decisionKey struct{}
+
+// decisionHolder Is the decision a context carries, the calls on the context publishing
+// copies of their report into it so concurrent calls and readers don't share one.
+type decisionHolder struct {
+	decision Decision
+
+	mu sync.Mutex
+}
+
+func (h *decisionHolder) get() Decision {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.decision
+}
+
+func (h *decisionHolder) set(d Decision) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.decision = d
+}
+
+// ContextWithDecision Returns ctx carrying a decision the breakers running calls on it fill
+// in, ctx itself when it already carries one so an outer middleware can read what an inner
+// one decided.
+func ContextWithDecision(ctx context.Context) context.Context {
+	if decisionFrom(ctx) != nil {
+		return ctx
+	}
+	return context.WithValue(ctx, decisionKey{}, &decisionHolder{})
+}
+
+// FromContext Returns the decision filled in by the last breaker that ran a call on ctx,
+// false when ctx carries none. It is the zero Decision until a breaker handles a call.
+func FromContext(ctx context.Context) (Decision, bool) {
+	h := decisionFrom(ctx)
+	if h == nil {
+		return Decision{}, false
+	}
+	return h.get(), true
+}
+
+// MarkFallback Records on the decision of ctx, if any, that a fallback answered.
+func MarkFallback(ctx context.Context) {
+	if h := decisionFrom(ctx); h != nil {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.decision.FellBack = true
+	}
+}
+
+func decisionFrom(ctx context.Context) *decisionHolder {
+	h, _ := ctx.Value(decisionKey{}).(*decisionHolder)
+	return h
+}
+
+// decide Gives the call a report of its own unless the caller asked for one, returning how
+// to publish it into the decision of ctx, <nil> when ctx carries none. The report is marked
+// retried when the breaker already handled a call on ctx.
+func (c *CircuitBreaker) decide(ctx context.Context, opts *callOptions) (publish func()) {
+	if opts.report != nil {
+		return nil
+	}
+	h := decisionFrom(ctx)
+	if h == nil {
+		return nil
+	}
+
+	report := &Report{ShortCircuited: true, Retried: h.get().Breaker == c.name}
+	opts.report = report
+	return func() { h.set(Decision{Breaker: c.name, Report: *report}) }
+}
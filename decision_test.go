@@ -0,0 +1,122 @@
+package breaker
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreakerDecision(t *testing.T) {
+	cb, cancel, err := New(WithName("payments"))
+	require.NoError(t, err)
+	defer cancel()
+
+	_, ok := FromContext(context.Background())
+	assert.False(t, ok)
+
+	ctx := ContextWithDecision(context.Background())
+	assert.Equal(t, ctx, ContextWithDecision(ctx))
+	decision, ok := FromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, Decision{}, decision)
+
+	require.NoError(t, cb.ExecuteContext(ctx, func(ctx context.Context) error {
+		decision, ok := FromContext(ctx)
+		require.True(t, ok)
+		assert.Equal(t, "payments", decision.Breaker)
+		assert.Equal(t, Closed, decision.State)
+		return nil
+	}))
+	decision, ok = FromContext(ctx)
+	require.True(t, ok)
+	assert.False(t, decision.ShortCircuited)
+	assert.Equal(t, OutcomeSuccess, decision.Outcome)
+
+	cb.Trip()
+	ctx = ContextWithDecision(context.Background())
+	assert.ErrorIs(t, cb.ExecuteContext(ctx, func(ctx context.Context) error { return nil }), ErrOpenCircuit)
+	MarkFallback(ctx)
+
+	decision, ok = FromContext(ctx)
+	require.True(t, ok)
+	assert.Equal(t, Decision{Breaker: "payments", Report: Report{State: Open, ShortCircuited: true, FellBack: true}}, decision)
+}
+
+func TestBreakerDecisionRetried(t *testing.T) {
+	cb, cancel, err := New(WithName("payments"))
+	require.NoError(t, err)
+	defer cancel()
+
+	ctx := ContextWithDecision(context.Background())
+	assert.ErrorIs(t, cb.ExecuteContext(ctx, func(ctx context.Context) error { return errCall }), errCall)
+	decision, _ := FromContext(ctx)
+	assert.False(t, decision.Retried)
+
+	require.NoError(t, cb.ExecuteContext(ctx, func(ctx context.Context) error { return nil }))
+	decision, _ = FromContext(ctx)
+	assert.True(t, decision.Retried)
+	assert.Equal(t, OutcomeSuccess, decision.Outcome)
+
+	other, cancel, err := New(WithName("ledger"))
+	require.NoError(t, err)
+	defer cancel()
+
+	require.NoError(t, other.ExecuteContext(ctx, func(ctx context.Context) error { return nil }))
+	decision, _ = FromContext(ctx)
+	assert.Equal(t, "ledger", decision.Breaker)
+	assert.False(t, decision.Retried)
+}
+
+func TestBreakerDecisionFellBackStale(t *testing.T) {
+	cb, cancel, err := New(WithName("users"))
+	require.NoError(t, err)
+	defer cancel()
+
+	cache := NewStaleCache[string]()
+	ctx := ContextWithDecision(context.Background())
+	_, _, err = ExecuteStaleContext(ctx, cb, cache, "user:1", func(context.Context) (string, error) { return "alice", nil })
+	require.NoError(t, err)
+	decision, _ := FromContext(ctx)
+	assert.False(t, decision.FellBack)
+
+	cb.Trip()
+	ctx = ContextWithDecision(context.Background())
+	got, stale, err := ExecuteStaleContext(ctx, cb, cache, "user:1", func(context.Context) (string, error) { return "bob", nil })
+	require.NoError(t, err)
+	assert.True(t, stale)
+	assert.Equal(t, "alice", got)
+
+	decision, _ = FromContext(ctx)
+	assert.True(t, decision.ShortCircuited)
+	assert.True(t, decision.FellBack)
+}
+
+func TestBreakerDecisionConcurrentCalls(t *testing.T) {
+	cb, cancel, err := New(WithName("payments"))
+	require.NoError(t, err)
+	defer cancel()
+
+	ctx := ContextWithDecision(context.Background())
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, cb.ExecuteContext(ctx, func(ctx context.Context) error {
+				_, ok := FromContext(ctx)
+				assert.True(t, ok)
+				return nil
+			}))
+			MarkFallback(ctx)
+		}()
+	}
+	wg.Wait()
+
+	decision, ok := FromContext(ctx)
+	require.True(t, ok)
+	assert.Equal(t, "payments", decision.Breaker)
+	assert.Equal(t, OutcomeSuccess, decision.Outcome)
+}
@@ -1,23 +1,42 @@
 package breaker
 
+import (
+	"sync"
+	"time"
+)
+
 func defaultCanTrip(summary Counts) bool {
-	return summary.Total > 10 && ((float64(summary.Fail)/float64(summary.Total))*100) >= 60
+	return summary.Total > _minimumVolume && summary.FailureRate() >= 60
 }
 
-func defaultFromHalfOpenToState(summary Counts) State {
-	if summary.Fail > 0 {
-		return Open
+// halfOpenEvaluation Reopens the circuit once failures calls fail and closes it once calls
+// calls are evaluated without failing as many.
+func halfOpenEvaluation(calls, failures int) fromHalfOpenToState {
+	return func(summary Counts) State {
+		if summary.Fail >= uint64(failures) {
+			return Open
+		}
+
+		if summary.Total >= uint64(calls) {
+			return Closed
+		}
+
+		return HalfOpen
 	}
+}
 
-	if summary.Total > 50 {
-		return Closed
+func defaultClassifier(err error, _ time.Duration) Outcome {
+	if err != nil {
+		return OutcomeFailure
 	}
 
-	return HalfOpen
+	return OutcomeSuccess
 }
 
-func cancelFunc(cancelCh chan struct{}) func() {
+// cancelFunc Stops the breaker background work once, later calls return immediately.
+func cancelFunc(stop func()) func() {
+	var once sync.Once
 	return func() {
-		cancelCh <- struct{}{}
+		once.Do(stop)
 	}
 }
@@ -0,0 +1,36 @@
+package breaker
+
+type degradedShedding struct {
+	start   float64
+	maxShed float64
+}
+
+// shedProbability Scales linearly from zero at the start failure rate up to the
+// max shed rate once every call in the window fails.
+func (d *degradedShedding) shedProbability(rate float64) float64 {
+	if rate < d.start {
+		return 0
+	}
+
+	return ((rate - d.start) / (100 - d.start)) * (d.maxShed / 100)
+}
+
+// canAdmitDegraded Rejects a share of the calls proportional to the window failure rate
+// while closed, high priority calls are left to the trip decision alone.
+func (c *CircuitBreaker) canAdmitDegraded(priority Priority) error {
+	if c.cfg.degraded == nil || priority >= High || c.stateCopy() != Closed {
+		return nil
+	}
+
+	summary := c.summaryCopy()
+	if summary.Total <= _minimumVolume {
+		return nil
+	}
+
+	probability := c.cfg.degraded.shedProbability(summary.FailureRate())
+	if probability > 0 && c.random() < probability {
+		return ErrCallShed
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package breaker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDegradedShedProbability(t *testing.T) {
+	tt := []struct {
+		name     string
+		degraded degradedShedding
+		rate     float64
+		expected float64
+	}{
+		{
+			name:     "below_start_rate",
+			degraded: degradedShedding{start: 20, maxShed: 80},
+			rate:     10,
+			expected: 0,
+		},
+		{
+			name:     "at_start_rate",
+			degraded: degradedShedding{start: 20, maxShed: 80},
+			rate:     20,
+			expected: 0,
+		},
+		{
+			name:     "halfway_to_full_failure",
+			degraded: degradedShedding{start: 20, maxShed: 80},
+			rate:     60,
+			expected: 0.4,
+		},
+		{
+			name:     "full_failure",
+			degraded: degradedShedding{start: 20, maxShed: 80},
+			rate:     100,
+			expected: 0.8,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.InDelta(t, tc.expected, tc.degraded.shedProbability(tc.rate), 0.0001)
+		})
+	}
+}
+
+func TestBreakerDegradedShedding(t *testing.T) {
+	cb, cancel, err := New(
+		WithDegradedShedding(0, 100),
+		WithCanTrip(func(summary Counts) bool { return false }),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	calls := make([]error, 11)
+	for i := range calls {
+		calls[i] = errCall
+	}
+	syncFeedCircuitBreakerHelper(cb, calls, false)
+
+	assert.ErrorIs(t, cb.Execute(fixtureCircuitCall(nil)), ErrCallShed)
+	assert.ErrorIs(t, cb.ExecuteWithPriority(Low, fixtureCircuitCall(nil)), ErrCallShed)
+	assert.NoError(t, cb.ExecuteWithPriority(High, fixtureCircuitCall(nil)))
+	assert.Equal(t, Counts{Total: 12, Fail: 11, Success: 1}, cb.summaryCopy())
+	assert.Equal(t, Closed, cb.stateCopy())
+}
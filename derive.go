@@ -0,0 +1,23 @@
+package breaker
+
+import "fmt"
+
+// With Returns a new breaker built from the options this one was created with followed by
+// opts, so a tuned base configuration can be varied per operation. The predicates, observers
+// and exporters given to the parent are shared, while the state, the window and the timers
+// are the derived breaker own. Stateful values such as a window strategy or a store are
+// shared as given too, override them when the derived breaker must count apart. A named
+// parent requires a WithName giving the derived breaker its own name, so it doesn't write
+// into the store, shared state or fleet keys of the parent, and both can be registered.
+func (c *CircuitBreaker) With(opts ...option) (cb *CircuitBreaker, cancel func(), err error) {
+	cb, cancel, err = New(append(append([]option{}, c.opts...), opts...)...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if c.name != "" && cb.name == c.name {
+		cancel()
+		return nil, nil, fmt.Errorf("%w: %w: derived breaker named %q like its parent", ErrNewCircuitBreaker, ErrInvalidName, c.name)
+	}
+	return cb, cancel, nil
+}
@@ -0,0 +1,44 @@
+package breaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreakerWith(t *testing.T) {
+	observer := &recordingObserver{}
+	base, cancel, err := New(
+		WithName("payments"),
+		WithOpenDuration(5),
+		WithCanTrip(func(summary Counts) bool { return summary.Fail > 0 }),
+		WithObserver(observer),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	refunds, stop, err := base.With(WithName("payments.refunds"), WithOpenDuration(60))
+	require.NoError(t, err)
+	defer stop()
+
+	assert.Equal(t, "payments.refunds", refunds.Name())
+	assert.Equal(t, time.Minute, refunds.cfg.openDuration)
+	assert.Equal(t, time.Second*5, base.cfg.openDuration)
+
+	assert.ErrorIs(t, refunds.Execute(fixtureCircuitCall(errCall)), errCall)
+	assert.Equal(t, Open, refunds.State())
+	assert.Equal(t, Closed, base.State())
+	assert.Equal(t, []string{"start", "end:failure"}, observer.calls)
+
+	_, _, err = base.With(WithName("payments.orders"), WithOpenDuration(0))
+	assert.ErrorIs(t, err, ErrInvalidOpenDuration)
+
+	_, _, err = base.With(WithOpenDuration(60))
+	assert.ErrorIs(t, err, ErrInvalidName)
+
+	registry := NewRegistry()
+	require.NoError(t, registry.Register(base))
+	assert.NoError(t, registry.Register(refunds))
+}
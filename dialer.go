@@ -0,0 +1,58 @@
+package breaker
+
+import (
+	"context"
+	"net"
+)
+
+type dialFunc func(ctx context.Context, network, address string) (net.Conn, error)
+
+// Dialer Guards connection establishment with a breaker per target address, dials to an
+// address whose circuit is open fail fast without touching the network.
+type Dialer struct {
+	dial     dialFunc
+	breakers *breakerSet
+}
+
+// NewDialer Wraps dial, such as a net.Dialer DialContext, creating every address breaker
+// from opts and naming it after the address.
+func NewDialer(dial func(ctx context.Context, network, address string) (net.Conn, error), opts ...option) *Dialer {
+	return &Dialer{
+		dial:     dial,
+		breakers: newBreakerSet(opts),
+	}
+}
+
+func (d *Dialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	cb, err := d.Breaker(address)
+	if err != nil {
+		return nil, err
+	}
+
+	var conn net.Conn
+	err = cb.ExecuteContext(ctx, func(ctx context.Context) error {
+		var err error
+		conn, err = d.dial(ctx, network, address)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+// Breaker Returns the breaker guarding dials to address, creating it on first use.
+func (d *Dialer) Breaker(address string) (*CircuitBreaker, error) {
+	return d.breakers.get(address)
+}
+
+// Breakers Returns the breakers of every address dialed so far sorted by name.
+func (d *Dialer) Breakers() []*CircuitBreaker {
+	return d.breakers.list()
+}
+
+// Close Stops the breaker of every address.
+func (d *Dialer) Close() {
+	d.breakers.close()
+}
@@ -0,0 +1,56 @@
+package breaker
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDialer(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	dials := map[string]int{}
+	var netDialer net.Dialer
+	d := NewDialer(func(ctx context.Context, network, address string) (net.Conn, error) {
+		dials[address]++
+		return netDialer.DialContext(ctx, network, address)
+	}, WithCanTrip(func(summary Counts) bool { return summary.Fail >= 2 }))
+	defer d.Close()
+
+	healthy := listener.Addr().String()
+	conn, err := d.DialContext(context.Background(), "tcp", healthy)
+	require.NoError(t, err)
+	conn.Close()
+
+	down := "127.0.0.1:1"
+	for i := 0; i < 4; i++ {
+		_, err = d.DialContext(context.Background(), "tcp", down)
+		assert.Error(t, err)
+	}
+	assert.ErrorIs(t, err, ErrOpenCircuit)
+	assert.Equal(t, 2, dials[down])
+
+	conn, err = d.DialContext(context.Background(), "tcp", healthy)
+	require.NoError(t, err)
+	conn.Close()
+
+	got := []string{}
+	for _, cb := range d.Breakers() {
+		got = append(got, cb.Name()+":"+string(cb.State()))
+	}
+	assert.ElementsMatch(t, []string{healthy + ":closed", down + ":open"}, got)
+}
@@ -0,0 +1,28 @@
+package breaker
+
+import (
+	"context"
+	"sync"
+)
+
+// Discovery Publishes the state this instance sees for a named dependency into a service
+// discovery backend, so routers and meshes upstream learn which dependencies it considers down.
+type Discovery interface {
+	Publish(ctx context.Context, dependency string, state State) error
+}
+
+type discovery struct {
+	cb      *CircuitBreaker
+	backend Discovery
+
+	mu sync.Mutex
+}
+
+// publish Writes the current state rather than the one that triggered it, so publishes
+// racing each other still leave the backend with the latest state.
+func (d *discovery) publish() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	_ = d.backend.Publish(context.Background(), d.cb.name, d.cb.stateCopy())
+}
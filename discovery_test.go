@@ -0,0 +1,55 @@
+package breaker
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingDiscovery struct {
+	states map[string]State
+	mu     sync.Mutex
+}
+
+func newRecordingDiscovery() *recordingDiscovery {
+	return &recordingDiscovery{states: make(map[string]State)}
+}
+
+func (r *recordingDiscovery) Publish(ctx context.Context, dependency string, state State) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.states[dependency] = state
+	return nil
+}
+
+func (r *recordingDiscovery) state(dependency string) State {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.states[dependency]
+}
+
+func TestBreakerDiscovery(t *testing.T) {
+	backend := newRecordingDiscovery()
+	cb, cancel, err := New(WithName("payments"), WithDiscovery(backend))
+	require.NoError(t, err)
+	defer cancel()
+
+	assert.Eventually(t, func() bool { return backend.state("payments") == Closed }, time.Second, time.Millisecond*10)
+
+	tt := []struct {
+		apply    func()
+		expected State
+	}{
+		{apply: cb.Trip, expected: Open},
+		{apply: cb.ProbeNow, expected: HalfOpen},
+		{apply: cb.Reset, expected: Closed},
+	}
+	for _, tc := range tt {
+		tc.apply()
+		assert.Eventually(t, func() bool { return backend.state("payments") == tc.expected }, time.Second, time.Millisecond*10)
+	}
+}
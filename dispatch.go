@@ -0,0 +1,54 @@
+package breaker
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// dispatcher Runs notifications in order on a single worker so slow consumers never hold
+// the breaker back, notifications overflowing the queue are dropped and accounted.
+type dispatcher struct {
+	queue   chan func()
+	done    chan struct{}
+	dropped atomic.Uint64
+
+	once sync.Once
+}
+
+func newDispatcher(size int) *dispatcher {
+	d := &dispatcher{
+		queue: make(chan func(), size),
+		done:  make(chan struct{}),
+	}
+	go d.run()
+	return d
+}
+
+func (d *dispatcher) run() {
+	for {
+		select {
+		case fn := <-d.queue:
+			fn()
+		case <-d.done:
+			return
+		}
+	}
+}
+
+func (d *dispatcher) dispatch(fn func()) {
+	select {
+	case d.queue <- fn:
+	default:
+		d.dropped.Add(1)
+	}
+}
+
+func (d *dispatcher) stop() {
+	d.once.Do(func() { close(d.done) })
+}
+
+// DroppedEvents Returns how many notifications were dropped because the dispatch queue
+// or a subscriber buffer was full.
+func (c *CircuitBreaker) DroppedEvents() uint64 {
+	return c.dispatcher.dropped.Load()
+}
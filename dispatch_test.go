@@ -0,0 +1,60 @@
+package breaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDispatcherDropsWhenFull(t *testing.T) {
+	d := newDispatcher(1)
+	defer d.stop()
+
+	block := make(chan struct{})
+	running := make(chan struct{})
+	d.dispatch(func() {
+		close(running)
+		<-block
+	})
+	<-running
+
+	done := make(chan struct{})
+	d.dispatch(func() { close(done) })
+	d.dispatch(func() {})
+	d.dispatch(func() {})
+	assert.Equal(t, uint64(2), d.dropped.Load())
+
+	close(block)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("queued notification never ran")
+	}
+}
+
+func TestBreakerSlowSubscriberDoesNotStall(t *testing.T) {
+	cb, cancel, err := New(WithEventQueue(1))
+	require.NoError(t, err)
+	defer cancel()
+
+	_, unsubscribe := cb.Subscribe(0)
+	defer unsubscribe()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			cb.Trip()
+			cb.Reset()
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("transitions stalled on a slow subscriber")
+	}
+	assert.Greater(t, cb.DroppedEvents(), uint64(0))
+}
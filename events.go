@@ -0,0 +1,78 @@
+package breaker
+
+import (
+	"sync"
+	"time"
+)
+
+type EventType string
+
+const (
+	EventTransition EventType = "transition"
+	EventFrameRoll  EventType = "frame-roll"
+	EventFlapping   EventType = "flapping"
+	EventStoreError EventType = "store-error"
+)
+
+type Event struct {
+	Type    EventType         `json:"type"`
+	Breaker string            `json:"breaker"`
+	Labels  map[string]string `json:"labels,omitempty"`
+	Time    time.Time         `json:"time"`
+
+	From   State  `json:"from,omitempty"`
+	To     State  `json:"to,omitempty"`
+	Reason Reason `json:"reason,omitempty"`
+
+	Frame   Counts `json:"frame"`
+	Summary Counts `json:"summary"`
+
+	Error string `json:"error,omitempty"`
+}
+
+type subscribers struct {
+	chans map[chan Event]struct{}
+
+	mu sync.RWMutex
+}
+
+// Subscribe Streams the breaker events until unsubscribe is called, events are dropped
+// whenever the subscriber falls more than buffer events behind.
+func (c *CircuitBreaker) Subscribe(buffer int) (events <-chan Event, unsubscribe func()) {
+	ch := make(chan Event, buffer)
+
+	c.subscribers.mu.Lock()
+	c.subscribers.chans[ch] = struct{}{}
+	c.subscribers.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			c.subscribers.mu.Lock()
+			defer c.subscribers.mu.Unlock()
+			delete(c.subscribers.chans, ch)
+			close(ch)
+		})
+	}
+}
+
+func (c *CircuitBreaker) emit(event Event) {
+	event.Breaker = c.name
+	event.Labels = c.Labels()
+	event.Time = c.clock.Now()
+
+	c.dispatcher.dispatch(func() { c.deliver(event) })
+}
+
+func (c *CircuitBreaker) deliver(event Event) {
+	c.subscribers.mu.RLock()
+	defer c.subscribers.mu.RUnlock()
+
+	for ch := range c.subscribers.chans {
+		select {
+		case ch <- event:
+		default:
+			c.dispatcher.dropped.Add(1)
+		}
+	}
+}
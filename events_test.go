@@ -0,0 +1,69 @@
+package breaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreakerSubscribeFrameRoll(t *testing.T) {
+	cb, cancel, err := New(
+		WithName("payments"),
+		WithWindowFrameThreshold(1),
+		WithWindowRollThreshold(2),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	events, unsubscribe := cb.Subscribe(8)
+	defer unsubscribe()
+
+	syncFeedCircuitBreakerHelper(cb, []error{nil, errCall}, false)
+
+	frameRoll := <-events
+	assert.Equal(t, EventFrameRoll, frameRoll.Type)
+	assert.Equal(t, "payments", frameRoll.Breaker)
+	assert.Equal(t, Counts{Total: 2, Fail: 1, Success: 1}, frameRoll.Frame)
+	assert.Equal(t, Counts{Total: 2, Fail: 1, Success: 1}, frameRoll.Summary)
+	assert.False(t, frameRoll.Time.IsZero())
+}
+
+func TestBreakerSubscribeTransitions(t *testing.T) {
+	cb, cancel, err := New(
+		WithName("payments"),
+		WithOpenDuration(1),
+		WithCanTrip(func(summary Counts) bool { return summary.Fail > 0 }),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	events, unsubscribe := cb.Subscribe(8)
+	dropped, unsubscribeDropped := cb.Subscribe(0)
+	defer unsubscribeDropped()
+
+	syncFeedCircuitBreakerHelper(cb, []error{nil, errCall}, false)
+
+	opened := <-events
+	assert.Equal(t, EventTransition, opened.Type)
+	assert.Equal(t, "payments", opened.Breaker)
+	assert.Equal(t, Closed, opened.From)
+	assert.Equal(t, Open, opened.To)
+	assert.Equal(t, Counts{Total: 2, Fail: 1, Success: 1}, opened.Summary)
+
+	halfOpened := <-events
+	assert.Equal(t, Open, halfOpened.From)
+	assert.Equal(t, HalfOpen, halfOpened.To)
+
+	unsubscribe()
+	unsubscribe()
+	_, ok := <-events
+	assert.False(t, ok)
+
+	select {
+	case <-dropped:
+		t.Fatal("unbuffered subscriber received an event")
+	case <-time.After(time.Millisecond * 10):
+	}
+}
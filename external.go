@@ -0,0 +1,99 @@
+package breaker
+
+import (
+	"fmt"
+	"sync"
+)
+
+// HealthPolicy Combines the external health signal with the live stats.
+type HealthPolicy string
+
+const (
+	// HealthExternalWins Holds the circuit open while unhealthy and closed while healthy.
+	HealthExternalWins HealthPolicy = "external-wins"
+	// HealthAnd Holds the circuit open while unhealthy, leaving the stats to decide otherwise.
+	HealthAnd HealthPolicy = "and"
+	// HealthOr Holds the circuit closed while healthy, leaving the stats to decide otherwise.
+	HealthOr HealthPolicy = "or"
+)
+
+// ExternalHealth Is the last health signal set on the breaker.
+type ExternalHealth struct {
+	Set     bool   `json:"set"`
+	Healthy bool   `json:"healthy"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+type external struct {
+	policy HealthPolicy
+	health ExternalHealth
+
+	mu sync.RWMutex
+}
+
+func (e *external) set(healthy bool, reason string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.health = ExternalHealth{Set: true, Healthy: healthy, Reason: reason}
+}
+
+func (e *external) get() ExternalHealth {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.health
+}
+
+func (e *external) holdsOpen() bool {
+	h := e.get()
+	return h.Set && !h.Healthy && e.policy != HealthOr
+}
+
+func (e *external) holdsClosed() bool {
+	h := e.get()
+	return h.Set && h.Healthy && e.policy != HealthAnd
+}
+
+// SetExternalHealth Feeds the verdict of a health checker or control plane, combined with the
+// live stats by the policy set with WithHealthPolicy. Releasing a circuit held open moves it to
+// half-open right away.
+func (c *CircuitBreaker) SetExternalHealth(healthy bool, reason string) {
+	c.mutate(func() {
+		heldOpen := c.external.holdsOpen()
+		c.external.set(healthy, reason)
+
+		switch {
+		case c.external.holdsOpen():
+			c.open(ReasonExternalHealth)
+		case c.external.holdsClosed():
+			c.close(ReasonExternalHealth)
+		case heldOpen && c.state.s == Open:
+			c.stopHalfOpenTimer()
+			c.toHalfOpen(ReasonExternalHealth)
+		}
+	})
+}
+
+// ExternalHealth Returns the last health signal set, Set being false until the first one.
+func (c *CircuitBreaker) ExternalHealth() ExternalHealth {
+	return c.external.get()
+}
+
+// holdOpen Keeps waiting out the open duration while the external health holds the circuit
+// open, reporting whether it did.
+func (c *CircuitBreaker) holdOpen() bool {
+	defer c.lockState()()
+
+	if c.state.s != Open || !c.external.holdsOpen() {
+		return false
+	}
+	c.waitHalfOpen()
+	return true
+}
+
+func validHealthPolicy(policy HealthPolicy) error {
+	switch policy {
+	case HealthExternalWins, HealthAnd, HealthOr:
+		return nil
+	}
+	return fmt.Errorf("%w: %q", ErrInvalidHealthPolicy, policy)
+}
@@ -0,0 +1,59 @@
+package breaker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreakerExternalHealth(t *testing.T) {
+	tt := []struct {
+		name          string
+		policy        HealthPolicy
+		unhealthyOpen bool
+		healthyClosed bool
+	}{
+		{name: "external_wins", policy: HealthExternalWins, unhealthyOpen: true, healthyClosed: true},
+		{name: "and", policy: HealthAnd, unhealthyOpen: true, healthyClosed: false},
+		{name: "or", policy: HealthOr, unhealthyOpen: false, healthyClosed: true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			cb, cancel, err := New(
+				WithCanTrip(func(summary Counts) bool { return summary.Fail > 0 }),
+				WithHealthPolicy(tc.policy),
+			)
+			require.NoError(t, err)
+			defer cancel()
+
+			assert.Equal(t, ExternalHealth{}, cb.ExternalHealth())
+
+			cb.SetExternalHealth(false, "control plane drained the zone")
+			assert.Equal(t, ExternalHealth{Set: true, Reason: "control plane drained the zone"}, cb.ExternalHealth())
+			if tc.unhealthyOpen {
+				assert.Equal(t, Open, cb.State())
+				assert.Equal(t, ReasonExternalHealth, cb.Transitions(1)[0].Reason)
+				cb.halfOpen()
+				assert.Equal(t, Open, cb.State())
+			} else {
+				assert.Equal(t, Closed, cb.State())
+			}
+
+			cb.SetExternalHealth(true, "")
+			assert.Equal(t, HalfOpen == cb.State(), tc.unhealthyOpen && !tc.healthyClosed)
+			if tc.healthyClosed {
+				assert.Equal(t, Closed, cb.State())
+			}
+
+			cb.Reset()
+			assert.ErrorIs(t, cb.Execute(fixtureCircuitCall(errCall)), errCall)
+			if tc.healthyClosed {
+				assert.Equal(t, Closed, cb.State())
+			} else {
+				assert.Equal(t, Open, cb.State())
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package breaker
+
+import (
+	"sync"
+	"time"
+)
+
+// flapDetector Spots a circuit opened opens times within a period, doubling its open
+// duration for every further open up to a ceiling, since a flapping breaker lets a burst of
+// calls through to a struggling dependency at every cycle.
+type flapDetector struct {
+	opens           int
+	within          time.Duration
+	maxOpenDuration time.Duration
+	times           []time.Time
+
+	mu sync.Mutex
+}
+
+// opened Records an open, reporting whether it started the flapping.
+func (f *flapDetector) opened(now time.Time) bool {
+	if f == nil {
+		return false
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.times = append(f.recent(now), now)
+	return len(f.times) == f.opens
+}
+
+// recent Drops the opens older than the period, the lock must be held.
+func (f *flapDetector) recent(now time.Time) []time.Time {
+	i := 0
+	for i < len(f.times) && now.Sub(f.times[i]) >= f.within {
+		i++
+	}
+	return f.times[i:]
+}
+
+// escalate Scales the open duration d while flapping.
+func (f *flapDetector) escalate(d time.Duration, now time.Time) time.Duration {
+	if f == nil {
+		return d
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if d >= f.maxOpenDuration {
+		return d
+	}
+	for excess := len(f.recent(now)) - f.opens; excess >= 0; excess-- {
+		if d *= 2; d >= f.maxOpenDuration {
+			return f.maxOpenDuration
+		}
+	}
+	return d
+}
+
+func (f *flapDetector) flapping(now time.Time) bool {
+	if f == nil {
+		return false
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.recent(now)) >= f.opens
+}
+
+// Flapping Reports whether the circuit opened often enough lately to be deemed flapping, as
+// set with WithFlapDetection.
+func (c *CircuitBreaker) Flapping() bool {
+	return c.flaps.flapping(c.clock.Now())
+}
+
+// flapped Records an open of the closed circuit and notifies the start of flapping, the
+// state lock must be held.
+func (c *CircuitBreaker) flapped() {
+	if c.flaps.opened(c.clock.Now()) {
+		c.emit(Event{
+			Type:    EventFlapping,
+			Frame:   c.currentFrameCopy(),
+			Summary: c.summaryCopy(),
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package breaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlapDetector(t *testing.T) {
+	now := time.Date(2024, time.January, 15, 10, 0, 0, 0, time.UTC)
+	f := &flapDetector{opens: 3, within: time.Minute, maxOpenDuration: 10 * time.Second}
+
+	assert.False(t, f.opened(now))
+	assert.False(t, f.opened(now.Add(10*time.Second)))
+	assert.Equal(t, time.Second, f.escalate(time.Second, now.Add(10*time.Second)))
+
+	assert.True(t, f.opened(now.Add(20*time.Second)))
+	assert.True(t, f.flapping(now.Add(20*time.Second)))
+	assert.Equal(t, 2*time.Second, f.escalate(time.Second, now.Add(20*time.Second)))
+
+	assert.False(t, f.opened(now.Add(30*time.Second)))
+	assert.Equal(t, 4*time.Second, f.escalate(time.Second, now.Add(30*time.Second)))
+	assert.Equal(t, 10*time.Second, f.escalate(3*time.Second, now.Add(30*time.Second)))
+	assert.Equal(t, 20*time.Second, f.escalate(20*time.Second, now.Add(30*time.Second)))
+
+	assert.False(t, f.flapping(now.Add(75*time.Second)))
+	assert.Equal(t, time.Second, f.escalate(time.Second, now.Add(75*time.Second)))
+}
+
+func TestBreakerFlapDetection(t *testing.T) {
+	cb, cancel, err := New(
+		WithFlapDetection(2, time.Minute, time.Hour),
+		WithOpenDuration(1),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	events, unsubscribe := cb.Subscribe(10)
+	defer unsubscribe()
+
+	cb.Trip()
+	assert.False(t, cb.Flapping())
+	assert.InDelta(t, time.Second, cb.TimeUntilHalfOpen(), float64(100*time.Millisecond))
+
+	cb.ProbeNow()
+	cb.Trip()
+	assert.False(t, cb.Flapping())
+	assert.InDelta(t, time.Second, cb.TimeUntilHalfOpen(), float64(100*time.Millisecond))
+
+	cb.Reset()
+	cb.Trip()
+	assert.True(t, cb.Flapping())
+	assert.InDelta(t, 2*time.Second, cb.TimeUntilHalfOpen(), float64(100*time.Millisecond))
+
+	types := []EventType{}
+	require.Eventually(t, func() bool {
+		select {
+		case event := <-events:
+			types = append(types, event.Type)
+		default:
+		}
+		return len(types) == 6
+	}, time.Second, time.Millisecond)
+	assert.Contains(t, types, EventFlapping)
+}
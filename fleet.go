@@ -0,0 +1,101 @@
+package breaker
+
+import (
+	"sync"
+	"time"
+)
+
+type FleetEvent struct {
+	Breaker string
+	Node    string
+	State   State
+	Time    time.Time
+}
+
+// FleetTransport Carries breaker state changes between the instances of a fleet, Subscribe
+// handlers must be called for every event broadcast by the other nodes until unsubscribed.
+type FleetTransport interface {
+	Broadcast(event FleetEvent) error
+	Subscribe(handler func(event FleetEvent)) (unsubscribe func())
+}
+
+type fleet struct {
+	cb        *CircuitBreaker
+	node      string
+	transport FleetTransport
+	quorum    int
+	openPeers map[string]time.Time
+
+	unsubscribe func()
+
+	mu sync.Mutex
+}
+
+func newFleet(cb *CircuitBreaker, node string, transport FleetTransport, quorum int) *fleet {
+	f := &fleet{
+		cb:        cb,
+		node:      node,
+		transport: transport,
+		quorum:    quorum,
+		openPeers: make(map[string]time.Time),
+	}
+	f.unsubscribe = transport.Subscribe(f.receive)
+
+	return f
+}
+
+// publish Broadcasts the transitions of the breaker but the trips the fleet itself caused,
+// which would otherwise count toward the quorum of the peers as fresh observations.
+func (f *fleet) publish(state State, reason Reason) {
+	if reason == ReasonFleetQuorum {
+		return
+	}
+
+	_ = f.transport.Broadcast(FleetEvent{
+		Breaker: f.cb.name,
+		Node:    f.node,
+		State:   state,
+		Time:    f.cb.clock.Now(),
+	})
+}
+
+func (f *fleet) close() {
+	if f.unsubscribe != nil {
+		f.unsubscribe()
+	}
+}
+
+func (f *fleet) receive(event FleetEvent) {
+	if event.Breaker != f.cb.name || event.Node == f.node {
+		return
+	}
+
+	if f.reachedQuorum(event) {
+		f.cb.trip(ReasonFleetQuorum)
+	}
+}
+
+// reachedQuorum Tracks which peers currently report the breaker open, reports that stopped
+// being refreshed within the window roll no longer count toward the quorum.
+func (f *fleet) reachedQuorum(event FleetEvent) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if event.State != Open {
+		delete(f.openPeers, event.Node)
+		return false
+	}
+	f.openPeers[event.Node] = event.Time
+
+	open := 0
+	now := f.cb.clock.Now()
+	for node, at := range f.openPeers {
+		if now.Sub(at) > f.cb.cfg.windowRoll {
+			delete(f.openPeers, node)
+			continue
+		}
+		open++
+	}
+
+	return open >= f.quorum
+}
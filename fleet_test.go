@@ -0,0 +1,111 @@
+package breaker
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreakerFleetBroadcastsTransitions(t *testing.T) {
+	transport := newFleetTransportHelper()
+	cb, cancel, err := New(
+		WithName("payments"),
+		WithFleet("node-a", transport, 2),
+		WithCanTrip(func(summary Counts) bool { return summary.Fail > 0 }),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	syncFeedCircuitBreakerHelper(cb, []error{errCall}, false)
+
+	event := <-transport.events
+	assert.Equal(t, "payments", event.Breaker)
+	assert.Equal(t, "node-a", event.Node)
+	assert.Equal(t, Open, event.State)
+	assert.False(t, event.Time.IsZero())
+}
+
+func TestBreakerFleetQuorum(t *testing.T) {
+	transport := newFleetTransportHelper()
+	cb, cancel, err := New(
+		WithName("payments"),
+		WithFleet("node-a", transport, 2),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	openEvent := func(breaker, node string) FleetEvent {
+		return FleetEvent{Breaker: breaker, Node: node, State: Open, Time: time.Now()}
+	}
+
+	transport.deliver(openEvent("payments", "node-b"))
+	transport.deliver(openEvent("orders", "node-c"))
+	transport.deliver(openEvent("payments", "node-a"))
+	transport.deliver(FleetEvent{Breaker: "payments", Node: "node-d", State: Open, Time: time.Now().Add(-time.Hour)})
+	assert.Equal(t, Closed, cb.stateCopy())
+
+	transport.deliver(FleetEvent{Breaker: "payments", Node: "node-b", State: HalfOpen, Time: time.Now()})
+	transport.deliver(openEvent("payments", "node-c"))
+	assert.Equal(t, Closed, cb.stateCopy())
+
+	transport.deliver(openEvent("payments", "node-b"))
+	assert.Equal(t, Open, cb.stateCopy())
+
+	time.Sleep(time.Millisecond * 50)
+	assert.Empty(t, transport.events, "the trips caused by the fleet aren't echoed back to it")
+}
+
+func TestBreakerFleetUnsubscribesOnCancel(t *testing.T) {
+	transport := newFleetTransportHelper()
+	cb, cancel, err := New(
+		WithName("payments"),
+		WithFleet("node-a", transport, 1),
+	)
+	require.NoError(t, err)
+	cancel()
+
+	transport.deliver(FleetEvent{Breaker: "payments", Node: "node-b", State: Open, Time: time.Now()})
+	assert.Equal(t, Closed, cb.stateCopy())
+}
+
+type fleetTransportHelper struct {
+	handlers []func(event FleetEvent)
+	events   chan FleetEvent
+
+	mu sync.Mutex
+}
+
+func newFleetTransportHelper() *fleetTransportHelper {
+	return &fleetTransportHelper{events: make(chan FleetEvent, 16)}
+}
+
+func (f *fleetTransportHelper) Broadcast(event FleetEvent) error {
+	f.events <- event
+	return nil
+}
+
+func (f *fleetTransportHelper) Subscribe(handler func(event FleetEvent)) func() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.handlers = append(f.handlers, handler)
+
+	i := len(f.handlers) - 1
+	return func() {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.handlers[i] = nil
+	}
+}
+
+func (f *fleetTransportHelper) deliver(event FleetEvent) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, handler := range f.handlers {
+		if handler != nil {
+			handler(event)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package breaker
+
+import (
+	"context"
+	"fmt"
+)
+
+// Future Is the pending result of a call run by ExecuteAsync.
+type Future struct {
+	done chan struct{}
+	err  error
+}
+
+// ExecuteAsync Runs fn on its own goroutine, calls rejected up front resolve the future
+// immediately and panics resolve it with ErrCallPanic instead of crashing the process.
+func (c *CircuitBreaker) ExecuteAsync(fn circuitCall) *Future {
+	f := &Future{done: make(chan struct{})}
+
+	if err := c.canExecute(); err != nil && c.openQueue == nil {
+		f.err = c.silence(c.rejected(context.Background(), c.rejection(err)))
+		close(f.done)
+		return f
+	}
+
+	go func() {
+		defer close(f.done)
+		defer func() {
+			if r := recover(); r != nil {
+				f.err = fmt.Errorf("%w: %v", ErrCallPanic, r)
+			}
+		}()
+
+		f.err = c.Execute(fn)
+	}()
+
+	return f
+}
+
+// Done Returns a channel closed once the call resolved the future.
+func (f *Future) Done() <-chan struct{} {
+	return f.done
+}
+
+// Wait Blocks until the call resolved the future and returns its error, or returns the error
+// of ctx when it is done first.
+func (f *Future) Wait(ctx context.Context) error {
+	select {
+	case <-f.done:
+		return f.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,61 @@
+package breaker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreakerExecuteAsync(t *testing.T) {
+	cb, cancel, err := New(
+		WithCanTrip(func(summary Counts) bool { return summary.Fail > 1 }),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	started, release := make(chan struct{}), make(chan struct{})
+	pending := cb.ExecuteAsync(func() error {
+		close(started)
+		<-release
+		return nil
+	})
+	<-started
+
+	failed := cb.ExecuteAsync(fixtureCircuitCall(errCall))
+	assert.ErrorIs(t, failed.Wait(context.Background()), errCall)
+
+	panicked := cb.ExecuteAsync(func() error { panic("boom") })
+	assert.ErrorIs(t, panicked.Wait(context.Background()), ErrCallPanic)
+	require.Equal(t, Open, cb.stateCopy())
+
+	rejected := cb.ExecuteAsync(fixtureCircuitCall(nil))
+	select {
+	case <-rejected.Done():
+	default:
+		t.Fatal("short-circuited future is not resolved")
+	}
+	assert.ErrorIs(t, rejected.Wait(context.Background()), ErrOpenCircuit)
+
+	ctx, ctxCancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+	defer ctxCancel()
+	assert.ErrorIs(t, pending.Wait(ctx), context.DeadlineExceeded)
+
+	close(release)
+	assert.NoError(t, pending.Wait(context.Background()))
+	assert.Equal(t, Counts{Total: 3, Fail: 2, Success: 1}, cb.summaryCopy())
+}
+
+func TestBreakerExecuteAsyncObservesRejections(t *testing.T) {
+	observer := &recordingObserver{}
+	cb, cancel, err := New(WithObserver(observer))
+	require.NoError(t, err)
+	defer cancel()
+	cb.Trip()
+
+	rejected := cb.ExecuteAsync(fixtureCircuitCall(nil))
+	assert.ErrorIs(t, rejected.Wait(context.Background()), ErrOpenCircuit)
+	assert.Equal(t, []string{"rejected:open"}, observer.calls)
+}
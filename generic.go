@@ -0,0 +1,85 @@
+package breaker
+
+import (
+	"context"
+	"sync"
+)
+
+// Execute Runs fn through the breaker and hands back the value it produced.
+func Execute[T any](c Breaker, fn func() (T, error)) (T, error) {
+	var value T
+	err := c.Execute(func() error {
+		var err error
+		value, err = fn()
+		return err
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return value, nil
+}
+
+// StaleCache Holds the last successful value per key ExecuteStale served, safe for
+// concurrent use.
+type StaleCache[T any] struct {
+	values map[string]T
+	mu     sync.RWMutex
+}
+
+// NewStaleCache Returns an empty cache to share between the ExecuteStale calls of a breaker.
+func NewStaleCache[T any]() *StaleCache[T] {
+	return &StaleCache[T]{values: make(map[string]T)}
+}
+
+func (s *StaleCache[T]) store(key string, value T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.values[key] = value
+}
+
+func (s *StaleCache[T]) load(key string) (T, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	value, ok := s.values[key]
+	return value, ok
+}
+
+// ExecuteStale Runs fn like Execute keeping the last value per key fn returned without error,
+// while the breaker turns calls away that value is served instead of the rejection and
+// flagged as stale. Under WithFailSilent a failure or rejection without a cached value
+// returns the zero T and no error, and is never cached.
+func ExecuteStale[T any](c Breaker, cache *StaleCache[T], key string, fn func() (T, error)) (value T, stale bool, err error) {
+	return ExecuteStaleContext(context.Background(), c, cache, key, func(context.Context) (T, error) {
+		return fn()
+	})
+}
+
+// ExecuteStaleContext Runs fn like ExecuteStale with ctx handed to the breaker, marking the
+// decision of ctx as fallen back when the stale value is served.
+func ExecuteStaleContext[T any](ctx context.Context, c Breaker, cache *StaleCache[T], key string, fn func(ctx context.Context) (T, error)) (value T, stale bool, err error) {
+	var ran, succeeded bool
+	err = c.ExecuteContext(ctx, func(ctx context.Context) error {
+		var err error
+		value, err = fn(ctx)
+		ran, succeeded = true, err == nil
+		return err
+	})
+	if succeeded && err == nil {
+		cache.store(key, value)
+		return value, false, nil
+	}
+
+	if !ran && (err == nil || IsRejection(err)) {
+		if cached, ok := cache.load(key); ok {
+			MarkFallback(ctx)
+			return cached, true, nil
+		}
+	}
+
+	var zero T
+	return zero, false, err
+}
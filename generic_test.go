@@ -0,0 +1,91 @@
+package breaker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecute(t *testing.T) {
+	cb, cancel, err := New()
+	require.NoError(t, err)
+	defer cancel()
+
+	got, err := Execute(cb, func() (int, error) { return 42, nil })
+	assert.NoError(t, err)
+	assert.Equal(t, 42, got)
+
+	got, err = Execute(cb, func() (int, error) { return 42, errCall })
+	assert.ErrorIs(t, err, errCall)
+	assert.Zero(t, got)
+	assert.Equal(t, Counts{Total: 2, Fail: 1, Success: 1}, cb.Counts())
+}
+
+func TestExecuteStale(t *testing.T) {
+	cb, cancel, err := New()
+	require.NoError(t, err)
+	defer cancel()
+
+	cache := NewStaleCache[string]()
+
+	got, stale, err := ExecuteStale(cb, cache, "user:1", func() (string, error) { return "alice", nil })
+	assert.NoError(t, err)
+	assert.False(t, stale)
+	assert.Equal(t, "alice", got)
+
+	got, stale, err = ExecuteStale(cb, cache, "user:1", func() (string, error) { return "", errCall })
+	assert.ErrorIs(t, err, errCall)
+	assert.False(t, stale)
+	assert.Empty(t, got)
+
+	cb.Trip()
+
+	got, stale, err = ExecuteStale(cb, cache, "user:1", func() (string, error) { return "bob", nil })
+	assert.NoError(t, err)
+	assert.True(t, stale)
+	assert.Equal(t, "alice", got)
+
+	got, stale, err = ExecuteStale(cb, cache, "user:2", func() (string, error) { return "bob", nil })
+	assert.ErrorIs(t, err, ErrOpenCircuit)
+	assert.False(t, stale)
+	assert.Empty(t, got)
+}
+
+func TestExecuteStaleFailSilent(t *testing.T) {
+	var recorded []error
+	cb, cancel, err := New(WithFailSilent(func(err error) { recorded = append(recorded, err) }))
+	require.NoError(t, err)
+	defer cancel()
+
+	cache := NewStaleCache[string]()
+
+	got, stale, err := ExecuteStale(cb, cache, "user:1", func() (string, error) { return "alice", nil })
+	require.NoError(t, err)
+	assert.False(t, stale)
+	assert.Equal(t, "alice", got)
+
+	got, stale, err = ExecuteStale(cb, cache, "user:1", func() (string, error) { return "", errCall })
+	assert.NoError(t, err)
+	assert.False(t, stale)
+	assert.Empty(t, got)
+
+	cb.Trip()
+
+	got, stale, err = ExecuteStale(cb, cache, "user:1", func() (string, error) { return "bob", nil })
+	assert.NoError(t, err)
+	assert.True(t, stale)
+	assert.Equal(t, "alice", got)
+
+	got, stale, err = ExecuteStale(cb, cache, "user:2", func() (string, error) { return "bob", nil })
+	assert.NoError(t, err)
+	assert.False(t, stale)
+	assert.Empty(t, got)
+
+	_, ok := cache.load("user:2")
+	assert.False(t, ok)
+	require.Len(t, recorded, 3)
+	assert.ErrorIs(t, recorded[0], errCall)
+	assert.ErrorIs(t, recorded[1], ErrOpenCircuit)
+	assert.ErrorIs(t, recorded[2], ErrOpenCircuit)
+}
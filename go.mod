@@ -2,7 +2,10 @@ module github.com/gilbertovgl/go-circuit-breaker
 
 go 1.21.0
 
-require github.com/stretchr/testify v1.9.0
+require (
+	github.com/stretchr/testify v1.9.0
+	golang.org/x/net v0.24.0
+)
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
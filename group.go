@@ -0,0 +1,94 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var ErrNoKey = errors.New("no breaker key")
+
+type keyFunc func(ctx context.Context) string
+
+type groupKey struct{}
+
+// ContextWithKey Returns ctx carrying the key a Group resolves the breaker of its calls by,
+// for integrations such as middlewares to set it once per request.
+func ContextWithKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, groupKey{}, key)
+}
+
+// KeyFromContext Returns the key set with ContextWithKey, empty when none was.
+func KeyFromContext(ctx context.Context) string {
+	key, _ := ctx.Value(groupKey{}).(string)
+	return key
+}
+
+// Group Fans the calls of a single call site out to a breaker per key, such as per shard,
+// region or customer, creating every breaker from the group options and naming it after
+// its key.
+type Group struct {
+	breakers *breakerSet
+	key      keyFunc
+}
+
+// NewGroup Creates a group whose ExecuteContext resolves the key through the WithKeyFunc
+// option, KeyFromContext when not given.
+func NewGroup(opts ...option) *Group {
+	cfg := &optionsConfiguration{}
+	for _, opt := range opts {
+		// The options are validated when the breakers are created, only the key function
+		// matters here.
+		_ = opt(cfg)
+	}
+	if cfg.keyFunc == nil {
+		cfg.keyFunc = KeyFromContext
+	}
+
+	return &Group{
+		breakers: newBreakerSet(opts),
+		key:      cfg.keyFunc,
+	}
+}
+
+func (g *Group) ExecuteKeyed(key string, fn func() error) error {
+	return g.ExecuteKeyedContext(context.Background(), key, func(context.Context) error { return fn() })
+}
+
+// ExecuteKeyedContext Runs fn through the breaker of key, creating it on first use.
+func (g *Group) ExecuteKeyedContext(ctx context.Context, key string, fn func(ctx context.Context) error) error {
+	if key == "" {
+		return ErrNoKey
+	}
+
+	cb, err := g.breakers.get(key)
+	if err != nil {
+		return err
+	}
+	return cb.ExecuteContext(ctx, fn)
+}
+
+// ExecuteContext Runs fn through the breaker of the key the key function resolves from ctx,
+// failing with ErrNoKey when it resolves none.
+func (g *Group) ExecuteContext(ctx context.Context, fn func(ctx context.Context) error) error {
+	key := g.key(ctx)
+	if key == "" {
+		return fmt.Errorf("%w: key function resolved none", ErrNoKey)
+	}
+	return g.ExecuteKeyedContext(ctx, key, fn)
+}
+
+// Breaker Returns the breaker of key, creating it on first use.
+func (g *Group) Breaker(key string) (*CircuitBreaker, error) {
+	return g.breakers.get(key)
+}
+
+// Breakers Returns the breakers of every key executed so far sorted by name.
+func (g *Group) Breakers() []*CircuitBreaker {
+	return g.breakers.list()
+}
+
+// Close Stops the breaker of every key.
+func (g *Group) Close() {
+	g.breakers.close()
+}
@@ -0,0 +1,61 @@
+package breaker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type shardKey struct{}
+
+func TestGroupExecuteKeyed(t *testing.T) {
+	g := NewGroup(WithCanTrip(func(summary Counts) bool { return summary.Fail > 0 }))
+	defer g.Close()
+
+	assert.ErrorIs(t, g.ExecuteKeyed("eu", fixtureCircuitCall(errCall)), errCall)
+	assert.ErrorIs(t, g.ExecuteKeyed("eu", fixtureCircuitCall(nil)), ErrOpenCircuit)
+	assert.NoError(t, g.ExecuteKeyed("us", fixtureCircuitCall(nil)))
+	assert.ErrorIs(t, g.ExecuteKeyed("", fixtureCircuitCall(nil)), ErrNoKey)
+
+	require.Len(t, g.Breakers(), 2)
+	assert.Equal(t, "eu", g.Breakers()[0].Name())
+	assert.Equal(t, Open, g.Breakers()[0].State())
+}
+
+func TestGroupExecuteContext(t *testing.T) {
+	tt := []struct {
+		name string
+		opts []option
+		ctx  context.Context
+	}{
+		{
+			name: "key_from_context_by_default",
+			ctx:  ContextWithKey(context.Background(), "shard-1"),
+		},
+		{
+			name: "key_func",
+			opts: []option{WithKeyFunc(func(ctx context.Context) string {
+				shard, _ := ctx.Value(shardKey{}).(string)
+				return shard
+			})},
+			ctx: context.WithValue(context.Background(), shardKey{}, "shard-1"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewGroup(tc.opts...)
+			defer g.Close()
+
+			require.NoError(t, g.ExecuteContext(tc.ctx, func(ctx context.Context) error { return nil }))
+			cb, err := g.Breaker("shard-1")
+			require.NoError(t, err)
+			assert.Equal(t, Counts{Total: 1, Success: 1}, cb.Counts())
+
+			err = g.ExecuteContext(context.Background(), func(ctx context.Context) error { return nil })
+			assert.ErrorIs(t, err, ErrNoKey)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package breaker
+
+import (
+	"sync"
+	"time"
+)
+
+// tokenBucket Admits a call per token, tokens refill one every period up to burst.
+type tokenBucket struct {
+	every  time.Duration
+	burst  int
+	tokens float64
+	last   time.Time
+
+	mu sync.Mutex
+}
+
+func newTokenBucket(every time.Duration, burst int) *tokenBucket {
+	if every <= 0 {
+		return nil
+	}
+
+	return &tokenBucket{every: every, burst: burst}
+}
+
+// fill Restores the whole burst, used every time the circuit enters half-open.
+func (b *tokenBucket) fill(now time.Time) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens = float64(b.burst)
+	b.last = now
+}
+
+func (b *tokenBucket) take(now time.Time) bool {
+	if b == nil {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens = min(float64(b.burst), b.tokens+(float64(now.Sub(b.last))/float64(b.every)))
+	b.last = now
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// canAdmitHalfOpen Rejects the half-open trial calls exceeding the current ramp step or the
+// half-open rate as if the circuit were still open. The ramp is checked first and gets its
+// slot back when the rate rejects, so neither is spent on a call the other turns away.
+func (c *CircuitBreaker) canAdmitHalfOpen() error {
+	if c.halfOpenRate == nil && c.ramp == nil {
+		return nil
+	}
+
+	v := c.load()
+	if v.mode != ModeNormal || v.state != HalfOpen {
+		return nil
+	}
+
+	now := c.clock.Now()
+	if !c.ramp.take(now, v.frame.Success) {
+		return ErrOpenCircuit
+	}
+	if !c.halfOpenRate.take(now) {
+		c.ramp.giveBack()
+		return ErrOpenCircuit
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package breaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreakerHalfOpenRate(t *testing.T) {
+	cb, cancel, err := New(
+		WithOpenDuration(1),
+		WithHalfOpenEvaluation(100, 100),
+		WithHalfOpenRate(200*time.Millisecond, 2),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	cb.Trip()
+	time.Sleep(cb.cfg.openDuration + (time.Millisecond * 100))
+	require.Equal(t, HalfOpen, cb.State())
+
+	assert.NoError(t, cb.Execute(fixtureCircuitCall(nil)))
+	assert.NoError(t, cb.Execute(fixtureCircuitCall(nil)))
+	assert.ErrorIs(t, cb.Execute(fixtureCircuitCall(nil)), ErrOpenCircuit)
+
+	time.Sleep(250 * time.Millisecond)
+	assert.NoError(t, cb.Execute(fixtureCircuitCall(nil)))
+	assert.ErrorIs(t, cb.Execute(fixtureCircuitCall(nil)), ErrOpenCircuit)
+	assert.Equal(t, Counts{Total: 3, Success: 3}, cb.currentFrameCopy())
+}
+
+func TestBreakerHalfOpenRateSkipsClosed(t *testing.T) {
+	cb, cancel, err := New(WithHalfOpenRate(time.Hour, 1))
+	require.NoError(t, err)
+	defer cancel()
+
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, cb.Execute(fixtureCircuitCall(nil)))
+	}
+}
+
+func TestBreakerHalfOpenRateWithRamp(t *testing.T) {
+	cb, cancel, err := New(
+		WithOpenDuration(1),
+		WithHalfOpenRamp(time.Hour, 3),
+		WithHalfOpenRate(time.Hour, 2),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	cb.Trip()
+	time.Sleep(cb.cfg.openDuration + (time.Millisecond * 100))
+	require.Equal(t, HalfOpen, cb.State())
+
+	assert.NoError(t, cb.Execute(fixtureCircuitCall(nil)))
+	assert.ErrorIs(t, cb.Execute(fixtureCircuitCall(nil)), ErrOpenCircuit)
+
+	cb.ramp.reset(cb.clock.Now())
+	assert.NoError(t, cb.Execute(fixtureCircuitCall(nil)))
+
+	cb.ramp.reset(cb.clock.Now())
+	assert.ErrorIs(t, cb.Execute(fixtureCircuitCall(nil)), ErrOpenCircuit)
+	assert.Zero(t, cb.ramp.admitted)
+}
@@ -0,0 +1,25 @@
+package breaker
+
+import "time"
+
+// ErrorRecord Is an error of a failed call and when it was recorded.
+type ErrorRecord struct {
+	Err  error
+	Time time.Time
+}
+
+func (c *CircuitBreaker) recordError(err error) {
+	c.errorHistory.push(ErrorRecord{Err: err, Time: c.clock.Now()})
+}
+
+// LastError Returns the error of the last failed call, <nil> when none failed or without
+// WithErrorHistory.
+func (c *CircuitBreaker) LastError() error {
+	record, _ := c.errorHistory.last()
+	return record.Err
+}
+
+// RecentErrors Returns the errors kept by WithErrorHistory, oldest first.
+func (c *CircuitBreaker) RecentErrors() []ErrorRecord {
+	return c.errorHistory.copy()
+}
@@ -0,0 +1,76 @@
+package breaker
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreakerErrorHistory(t *testing.T) {
+	errs := make([]error, 5)
+	for i := range errs {
+		errs[i] = fmt.Errorf("call %d: %w", i, errCall)
+	}
+
+	tt := []struct {
+		name           string
+		input          []option
+		calls          []error
+		expectedLast   error
+		expectedRecent []error
+	}{
+		{
+			name:           "disabled_by_default",
+			input:          nil,
+			calls:          errs[:2],
+			expectedLast:   nil,
+			expectedRecent: []error{},
+		},
+		{
+			name:           "keeps_errors_under_size",
+			input:          []option{WithErrorHistory(3)},
+			calls:          []error{errs[0], nil, errs[1]},
+			expectedLast:   errs[1],
+			expectedRecent: []error{errs[0], errs[1]},
+		},
+		{
+			name:           "overwrites_oldest_errors",
+			input:          []option{WithErrorHistory(3)},
+			calls:          errs,
+			expectedLast:   errs[4],
+			expectedRecent: []error{errs[2], errs[3], errs[4]},
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			cb, cancel, err := New(tc.input...)
+			require.NoError(t, err)
+			defer cancel()
+
+			syncFeedCircuitBreakerHelper(cb, tc.calls, false)
+
+			gotRecent := []error{}
+			for _, record := range cb.RecentErrors() {
+				assert.False(t, record.Time.IsZero())
+				gotRecent = append(gotRecent, record.Err)
+			}
+			assert.Equal(t, tc.expectedLast, cb.LastError())
+			assert.Equal(t, tc.expectedRecent, gotRecent)
+		})
+	}
+}
+
+func TestBreakerErrorHistoryRecordsPanic(t *testing.T) {
+	cb, cancel, err := New(WithErrorHistory(1))
+	require.NoError(t, err)
+	defer cancel()
+
+	assert.Panics(t, func() {
+		_ = cb.Execute(func() error { panic("boom") })
+	})
+
+	assert.ErrorIs(t, cb.LastError(), ErrCallPanic)
+	assert.EqualError(t, cb.LastError(), "call panicked: boom")
+}
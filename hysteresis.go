@@ -0,0 +1,25 @@
+package breaker
+
+// hysteresisTrip Trips the circuit once the window failure rate reaches tripRate percent.
+func hysteresisTrip(tripRate float64) canTrip {
+	return func(summary Counts) bool {
+		return summary.Total > _minimumVolume && summary.FailureRate() >= tripRate
+	}
+}
+
+// hysteresisEvaluation Closes a half-open circuit once calls trial calls failed at most
+// recoverRate percent of the time, reopening it as soon as more failed.
+func hysteresisEvaluation(recoverRate float64, calls int) fromHalfOpenToState {
+	allowed := uint64(recoverRate * float64(calls) / 100)
+	return func(frame Counts) State {
+		if frame.Fail > allowed {
+			return Open
+		}
+
+		if frame.Total >= uint64(calls) {
+			return Closed
+		}
+
+		return HalfOpen
+	}
+}
@@ -0,0 +1,50 @@
+package breaker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHysteresis(t *testing.T) {
+	trip := hysteresisTrip(60)
+	assert.True(t, trip(Counts{Total: 20, Fail: 12}))
+	assert.False(t, trip(Counts{Total: 20, Fail: 11}))
+	assert.False(t, trip(Counts{Total: 5, Fail: 5}))
+
+	tt := []struct {
+		name     string
+		frame    Counts
+		expected State
+	}{
+		{name: "keeps_probing", frame: Counts{Total: 10, Fail: 1}, expected: HalfOpen},
+		{name: "closes_under_recover_rate", frame: Counts{Total: 20, Fail: 1}, expected: Closed},
+		{name: "reopens_over_recover_rate", frame: Counts{Total: 5, Fail: 2}, expected: Open},
+	}
+
+	evaluate := hysteresisEvaluation(5, 20)
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, evaluate(tc.frame))
+		})
+	}
+}
+
+func TestBreakerHysteresis(t *testing.T) {
+	cb, cancel, err := New(WithHysteresis(60, 5, 20))
+	require.NoError(t, err)
+	defer cancel()
+
+	cb.Trip()
+	cb.ProbeNow()
+	syncFeedCircuitBreakerHelper(cb, append([]error{errCall}, make([]error, 18)...), false)
+	assert.Equal(t, HalfOpen, cb.State())
+	syncFeedCircuitBreakerHelper(cb, []error{nil}, false)
+	assert.Equal(t, Closed, cb.State())
+
+	cb.Trip()
+	cb.ProbeNow()
+	syncFeedCircuitBreakerHelper(cb, []error{errCall, errCall}, false)
+	assert.Equal(t, Open, cb.State())
+}
@@ -0,0 +1,78 @@
+package breaker
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	_hystrixTimeout                = 1000
+	_hystrixRequestVolumeThreshold = 20
+	_hystrixSleepWindow            = 5000
+	_hystrixErrorPercentThreshold  = 50
+	_hystrixWindow                 = time.Second * 10
+	_hystrixBuckets                = 10
+)
+
+// HystrixConfig Mirrors the hystrix-go CommandConfig, the zero settings taking the Hystrix
+// defaults as hystrix-go does. MaxConcurrentRequests has no counterpart here and is ignored.
+type HystrixConfig struct {
+	// Timeout Is timeoutInMilliseconds, the calls outliving it count as failures.
+	Timeout               int `json:"timeout"`
+	MaxConcurrentRequests int `json:"max_concurrent_requests"`
+	// RequestVolumeThreshold Is requestVolumeThreshold, the calls the window needs before
+	// the circuit may trip.
+	RequestVolumeThreshold int `json:"request_volume_threshold"`
+	// SleepWindow Is sleepWindowInMilliseconds, how long the circuit stays open, rounded up
+	// to the second.
+	SleepWindow int `json:"sleep_window"`
+	// ErrorPercentThreshold Is errorThresholdPercentage, the failure rate tripping the circuit.
+	ErrorPercentThreshold int `json:"error_percent_threshold"`
+}
+
+// WithHystrix Configures the breaker as a hystrix-go command with cfg would be: a ten second
+// window of ten buckets, tripping once the request volume and error percentage thresholds
+// are both reached, and a single trial call deciding whether the circuit closes. Options
+// given after it override its settings.
+func WithHystrix(cfg HystrixConfig) option {
+	return func(opt *optionsConfiguration) error {
+		if cfg.Timeout < 0 || cfg.MaxConcurrentRequests < 0 || cfg.RequestVolumeThreshold < 0 || cfg.SleepWindow < 0 {
+			return fmt.Errorf("%w: settings can't be less than zero", ErrInvalidHystrix)
+		}
+		if cfg.ErrorPercentThreshold < 0 || cfg.ErrorPercentThreshold > 100 {
+			return fmt.Errorf("%w: error percent threshold must be between 0 and 100", ErrInvalidHystrix)
+		}
+
+		timeout := time.Millisecond * time.Duration(orDefault(cfg.Timeout, _hystrixTimeout))
+		volume := uint64(orDefault(cfg.RequestVolumeThreshold, _hystrixRequestVolumeThreshold))
+		sleepWindow := time.Millisecond * time.Duration(orDefault(cfg.SleepWindow, _hystrixSleepWindow))
+		errorPercent := float64(orDefault(cfg.ErrorPercentThreshold, _hystrixErrorPercentThreshold))
+
+		for _, apply := range []option{
+			WithWindow(_hystrixWindow, _hystrixBuckets),
+			WithOpenDuration(int((sleepWindow + time.Second - 1) / time.Second)),
+			WithHalfOpenEvaluation(1, 1),
+			WithCanTrip(func(summary Counts) bool {
+				return summary.Total >= volume && summary.FailureRate() >= errorPercent
+			}),
+			WithClassifier(func(err error, latency time.Duration) Outcome {
+				if err != nil || latency > timeout {
+					return OutcomeFailure
+				}
+				return OutcomeSuccess
+			}),
+		} {
+			if err := apply(opt); err != nil {
+				return fmt.Errorf("%w: %w", ErrInvalidHystrix, err)
+			}
+		}
+		return nil
+	}
+}
+
+func orDefault(value, fallback int) int {
+	if value == 0 {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,45 @@
+package breaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreakerHystrixDefaults(t *testing.T) {
+	cb, cancel, err := New(WithHystrix(HystrixConfig{}))
+	require.NoError(t, err)
+	defer cancel()
+
+	assert.Equal(t, time.Second*10, cb.cfg.windowRoll)
+	assert.Equal(t, time.Second, cb.cfg.windowFrame)
+	assert.Equal(t, time.Second*5, cb.cfg.openDuration)
+	assert.False(t, cb.canTrip(Counts{Total: 19, Fail: 19}))
+	assert.False(t, cb.canTrip(Counts{Total: 20, Fail: 9, Success: 11}))
+	assert.True(t, cb.canTrip(Counts{Total: 20, Fail: 10, Success: 10}))
+}
+
+func TestBreakerHystrix(t *testing.T) {
+	cb, cancel, err := New(WithHystrix(HystrixConfig{
+		Timeout:                10,
+		RequestVolumeThreshold: 4,
+		SleepWindow:            1500,
+		ErrorPercentThreshold:  50,
+	}))
+	require.NoError(t, err)
+	defer cancel()
+
+	assert.Equal(t, time.Second*2, cb.cfg.openDuration)
+
+	syncFeedCircuitBreakerHelper(cb, []error{nil, nil, errCall}, false)
+	require.Equal(t, Closed, cb.State())
+
+	require.NoError(t, cb.Execute(func() error {
+		time.Sleep(time.Millisecond * 20)
+		return nil
+	}))
+	assert.Equal(t, Counts{Total: 4, Fail: 2, Success: 2}, cb.Counts())
+	assert.Equal(t, Open, cb.State())
+}
@@ -0,0 +1,61 @@
+package breaker
+
+import (
+	"context"
+	"sync"
+)
+
+type inflight struct {
+	calls map[uint64]context.CancelCauseFunc
+	next  uint64
+
+	mu sync.Mutex
+}
+
+func newInflight() *inflight {
+	return &inflight{calls: make(map[uint64]context.CancelCauseFunc)}
+}
+
+// track Derives a cancelable context for a guarded call, done must be called once the
+// call returns.
+func (f *inflight) track(ctx context.Context) (context.Context, func()) {
+	if f == nil {
+		return ctx, func() {}
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+
+	f.mu.Lock()
+	id := f.next
+	f.next++
+	f.calls[id] = cancel
+	f.mu.Unlock()
+
+	return ctx, func() {
+		f.mu.Lock()
+		delete(f.calls, id)
+		f.mu.Unlock()
+		cancel(nil)
+	}
+}
+
+// cancelAll Cancels every call in flight with ErrOpenCircuit as the cause.
+func (f *inflight) cancelAll() {
+	if f == nil {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for id, cancel := range f.calls {
+		cancel(ErrOpenCircuit)
+		delete(f.calls, id)
+	}
+}
+
+// InFlight Returns how many guarded calls are executing right now, rising ahead of the
+// latencies and failure rate as the dependency saturates.
+func (c *CircuitBreaker) InFlight() int64 {
+	return c.executing.Load()
+}
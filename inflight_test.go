@@ -0,0 +1,87 @@
+package breaker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreakerCancelOnTrip(t *testing.T) {
+	tt := []struct {
+		name     string
+		input    []option
+		expected error
+	}{
+		{
+			name:     "keeps_calls_running_by_default",
+			input:    nil,
+			expected: context.DeadlineExceeded,
+		},
+		{
+			name:     "cancels_calls_when_tripped",
+			input:    []option{WithCancelOnTrip()},
+			expected: ErrOpenCircuit,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			cb, cancel, err := New(tc.input...)
+			require.NoError(t, err)
+			defer cancel()
+
+			ctx, cancelCtx := context.WithTimeout(context.Background(), 200*time.Millisecond)
+			defer cancelCtx()
+
+			started := make(chan struct{})
+			cause := make(chan error, 1)
+			go func() {
+				_ = cb.ExecuteContext(ctx, func(ctx context.Context) error {
+					close(started)
+					<-ctx.Done()
+					cause <- context.Cause(ctx)
+					return ctx.Err()
+				})
+			}()
+
+			<-started
+			cb.Trip()
+
+			assert.ErrorIs(t, <-cause, tc.expected)
+		})
+	}
+}
+
+func TestBreakerInFlight(t *testing.T) {
+	cb, cancel, err := New()
+	require.NoError(t, err)
+	defer cancel()
+
+	started, release := make(chan struct{}), make(chan struct{})
+	done := make(chan struct{})
+	for i := 0; i < 2; i++ {
+		go func() {
+			_ = cb.Execute(func() error {
+				started <- struct{}{}
+				<-release
+				return nil
+			})
+			done <- struct{}{}
+		}()
+	}
+	<-started
+	<-started
+
+	assert.Equal(t, int64(2), cb.InFlight())
+	assert.Equal(t, int64(2), cb.Snapshot().InFlight)
+
+	close(release)
+	<-done
+	<-done
+	assert.Equal(t, int64(0), cb.InFlight())
+
+	assert.ErrorIs(t, cb.Execute(fixtureCircuitCall(errCall)), errCall)
+	assert.Equal(t, int64(0), cb.InFlight())
+}
@@ -0,0 +1,47 @@
+package breaker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreakerInterceptors(t *testing.T) {
+	var order []string
+	tracing := func(name string) interceptor {
+		return func(next circuitCall) circuitCall {
+			return func() error {
+				order = append(order, name+":before")
+				err := next()
+				order = append(order, name+":after")
+				return err
+			}
+		}
+	}
+	failing := func(next circuitCall) circuitCall {
+		return func() error {
+			if err := next(); err != nil {
+				return err
+			}
+			return errCall
+		}
+	}
+
+	cb, cancel, err := New(
+		WithInterceptor(tracing("outer")),
+		WithInterceptor(tracing("inner")),
+		WithInterceptor(failing),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	err = cb.Execute(func() error {
+		order = append(order, "call")
+		return nil
+	})
+
+	assert.ErrorIs(t, err, errCall)
+	assert.Equal(t, []string{"outer:before", "inner:before", "call", "inner:after", "outer:after"}, order)
+	assert.Equal(t, Counts{Total: 1, Fail: 1}, cb.summaryCopy())
+}
@@ -0,0 +1,68 @@
+package breaker
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// jitter Spreads the frame rotation and open duration timers by up to fraction of their
+// duration either way, so the breakers of a fleet created together don't rotate nor probe
+// in lockstep.
+type jitter struct {
+	fraction float64
+	random   func() float64
+}
+
+func newJitter(fraction float64, random func() float64) *jitter {
+	if fraction == 0 {
+		return nil
+	}
+	return &jitter{fraction: fraction, random: random}
+}
+
+// apply Returns d moved by a random offset within fraction of d.
+func (j *jitter) apply(d time.Duration) time.Duration {
+	if j == nil {
+		return d
+	}
+
+	offset := (j.random()*2 - 1) * j.fraction
+	return d + time.Duration(offset*float64(d))
+}
+
+// stretch Returns the longest d can get once applied.
+func (j *jitter) stretch(d time.Duration) time.Duration {
+	if j == nil {
+		return d
+	}
+	return d + time.Duration(j.fraction*float64(d))
+}
+
+// lockedSource Serializes a rand.Source, which isn't safe for concurrent use, so the breakers
+// built with the same WithRandSource option, such as those derived with With, can share it.
+type lockedSource struct {
+	src rand.Source
+
+	mu sync.Mutex
+}
+
+func (s *lockedSource) Int63() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Int63()
+}
+
+func (s *lockedSource) Seed(seed int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.src.Seed(seed)
+}
+
+// newRandom Returns a draw in [0, 1) from source, from the global math/rand source when nil.
+func newRandom(source rand.Source) func() float64 {
+	if source == nil {
+		return rand.Float64
+	}
+	return rand.New(source).Float64
+}
@@ -0,0 +1,87 @@
+package breaker
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJitterApply(t *testing.T) {
+	a := newJitter(0.2, newRandom(rand.NewSource(42)))
+	b := newJitter(0.2, newRandom(rand.NewSource(42)))
+
+	spread := false
+	for i := 0; i < 100; i++ {
+		d := a.apply(time.Second)
+		assert.Equal(t, d, b.apply(time.Second))
+		assert.InDelta(t, time.Second, d, float64(time.Millisecond*200))
+		spread = spread || d != time.Second
+	}
+	assert.True(t, spread)
+
+	var none *jitter
+	assert.Nil(t, newJitter(0, rand.Float64))
+	assert.Equal(t, time.Second, none.apply(time.Second))
+
+	assert.Equal(t, time.Millisecond*1200, a.stretch(time.Second))
+	assert.Equal(t, time.Second, none.stretch(time.Second))
+}
+
+func TestBreakerJitterOpenDuration(t *testing.T) {
+	cb, cancel, err := New(
+		WithOpenDuration(10),
+		WithJitter(0.5),
+		WithRandSource(rand.NewSource(1)),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	expected := newJitter(0.5, newRandom(rand.NewSource(1)))
+	expected.apply(cb.cfg.windowFrame)
+
+	cb.Trip()
+	assert.InDelta(t, expected.apply(cb.cfg.openDuration), cb.TimeUntilHalfOpen(), float64(time.Millisecond*50))
+}
+
+func TestBreakerRandSourceShared(t *testing.T) {
+	parent, cancel, err := New(WithJitter(0.5), WithRandSource(rand.NewSource(1)))
+	require.NoError(t, err)
+	defer cancel()
+
+	derived, stop, err := parent.With()
+	require.NoError(t, err)
+	defer stop()
+
+	var wg sync.WaitGroup
+	for _, cb := range []*CircuitBreaker{parent, derived} {
+		wg.Add(1)
+		go func(cb *CircuitBreaker) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				cb.random()
+			}
+		}(cb)
+	}
+	wg.Wait()
+}
+
+func TestBreakerRandSourceChaos(t *testing.T) {
+	failures := func() []bool {
+		cb, cancel, err := New(WithChaos(50, 0), WithRandSource(rand.NewSource(7)))
+		require.NoError(t, err)
+		defer cancel()
+		cb.EnableChaos()
+
+		failed := make([]bool, 20)
+		for i := range failed {
+			failed[i] = cb.Execute(fixtureCircuitCall(nil)) != nil
+		}
+		return failed
+	}
+
+	assert.Equal(t, failures(), failures())
+}
@@ -0,0 +1,86 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrJobSkipped = errors.New("job skipped")
+
+const (
+	// _requeueJitter Spreads the requeued jobs over this fraction of their backoff, so they
+	// don't all come back the moment the circuit half-opens.
+	_requeueJitter = 0.2
+	_halfOpenPoll  = time.Millisecond * 10
+)
+
+// JobPolicy Tells a guarded job what to do while the circuit of its dependency is open.
+type JobPolicy struct {
+	// MaxDelay Holds the job until the circuit half-opens when it does within MaxDelay,
+	// instead of giving it up.
+	MaxDelay time.Duration
+	// Requeue Hands the job back to the processor to run again after the backoff, the time
+	// left until the circuit half-opens with some jitter. The job returns its error, nil
+	// once requeued. When nil the job fails with ErrJobSkipped instead.
+	Requeue func(ctx context.Context, backoff time.Duration) error
+}
+
+// Guard Wraps a background job so it runs through the breaker, with jobs hitting an open
+// circuit delayed, requeued or skipped as policy says rather than burning their retries
+// during a known outage. A skipped job error wraps both ErrJobSkipped and ErrOpenCircuit.
+func (c *CircuitBreaker) Guard(job func(ctx context.Context) error, policy JobPolicy) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		if err := c.awaitHalfOpen(ctx, policy.MaxDelay); err != nil {
+			return err
+		}
+
+		err := c.ExecuteContext(ctx, job)
+		if !errors.Is(err, ErrOpenCircuit) {
+			return err
+		}
+
+		if policy.Requeue == nil {
+			return fmt.Errorf("%w: %w", ErrJobSkipped, err)
+		}
+		return policy.Requeue(ctx, c.requeueBackoff())
+	}
+}
+
+// awaitHalfOpen Waits for an open circuit to half-open when it does within maxDelay, polling
+// past the open duration as the timer moving it may not have fired yet.
+func (c *CircuitBreaker) awaitHalfOpen(ctx context.Context, maxDelay time.Duration) error {
+	deadline := c.clock.Now().Add(maxDelay)
+	for c.load().state == Open {
+		wait := max(c.TimeUntilHalfOpen(), _halfOpenPoll)
+		if c.clock.Now().Add(wait).After(deadline) {
+			return nil
+		}
+		if err := c.sleep(ctx, wait); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// requeueBackoff Returns the time left until the circuit half-opens, at least a second,
+// plus some jitter.
+func (c *CircuitBreaker) requeueBackoff() time.Duration {
+	backoff := max(c.TimeUntilHalfOpen(), time.Second)
+	return backoff + time.Duration(c.random()*_requeueJitter*float64(backoff))
+}
+
+// sleep Waits d on the breaker clock unless ctx is done first.
+func (c *CircuitBreaker) sleep(ctx context.Context, d time.Duration) error {
+	woken := make(chan struct{})
+	timer := c.clock.AfterFunc(d, func() { close(woken) })
+	defer timer.Stop()
+
+	select {
+	case <-woken:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,56 @@
+package breaker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreakerGuard(t *testing.T) {
+	cb, cancel, err := New(WithOpenDuration(1), WithHalfOpenEvaluation(1, 1))
+	require.NoError(t, err)
+	defer cancel()
+
+	runs := 0
+	job := func(ctx context.Context) error {
+		runs++
+		return nil
+	}
+
+	require.NoError(t, cb.Guard(job, JobPolicy{})(context.Background()))
+	cb.Trip()
+
+	err = cb.Guard(job, JobPolicy{})(context.Background())
+	assert.ErrorIs(t, err, ErrJobSkipped)
+	assert.ErrorIs(t, err, ErrOpenCircuit)
+
+	var backoff time.Duration
+	err = cb.Guard(job, JobPolicy{Requeue: func(ctx context.Context, after time.Duration) error {
+		backoff = after
+		return nil
+	}})(context.Background())
+	require.NoError(t, err)
+	assert.Greater(t, backoff, time.Millisecond*500)
+	assert.LessOrEqual(t, backoff, time.Millisecond*1250)
+	assert.Equal(t, 1, runs)
+
+	require.NoError(t, cb.Guard(job, JobPolicy{MaxDelay: time.Second * 2})(context.Background()))
+	assert.Equal(t, 2, runs)
+	assert.Equal(t, Closed, cb.State())
+}
+
+func TestBreakerGuardDelayCancelled(t *testing.T) {
+	cb, cancel, err := New(WithOpenDuration(30))
+	require.NoError(t, err)
+	defer cancel()
+	cb.Trip()
+
+	ctx, stop := context.WithTimeout(context.Background(), time.Millisecond*10)
+	defer stop()
+
+	err = cb.Guard(func(ctx context.Context) error { return nil }, JobPolicy{MaxDelay: time.Minute})(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,105 @@
+package breaker
+
+import "context"
+
+// LabelCounts Holds the counts per label name and value.
+type LabelCounts map[string]map[string]Counts
+
+type callOptions struct {
+	priority Priority
+	labels   map[string]string
+	report   *Report
+	recorder *Recorder
+	weight   uint64
+}
+
+func (c *CircuitBreaker) ExecuteLabeled(labels map[string]string, fn circuitCall) error {
+	return c.execute(context.Background(), callOptions{priority: Normal, labels: labels}, func(context.Context) error {
+		return fn()
+	})
+}
+
+// LabelCounts Returns the per label counts aggregated over the rolling window.
+func (c *CircuitBreaker) LabelCounts() LabelCounts {
+	c.rollingWindow.mu.RLock()
+	defer c.rollingWindow.mu.RUnlock()
+
+	aggregated := LabelCounts{}
+	for _, f := range c.rollingWindow.window {
+		aggregated.merge(f.labels)
+	}
+
+	return aggregated
+}
+
+func (l LabelCounts) add(labels map[string]string, delta Counts) {
+	for name, value := range labels {
+		if l[name] == nil {
+			l[name] = make(map[string]Counts)
+		}
+		l[name][value] = addCounts(l[name][value], delta)
+	}
+}
+
+func (l LabelCounts) merge(other LabelCounts) {
+	for name, values := range other {
+		if l[name] == nil {
+			l[name] = make(map[string]Counts, len(values))
+		}
+		for value, counts := range values {
+			l[name][value] = addCounts(l[name][value], counts)
+		}
+	}
+}
+
+func (l LabelCounts) copy() LabelCounts {
+	if l == nil {
+		return nil
+	}
+
+	cl := make(LabelCounts, len(l))
+	cl.merge(l)
+	return cl
+}
+
+func (f *frame) addLabels(labels map[string]string, delta Counts) {
+	if len(labels) == 0 {
+		return
+	}
+
+	if f.labels == nil {
+		f.labels = LabelCounts{}
+	}
+	f.labels.add(labels, delta)
+}
+
+func (f *frame) mergeLabels(labels LabelCounts) {
+	if len(labels) == 0 {
+		return
+	}
+
+	if f.labels == nil {
+		f.labels = LabelCounts{}
+	}
+	f.labels.merge(labels)
+}
+
+func addCounts(a, b Counts) Counts {
+	return Counts{
+		Total:   a.Total + b.Total,
+		Fail:    a.Fail + b.Fail,
+		Success: a.Success + b.Success,
+		Slow:    a.Slow + b.Slow,
+		Ignored: a.Ignored + b.Ignored,
+	}
+}
+
+func subCounts(a, b Counts) Counts {
+	return Counts{
+		Total:   a.Total - b.Total,
+		Fail:    a.Fail - b.Fail,
+		Success: a.Success - b.Success,
+		Slow:    a.Slow - b.Slow,
+		Ignored: a.Ignored - b.Ignored,
+	}
+}
@@ -0,0 +1,44 @@
+package breaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreakerExecuteLabeled(t *testing.T) {
+	cb, cancel, err := New(
+		WithWindowFrameThreshold(1),
+		WithWindowRollThreshold(2),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	payments := map[string]string{"endpoint": "payments", "tenant": "acme"}
+	orders := map[string]string{"endpoint": "orders", "tenant": "acme"}
+
+	require.ErrorIs(t, cb.ExecuteLabeled(payments, fixtureCircuitCall(errCall)), errCall)
+	require.NoError(t, cb.ExecuteLabeled(orders, fixtureCircuitCall(nil)))
+	time.Sleep(cb.cfg.windowFrame + (time.Millisecond * 500))
+	require.NoError(t, cb.ExecuteLabeled(payments, fixtureCircuitCall(nil)))
+	require.NoError(t, cb.Execute(fixtureCircuitCall(nil)))
+
+	assert.Equal(t, LabelCounts{
+		"endpoint": {
+			"payments": {Total: 2, Fail: 1, Success: 1},
+			"orders":   {Total: 1, Success: 1},
+		},
+		"tenant": {
+			"acme": {Total: 3, Fail: 1, Success: 2},
+		},
+	}, cb.LabelCounts())
+	assert.Equal(t, Counts{Total: 4, Fail: 1, Success: 3}, cb.summaryCopy())
+
+	snapshot := cb.WindowSnapshot()
+	assert.Equal(t, LabelCounts{
+		"endpoint": {"payments": {Total: 1, Success: 1}},
+		"tenant":   {"acme": {Total: 1, Success: 1}},
+	}, snapshot[len(snapshot)-1].Labels)
+}
@@ -0,0 +1,82 @@
+package breaker
+
+import (
+	"context"
+	"math"
+	"slices"
+	"sync/atomic"
+	"time"
+)
+
+// samples Keeps the most recent latencies without locking, as every call records one. A copy
+// racing with pushes may mix in a newer sample, which is fine for statistics.
+type samples struct {
+	items []atomic.Int64
+	next  atomic.Uint64
+}
+
+func newSamples(size int) *samples {
+	return &samples{items: make([]atomic.Int64, size)}
+}
+
+func (s *samples) push(latency time.Duration) {
+	i := s.next.Add(1) - 1
+	s.items[i%uint64(len(s.items))].Store(int64(latency))
+}
+
+func (s *samples) copy() []time.Duration {
+	n := min(s.next.Load(), uint64(len(s.items)))
+	copied := make([]time.Duration, n)
+	for i := range copied {
+		copied[i] = time.Duration(s.items[i].Load())
+	}
+	return copied
+}
+
+// latencyPercentile Returns the given percentile of the recently observed call latencies.
+func (c *CircuitBreaker) latencyPercentile(percentile float64) (time.Duration, bool) {
+	samples := c.latencies.copy()
+	if len(samples) == 0 {
+		return 0, false
+	}
+
+	slices.Sort(samples)
+	i := int(math.Ceil((percentile/100)*float64(len(samples)))) - 1
+	return samples[max(i, 0)], true
+}
+
+// latencyMean Returns the mean of the recently observed call latencies.
+func (c *CircuitBreaker) latencyMean() time.Duration {
+	samples := c.latencies.copy()
+	if len(samples) == 0 {
+		return 0
+	}
+
+	var total time.Duration
+	for _, sample := range samples {
+		total += sample
+	}
+	return total / time.Duration(len(samples))
+}
+
+func (c *CircuitBreaker) canMeetDeadline(ctx context.Context) error {
+	if c.cfg.deadlinePercentile == 0 {
+		return nil
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return nil
+	}
+
+	latency, ok := c.latencyPercentile(c.cfg.deadlinePercentile)
+	if !ok {
+		return nil
+	}
+
+	if deadline.Sub(c.clock.Now()) < latency {
+		return ErrInsufficientDeadline
+	}
+
+	return nil
+}
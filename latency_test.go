@@ -0,0 +1,81 @@
+package breaker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreakerLatencyPercentile(t *testing.T) {
+	cb, cancel, err := New(WithDeadlineRejection(50))
+	require.NoError(t, err)
+	cancel()
+
+	_, ok := cb.latencyPercentile(50)
+	assert.False(t, ok)
+
+	for i := 1; i <= 10; i++ {
+		cb.latencies.push(time.Duration(i) * time.Millisecond)
+	}
+
+	tt := []struct {
+		percentile float64
+		expected   time.Duration
+	}{
+		{percentile: 1, expected: time.Millisecond},
+		{percentile: 50, expected: time.Millisecond * 5},
+		{percentile: 95, expected: time.Millisecond * 10},
+		{percentile: 100, expected: time.Millisecond * 10},
+	}
+	for _, tc := range tt {
+		got, ok := cb.latencyPercentile(tc.percentile)
+		assert.True(t, ok)
+		assert.Equal(t, tc.expected, got)
+	}
+}
+
+func TestBreakerDeadlineRejection(t *testing.T) {
+	cb, cancel, err := New(WithDeadlineRejection(95))
+	require.NoError(t, err)
+	defer cancel()
+
+	slowCall := func(ctx context.Context) error {
+		time.Sleep(time.Millisecond * 50)
+		return nil
+	}
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, cb.ExecuteContext(context.Background(), slowCall))
+	}
+
+	ctx, ctxCancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+	defer ctxCancel()
+
+	err = cb.ExecuteContext(ctx, slowCall)
+	assert.ErrorIs(t, err, ErrInsufficientDeadline)
+	assert.Equal(t, Counts{Total: 3, Success: 3}, cb.summaryCopy())
+
+	ctx, ctxCancel = context.WithTimeout(context.Background(), time.Second)
+	defer ctxCancel()
+
+	err = cb.ExecuteContext(ctx, slowCall)
+	assert.NoError(t, err)
+	assert.NoError(t, cb.Execute(func() error { return nil }))
+	assert.Equal(t, Counts{Total: 5, Success: 5}, cb.summaryCopy())
+}
+
+func TestSamples(t *testing.T) {
+	s := newSamples(3)
+	assert.Empty(t, s.copy())
+
+	s.push(time.Millisecond)
+	s.push(2 * time.Millisecond)
+	assert.Equal(t, []time.Duration{time.Millisecond, 2 * time.Millisecond}, s.copy())
+
+	s.push(3 * time.Millisecond)
+	s.push(4 * time.Millisecond)
+	assert.ElementsMatch(t, []time.Duration{2 * time.Millisecond, 3 * time.Millisecond, 4 * time.Millisecond}, s.copy())
+}
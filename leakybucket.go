@@ -0,0 +1,62 @@
+package breaker
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// LeakyBucket Is a WindowStrategy adding every failure to a bucket draining a failure every
+// period, it keeps constant memory and reports the bucket level as failed calls.
+type LeakyBucket struct {
+	drainEvery time.Duration
+	level      float64
+	last       time.Time
+
+	mu sync.Mutex
+}
+
+func NewLeakyBucket(drainEvery time.Duration) *LeakyBucket {
+	return &LeakyBucket{drainEvery: drainEvery}
+}
+
+// drain Leaks what drained since the last access, the lock must be held.
+func (b *LeakyBucket) drain(at time.Time) {
+	if !b.last.IsZero() && at.After(b.last) {
+		b.level = max(0, b.level-(float64(at.Sub(b.last))/float64(b.drainEvery)))
+	}
+	if at.After(b.last) {
+		b.last = at
+	}
+}
+
+func (b *LeakyBucket) Record(outcome Outcome, at time.Time, weight uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.drain(at)
+	if outcome == OutcomeFailure {
+		b.level += float64(weight)
+	}
+}
+
+func (b *LeakyBucket) Counts(at time.Time) Counts {
+	level := uint64(math.Ceil(b.Level(at)))
+	return Counts{Total: level, Fail: level}
+}
+
+func (b *LeakyBucket) Level(at time.Time) float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.drain(at)
+	return b.level
+}
+
+func (b *LeakyBucket) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.level = 0
+	b.last = time.Time{}
+}
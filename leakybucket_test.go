@@ -0,0 +1,46 @@
+package breaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeakyBucket(t *testing.T) {
+	start := time.Now()
+	bucket := NewLeakyBucket(time.Second)
+
+	bucket.Record(OutcomeFailure, start, 1)
+	bucket.Record(OutcomeFailure, start, 1)
+	bucket.Record(OutcomeSuccess, start, 1)
+	bucket.Record(OutcomeFailure, start, 1)
+	assert.Equal(t, float64(3), bucket.Level(start))
+	assert.Equal(t, Counts{Total: 3, Fail: 3}, bucket.Counts(start))
+
+	assert.Equal(t, 1.5, bucket.Level(start.Add(1500*time.Millisecond)))
+	assert.Equal(t, Counts{Total: 2, Fail: 2}, bucket.Counts(start.Add(1500*time.Millisecond)))
+	assert.Equal(t, float64(0), bucket.Level(start.Add(10*time.Second)))
+
+	bucket.Record(OutcomeFailure, start.Add(10*time.Second), 1)
+	bucket.Reset()
+	assert.Equal(t, float64(0), bucket.Level(start.Add(10*time.Second)))
+
+	bucket.Record(OutcomeFailure, start.Add(10*time.Second), 4)
+	assert.Equal(t, float64(4), bucket.Level(start.Add(10*time.Second)))
+}
+
+func TestBreakerLeakyBucket(t *testing.T) {
+	cb, cancel, err := New(WithLeakyBucket(2, 200*time.Millisecond))
+	require.NoError(t, err)
+	defer cancel()
+
+	syncFeedCircuitBreakerHelper(cb, []error{errCall, errCall}, false)
+	time.Sleep(300 * time.Millisecond)
+	syncFeedCircuitBreakerHelper(cb, []error{errCall, nil}, false)
+	assert.Equal(t, Closed, cb.State())
+
+	syncFeedCircuitBreakerHelper(cb, []error{errCall, errCall}, false)
+	assert.Equal(t, Open, cb.State())
+}
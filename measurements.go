@@ -0,0 +1,92 @@
+package breaker
+
+import (
+	"context"
+	"sync"
+)
+
+// Measurements Holds the sums of the numeric measurements recorded by the calls, by name.
+type Measurements map[string]float64
+
+// Recorder Collects the measurements of a single call, such as bytes transferred or items
+// processed, it is safe to use from the goroutines the call spawns.
+type Recorder struct {
+	measurements Measurements
+
+	mu sync.Mutex
+}
+
+// Record Adds value to the measurement name of the call.
+func (r *Recorder) Record(name string, value float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.measurements == nil {
+		r.measurements = Measurements{}
+	}
+	r.measurements[name] += value
+}
+
+func (r *Recorder) copy() Measurements {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.measurements.copy()
+}
+
+// ExecuteMeasured Runs fn like Execute handing it a recorder, the measurements it records
+// are aggregated with the call outcome in the current frame, the half-open one while the
+// circuit is half-open, before the trip predicate is evaluated.
+func (c *CircuitBreaker) ExecuteMeasured(fn func(r *Recorder) error) error {
+	r := &Recorder{}
+	return c.execute(context.Background(), callOptions{priority: Normal, recorder: r}, func(context.Context) error {
+		return fn(r)
+	})
+}
+
+// Measurements Returns the measurements aggregated over the rolling window.
+func (c *CircuitBreaker) Measurements() Measurements {
+	c.rollingWindow.mu.RLock()
+	defer c.rollingWindow.mu.RUnlock()
+
+	aggregated := Measurements{}
+	for _, f := range c.rollingWindow.window {
+		aggregated.merge(f.measurements)
+	}
+
+	return aggregated
+}
+
+// measurements Returns what the call recorded, <nil> when it had no recorder.
+func (o callOptions) measurements() Measurements {
+	if o.recorder == nil {
+		return nil
+	}
+	return o.recorder.copy()
+}
+
+func (m Measurements) merge(other Measurements) {
+	for name, value := range other {
+		m[name] += value
+	}
+}
+
+func (m Measurements) copy() Measurements {
+	if m == nil {
+		return nil
+	}
+
+	cm := make(Measurements, len(m))
+	cm.merge(m)
+	return cm
+}
+
+func (f *frame) mergeMeasurements(m Measurements) {
+	if len(m) == 0 {
+		return
+	}
+
+	if f.measurements == nil {
+		f.measurements = Measurements{}
+	}
+	f.measurements.merge(m)
+}
@@ -0,0 +1,81 @@
+package breaker
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreakerExecuteMeasured(t *testing.T) {
+	cb, cancel, err := New(
+		WithMeasuredCanTrip(func(summary Counts, measurements Measurements) bool {
+			return summary.Total >= 3 && measurements["items"]/float64(summary.Total) < 10
+		}),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	assert.NoError(t, cb.ExecuteMeasured(func(r *Recorder) error {
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				r.Record("bytes", 256)
+			}()
+		}
+		wg.Wait()
+		r.Record("items", 20)
+		return nil
+	}))
+	assert.ErrorIs(t, cb.ExecuteMeasured(func(r *Recorder) error {
+		r.Record("items", 8)
+		return errCall
+	}), errCall)
+
+	assert.Equal(t, Measurements{"bytes": 1024, "items": 28}, cb.Measurements())
+	assert.Equal(t, Measurements{"bytes": 1024, "items": 28}, cb.Snapshot().Measurements)
+	window := cb.WindowSnapshot()
+	assert.Equal(t, Measurements{"bytes": 1024, "items": 28}, window[len(window)-1].Measurements)
+	assert.Equal(t, Closed, cb.State())
+
+	assert.NoError(t, cb.ExecuteMeasured(func(r *Recorder) error {
+		r.Record("items", 2)
+		return nil
+	}))
+	assert.Equal(t, Closed, cb.State())
+
+	assert.NoError(t, cb.ExecuteMeasured(func(r *Recorder) error { return nil }))
+	assert.Equal(t, Open, cb.State())
+}
+
+func TestBreakerExecuteMeasuredHalfOpen(t *testing.T) {
+	cb, cancel, err := New(
+		WithMeasuredCanTrip(func(summary Counts, measurements Measurements) bool {
+			return measurements["items"] >= 100
+		}),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	cb.Trip()
+	cb.ProbeNow()
+	require.Equal(t, HalfOpen, cb.State())
+
+	assert.ErrorIs(t, cb.ExecuteMeasured(func(r *Recorder) error {
+		r.Record("items", 100)
+		return errCall
+	}), errCall)
+	require.Equal(t, Open, cb.State())
+	assert.Empty(t, cb.Measurements())
+
+	cb.Reset()
+	assert.NoError(t, cb.ExecuteMeasured(func(r *Recorder) error {
+		r.Record("items", 1)
+		return nil
+	}))
+	assert.Equal(t, Measurements{"items": 1}, cb.Measurements())
+	assert.Equal(t, Closed, cb.State())
+}
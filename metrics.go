@@ -0,0 +1,96 @@
+package breaker
+
+import "time"
+
+// MetricsSink Receives the breaker metrics, so any metrics system can be plugged in through
+// a small adapter. It is called on the calling goroutine, and on transitions while the state
+// lock is held, so it must not block. Besides the ones each metric lists, the labels carry
+// those given with WithLabels, and must not be modified nor retained.
+type MetricsSink interface {
+	IncCounter(name string, labels map[string]string)
+	SetGauge(name string, value float64, labels map[string]string)
+	ObserveDuration(name string, duration time.Duration, labels map[string]string)
+}
+
+const (
+	// MetricCalls Counts the admitted calls labeled by breaker and outcome.
+	MetricCalls = "breaker_calls_total"
+	// MetricCallDuration Observes how long the admitted calls took labeled by breaker and outcome.
+	MetricCallDuration = "breaker_call_duration"
+	// MetricRejections Counts the calls rejected without running labeled by breaker and state.
+	MetricRejections = "breaker_rejections_total"
+	// MetricInFlight Gauges the admitted calls yet to return labeled by breaker.
+	MetricInFlight = "breaker_in_flight"
+	// MetricTransitions Counts the state changes labeled by breaker, from and to.
+	MetricTransitions = "breaker_transitions_total"
+	// MetricState Gauges the state labeled by breaker, 0 when closed, 1 when half-open and
+	// 2 when open.
+	MetricState = "breaker_state"
+)
+
+var stateGauge = map[State]float64{Closed: 0, HalfOpen: 1, Open: 2}
+
+// metrics Reports to the sink as an observer, the label sets are built once so the calls
+// don't allocate them. Every set carries the labels of the breaker, the metric ones taking
+// precedence over them.
+type metrics struct {
+	sink     MetricsSink
+	cb       *CircuitBreaker
+	breaker  map[string]string
+	outcomes map[Outcome]map[string]string
+	states   map[State]map[string]string
+}
+
+func newMetrics(sink MetricsSink, cb *CircuitBreaker) *metrics {
+	m := &metrics{
+		sink:     sink,
+		cb:       cb,
+		breaker:  metricLabels(cb),
+		outcomes: make(map[Outcome]map[string]string),
+		states:   make(map[State]map[string]string),
+	}
+	for _, outcome := range []Outcome{OutcomeSuccess, OutcomeFailure, OutcomeSlow, OutcomeIgnored} {
+		m.outcomes[outcome] = metricLabels(cb, "outcome", string(outcome))
+	}
+	for state := range stateGauge {
+		m.states[state] = metricLabels(cb, "state", string(state))
+	}
+	return m
+}
+
+// metricLabels Returns the labels of cb along the breaker name and the given key value pairs.
+func metricLabels(cb *CircuitBreaker, pairs ...string) map[string]string {
+	labels := make(map[string]string, len(cb.tags)+1+len(pairs)/2)
+	for key, value := range cb.tags {
+		labels[key] = value
+	}
+	labels["breaker"] = cb.name
+	for i := 0; i+1 < len(pairs); i += 2 {
+		labels[pairs[i]] = pairs[i+1]
+	}
+	return labels
+}
+
+func (m *metrics) OnCallStart() {
+	m.sink.SetGauge(MetricInFlight, float64(m.cb.executing.Load()), m.breaker)
+}
+
+func (m *metrics) OnCallEnd(outcome Outcome, duration time.Duration) {
+	labels, ok := m.outcomes[outcome]
+	if !ok {
+		labels = metricLabels(m.cb, "outcome", string(outcome))
+	}
+	m.sink.IncCounter(MetricCalls, labels)
+	m.sink.ObserveDuration(MetricCallDuration, duration, labels)
+	m.sink.SetGauge(MetricInFlight, float64(m.cb.executing.Load()-1), m.breaker)
+}
+
+func (m *metrics) OnRejected(state State) {
+	m.sink.IncCounter(MetricRejections, m.states[state])
+}
+
+// transition Reports a state change, the state lock must be held.
+func (m *metrics) transition(from, to State) {
+	m.sink.IncCounter(MetricTransitions, metricLabels(m.cb, "from", string(from), "to", string(to)))
+	m.sink.SetGauge(MetricState, stateGauge[to], m.breaker)
+}
@@ -0,0 +1,92 @@
+package breaker
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingSink struct {
+	counters map[string]int
+	gauges   map[string]float64
+	observed map[string]int
+	mu       sync.Mutex
+}
+
+func newRecordingSink() *recordingSink {
+	return &recordingSink{counters: map[string]int{}, gauges: map[string]float64{}, observed: map[string]int{}}
+}
+
+func (r *recordingSink) key(name string, labels map[string]string) string {
+	return fmt.Sprintf("%s %v", name, labels)
+}
+
+func (r *recordingSink) IncCounter(name string, labels map[string]string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.counters[r.key(name, labels)]++
+}
+
+func (r *recordingSink) SetGauge(name string, value float64, labels map[string]string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.gauges[r.key(name, labels)] = value
+}
+
+func (r *recordingSink) ObserveDuration(name string, duration time.Duration, labels map[string]string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.observed[r.key(name, labels)]++
+}
+
+func TestBreakerMetricsSink(t *testing.T) {
+	sink := newRecordingSink()
+	cb, cancel, err := New(
+		WithName("payments"),
+		WithMetricsSink(sink),
+		WithCanTrip(func(summary Counts) bool { return summary.Fail > 1 }),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	assert.Equal(t, float64(0), sink.gauges["breaker_state map[breaker:payments]"])
+
+	syncFeedCircuitBreakerHelper(cb, []error{nil, errCall, errCall, nil}, false)
+
+	assert.Equal(t, map[string]int{
+		"breaker_calls_total map[breaker:payments outcome:success]":           1,
+		"breaker_calls_total map[breaker:payments outcome:failure]":           2,
+		"breaker_transitions_total map[breaker:payments from:closed to:open]": 1,
+		"breaker_rejections_total map[breaker:payments state:open]":           1,
+	}, sink.counters)
+	assert.Equal(t, map[string]int{
+		"breaker_call_duration map[breaker:payments outcome:success]": 1,
+		"breaker_call_duration map[breaker:payments outcome:failure]": 2,
+	}, sink.observed)
+	assert.Equal(t, float64(2), sink.gauges["breaker_state map[breaker:payments]"])
+	assert.Equal(t, float64(0), sink.gauges["breaker_in_flight map[breaker:payments]"])
+}
+
+func TestBreakerMetricsSinkLabels(t *testing.T) {
+	sink := newRecordingSink()
+	cb, cancel, err := New(
+		WithName("payments"),
+		WithLabels(map[string]string{"team": "billing", "breaker": "ignored"}),
+		WithMetricsSink(sink),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	syncFeedCircuitBreakerHelper(cb, []error{nil}, false)
+	cb.Trip()
+
+	assert.Equal(t, map[string]int{
+		"breaker_calls_total map[breaker:payments outcome:success team:billing]":           1,
+		"breaker_transitions_total map[breaker:payments from:closed team:billing to:open]": 1,
+	}, sink.counters)
+	assert.Equal(t, float64(2), sink.gauges["breaker_state map[breaker:payments team:billing]"])
+}
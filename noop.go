@@ -0,0 +1,31 @@
+package breaker
+
+import "context"
+
+// NoopBreaker Is a Breaker that always runs the call and never trips, for when breaking
+// is turned off or not wanted in tests.
+type NoopBreaker struct {
+	name string
+}
+
+var _ Breaker = NoopBreaker{}
+
+func NewNoopBreaker(name string) NoopBreaker {
+	return NoopBreaker{name: name}
+}
+
+func (n NoopBreaker) Name() string {
+	return n.name
+}
+
+func (n NoopBreaker) State() State {
+	return Closed
+}
+
+func (n NoopBreaker) Execute(fn func() error) error {
+	return fn()
+}
+
+func (n NoopBreaker) ExecuteContext(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
@@ -0,0 +1,29 @@
+package breaker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNoopBreaker(t *testing.T) {
+	var cb Breaker = NewNoopBreaker("payments")
+
+	for i := 0; i < 100; i++ {
+		assert.ErrorIs(t, cb.Execute(fixtureCircuitCall(errCall)), errCall)
+	}
+	assert.Equal(t, Closed, cb.State())
+	assert.Equal(t, "payments", cb.Name())
+	assert.NoError(t, cb.Execute(fixtureCircuitCall(nil)))
+
+	ctx := context.WithValue(context.Background(), struct{}{}, "value")
+	assert.NoError(t, cb.ExecuteContext(ctx, func(got context.Context) error {
+		assert.Equal(t, ctx, got)
+		return nil
+	}))
+
+	value, err := Execute(cb, func() (int, error) { return 42, nil })
+	assert.NoError(t, err)
+	assert.Equal(t, 42, value)
+}
@@ -0,0 +1,47 @@
+package breaker
+
+import (
+	"context"
+	"runtime/trace"
+	"time"
+)
+
+// Observer Is notified around the lifecycle of the calls, giving telemetry a single place to
+// hook into. Callbacks run on the calling goroutine and must not block.
+type Observer interface {
+	// OnCallStart Is called once a call is admitted, right before it runs.
+	OnCallStart()
+	// OnCallEnd Is called once an admitted call returns with how it was counted.
+	OnCallEnd(outcome Outcome, duration time.Duration)
+	// OnRejected Is called for every call rejected without running, with the breaker state.
+	OnRejected(state State)
+}
+
+func (c *CircuitBreaker) observeStart() {
+	for _, o := range c.observers {
+		o.OnCallStart()
+	}
+}
+
+func (c *CircuitBreaker) observeEnd(outcome Outcome, duration time.Duration) {
+	for _, o := range c.observers {
+		o.OnCallEnd(outcome, duration)
+	}
+}
+
+// rejected Notifies the observers and the trace of the rejection err, handing it back.
+func (c *CircuitBreaker) rejected(ctx context.Context, err error) error {
+	if c.trace {
+		trace.Log(ctx, "rejected", err.Error())
+	}
+	if len(c.observers) == 0 {
+		return err
+	}
+
+	state := c.load().state
+	for _, o := range c.observers {
+		o.OnRejected(state)
+	}
+
+	return err
+}
@@ -0,0 +1,60 @@
+package breaker
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingObserver struct {
+	calls []string
+	mu    sync.Mutex
+}
+
+func (r *recordingObserver) record(call string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, call)
+}
+
+func (r *recordingObserver) OnCallStart() {
+	r.record("start")
+}
+
+func (r *recordingObserver) OnCallEnd(outcome Outcome, duration time.Duration) {
+	r.record(fmt.Sprintf("end:%s", outcome))
+}
+
+func (r *recordingObserver) OnRejected(state State) {
+	r.record(fmt.Sprintf("rejected:%s", state))
+}
+
+func TestBreakerObserver(t *testing.T) {
+	first, second := &recordingObserver{}, &recordingObserver{}
+	cb, cancel, err := New(
+		WithObserver(first),
+		WithObserver(second),
+		WithCanTrip(func(summary Counts) bool { return summary.Fail > 0 }),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	syncFeedCircuitBreakerHelper(cb, []error{nil, errCall, nil}, false)
+	assert.Panics(t, func() {
+		cb.Pause()
+		defer cb.Resume()
+		_ = cb.Execute(func() error { panic("unobserved while paused") })
+	})
+	cb.Reset()
+	assert.Panics(t, func() {
+		_ = cb.Execute(func() error { panic("boom") })
+	})
+
+	expected := []string{"start", "end:success", "start", "end:failure", "rejected:open", "start", "end:failure"}
+	assert.Equal(t, expected, first.calls)
+	assert.Equal(t, expected, second.calls)
+}
@@ -1,64 +1,898 @@
 package breaker
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+var (
+	ErrInvalidName                = errors.New("invalid name")
+	ErrInvalidLabels              = errors.New("invalid labels")
+	ErrInvalidWindowFrame         = errors.New("invalid window frame threshold")
+	ErrInvalidWindowRoll          = errors.New("invalid window roll threshold")
+	ErrInvalidWindowThreshold     = errors.New("invalid window threshold")
+	ErrInvalidOpenDuration        = errors.New("invalid open duration")
+	ErrInvalidHalfOpenEvaluation  = errors.New("invalid half open evaluation")
+	ErrInvalidHysteresis          = errors.New("invalid hysteresis")
+	ErrInvalidHalfOpenRamp        = errors.New("invalid half open ramp")
+	ErrInvalidHistoryRetention    = errors.New("invalid history retention")
+	ErrInvalidJitter              = errors.New("invalid jitter")
+	ErrInvalidRandSource          = errors.New("invalid rand source")
+	ErrInvalidRecoveryCooldown    = errors.New("invalid recovery cooldown")
+	ErrInvalidFlapDetection       = errors.New("invalid flap detection")
+	ErrInvalidHalfOpenRate        = errors.New("invalid half open rate")
+	ErrInvalidWindowStrategy      = errors.New("invalid window strategy")
+	ErrInvalidDecay               = errors.New("invalid decay")
+	ErrInvalidStore               = errors.New("invalid store")
+	ErrInvalidLeakyBucket         = errors.New("invalid leaky bucket")
+	ErrInvalidSlidingLog          = errors.New("invalid sliding log")
+	ErrInvalidClock               = errors.New("invalid clock")
+	ErrInvalidCanTrip             = errors.New("invalid can trip callback")
+	ErrInvalidAdaptiveThreshold   = errors.New("invalid adaptive threshold")
+	ErrInvalidThroughputCollapse  = errors.New("invalid throughput collapse")
+	ErrInvalidAnomalyDetection    = errors.New("invalid anomaly detection")
+	ErrInvalidFailureSpike        = errors.New("invalid failure spike")
+	ErrInvalidFromHalfOpenToState = errors.New("invalid half open state change callback")
+	ErrInvalidOnFrameRoll         = errors.New("invalid frame roll callback")
+	ErrInvalidInterceptor         = errors.New("invalid interceptor")
+	ErrInvalidObserver            = errors.New("invalid observer")
+	ErrInvalidMetricsSink         = errors.New("invalid metrics sink")
+	ErrInvalidHystrix             = errors.New("invalid hystrix configuration")
+	ErrInvalidKeyFunc             = errors.New("invalid key function")
+	ErrInvalidErrorHistory        = errors.New("invalid error history")
+	ErrInvalidDeadlinePercentile  = errors.New("invalid deadline percentile")
+	ErrInvalidPriorityShedding    = errors.New("invalid priority shedding")
+	ErrInvalidDegradedShedding    = errors.New("invalid degraded shedding")
+	ErrInvalidOpenQueue           = errors.New("invalid open queue")
+	ErrInvalidFleet               = errors.New("invalid fleet")
+	ErrInvalidSharedState         = errors.New("invalid shared state")
+	ErrInvalidDiscovery           = errors.New("invalid discovery")
+	ErrInvalidRejectionError      = errors.New("invalid rejection error callback")
+	ErrInvalidFailSilent          = errors.New("invalid fail silent recorder")
+	ErrInvalidClassifier          = errors.New("invalid classifier")
+	ErrInvalidTripOnErrors        = errors.New("invalid trip on errors")
+	ErrInvalidHealthPolicy        = errors.New("invalid health policy")
+	ErrInvalidChaos               = errors.New("invalid chaos")
+	ErrInvalidActor               = errors.New("invalid actor")
+	ErrInvalidEventQueue          = errors.New("invalid event queue")
+	ErrInvalidRules               = errors.New("invalid rules")
+
+	// Deprecated: use ErrInvalidOpenDuration.
+	ErrInvalidHalfOpenThreshold = ErrInvalidOpenDuration
+)
 
 type option func(opt *optionsConfiguration) error
 
 type optionsConfiguration struct {
-	windowFrame       int
-	windowRoll        int
-	halfOpenThreshold int
+	name string
+	tags map[string]string
+
+	windowFrame  int
+	windowRoll   int
+	window       time.Duration
+	frames       int
+	openDuration int
+	errorHistory int
+
+	halfOpenCalls     int
+	halfOpenFailures  int
+	halfOpenRateEvery time.Duration
+	halfOpenRateBurst int
+	rampInterval      time.Duration
+	rampSteps         int
+	historyRetention  int
+	jitter            float64
+	randSource        rand.Source
+
+	deadlinePercentile float64
+	shedding           *shedding
+	degraded           *degradedShedding
+
+	openQueueMax     int
+	openQueueMaxWait time.Duration
+
+	probeCoalescing bool
+	probeKey        probeKey
+
+	cancelOnTrip bool
+	trace        bool
+
+	fleetNode      string
+	fleetTransport FleetTransport
+	fleetQuorum    int
+
+	sharedState SharedState
+	discovery   Discovery
 
 	fromHalfOpenToState fromHalfOpenToState
 	canTrip             canTrip
+	measuredCanTrip     measuredCanTrip
+	summaryCanTrip      summaryCanTrip
+	adaptiveK           float64
+	adaptiveMinRate     float64
+	adaptiveMaxRate     float64
+	collapseRatio       float64
+	anomalyZ            float64
+	spike               *spike
+	decay               decay
+	separateTrials      bool
+	tripOn              []error
+	healthPolicy        HealthPolicy
+	chaos               bool
+	chaosFailureRate    float64
+	chaosExtraLatency   time.Duration
+	recoveryCooldown    time.Duration
+	flapOpens           int
+	flapWithin          time.Duration
+	flapMaxOpenDuration time.Duration
+	profiles            []scheduledProfile
+	onFrameRoll         onFrameRoll
+	interceptors        []interceptor
+	observers           []Observer
+	metricsSink         MetricsSink
+	keyFunc             keyFunc
+	rejectionError      rejectionErrorFunc
+	failSilent          failSilentRecorder
+	classifier          classifier
+	eventQueue          int
+	mailbox             int
+	strategy            WindowStrategy
+	store               Store
+	clock               Clock
+	rules               []Rule
+}
+
+func WithName(name string) option {
+	return func(opt *optionsConfiguration) error {
+		if name == "" {
+			return fmt.Errorf("%w: can't be empty", ErrInvalidName)
+		}
+		opt.name = name
+		return nil
+	}
+}
+
+// WithLabels Attaches labels such as the owning team, tier or dependency to the breaker,
+// carried by its snapshots, events and metrics to route alerts. Unlike ExecuteLabeled labels they
+// describe the breaker rather than its calls.
+func WithLabels(labels map[string]string) option {
+	return func(opt *optionsConfiguration) error {
+		tags := make(map[string]string, len(labels))
+		for key, value := range labels {
+			if key == "" {
+				return fmt.Errorf("%w: key can't be empty", ErrInvalidLabels)
+			}
+			tags[key] = value
+		}
+		opt.tags = tags
+		return nil
+	}
 }
 
 func WithWindowFrameThreshold(seconds int) option {
 	return func(opt *optionsConfiguration) error {
 		if seconds <= 0 {
-			return errors.New("frame can't be less than equal zero")
+			return fmt.Errorf("%w: can't be less than equal zero", ErrInvalidWindowFrame)
 		}
 		opt.windowFrame = seconds
 		return nil
 	}
 }
 
+// WithWindow Splits a rolling window spanning roll into the given number of frames, taking
+// precedence over the frame and roll thresholds.
+func WithWindow(roll time.Duration, frames int) option {
+	return func(opt *optionsConfiguration) error {
+		if frames <= 0 {
+			return fmt.Errorf("%w: frames can't be less than equal zero", ErrInvalidWindowThreshold)
+		}
+		if roll < (time.Millisecond * time.Duration(frames)) {
+			return fmt.Errorf("%w: frames can't be shorter than a millisecond", ErrInvalidWindowThreshold)
+		}
+		opt.window = roll
+		opt.frames = frames
+		return nil
+	}
+}
+
 func WithWindowRollThreshold(seconds int) option {
 	return func(opt *optionsConfiguration) error {
 		if seconds <= 0 {
-			return errors.New("window roll can't be less than equal zero")
+			return fmt.Errorf("%w: can't be less than equal zero", ErrInvalidWindowRoll)
 		}
 		opt.windowRoll = seconds
 		return nil
 	}
 }
 
-func WithHalfOpenThreshold(seconds int) option {
+// WithOpenDuration Sets how many seconds the circuit rejects calls once open before probing
+// the dependency in half-open.
+func WithOpenDuration(seconds int) option {
 	return func(opt *optionsConfiguration) error {
 		if seconds <= 0 {
-			return errors.New("half open threshold can't be less than equal zero")
+			return fmt.Errorf("%w: can't be less than equal zero", ErrInvalidOpenDuration)
 		}
-		opt.halfOpenThreshold = seconds
+		opt.openDuration = seconds
 		return nil
 	}
 }
 
+// WithRules Tunes the breaker by the first rule whose pattern matches its name, letting
+// differently-critical endpoints get their thresholds from one shared rule set.
+func WithRules(rules []Rule) option {
+	return func(opt *optionsConfiguration) error {
+		for _, rule := range rules {
+			if err := rule.validate(); err != nil {
+				return fmt.Errorf("%w: %w", ErrInvalidRules, err)
+			}
+		}
+		opt.rules = rules
+		return nil
+	}
+}
+
+// Deprecated: use WithOpenDuration.
+func WithHalfOpenThreshold(seconds int) option {
+	return WithOpenDuration(seconds)
+}
+
+// WithHalfOpenEvaluation Reopens a half-open circuit once failures calls fail and closes it
+// once calls calls are evaluated without reaching them, ignored when WithFromHalfOpenToState is set.
+func WithHalfOpenEvaluation(calls, failures int) option {
+	return func(opt *optionsConfiguration) error {
+		if calls <= 0 || failures <= 0 {
+			return fmt.Errorf("%w: calls and failures can't be less than equal zero", ErrInvalidHalfOpenEvaluation)
+		}
+		if failures > calls {
+			return fmt.Errorf("%w: failures can't be greater than calls", ErrInvalidHalfOpenEvaluation)
+		}
+		opt.halfOpenCalls = calls
+		opt.halfOpenFailures = failures
+		return nil
+	}
+}
+
+// WithHysteresis Trips the circuit once the window failure rate reaches tripRate percent but
+// closes it only once trialCalls half-open calls failed at most recoverRate percent of the
+// time, so a dependency hovering around one threshold doesn't flap. It takes over the
+// WithCanTrip and WithHalfOpenEvaluation settings.
+func WithHysteresis(tripRate, recoverRate float64, trialCalls int) option {
+	return func(opt *optionsConfiguration) error {
+		if recoverRate < 0 || recoverRate >= tripRate || tripRate > 100 {
+			return fmt.Errorf("%w: rates must satisfy 0 <= recover < trip <= 100", ErrInvalidHysteresis)
+		}
+		if trialCalls <= 0 {
+			return fmt.Errorf("%w: trial calls can't be less than equal zero", ErrInvalidHysteresis)
+		}
+		opt.canTrip = hysteresisTrip(tripRate)
+		opt.fromHalfOpenToState = hysteresisEvaluation(recoverRate, trialCalls)
+		return nil
+	}
+}
+
+// WithHalfOpenRamp Admits the half-open trial calls in doubling batches per interval, one
+// call at first, then two once it succeeded, then four, up to 2^(steps-1). Any failure
+// reopens the circuit, which only closes once 2^steps-1 trial calls succeeded in a row. It
+// takes over the half-open evaluation.
+func WithHalfOpenRamp(interval time.Duration, steps int) option {
+	return func(opt *optionsConfiguration) error {
+		if interval <= 0 {
+			return fmt.Errorf("%w: interval can't be less than equal zero", ErrInvalidHalfOpenRamp)
+		}
+		if steps <= 0 || steps > 16 {
+			return fmt.Errorf("%w: steps must be between 1 and 16", ErrInvalidHalfOpenRamp)
+		}
+		opt.rampInterval, opt.rampSteps = interval, steps
+		return nil
+	}
+}
+
+// WithHistoryRetention Keeps up to frames completed frames once they leave the rolling
+// window, the oldest dropped first, read with History.
+func WithHistoryRetention(frames int) option {
+	return func(opt *optionsConfiguration) error {
+		if frames <= 0 {
+			return fmt.Errorf("%w: frames can't be less than equal zero", ErrInvalidHistoryRetention)
+		}
+		opt.historyRetention = frames
+		return nil
+	}
+}
+
+// WithJitter Moves every frame rotation and open duration by a random offset of up to
+// fraction of it either way, so the breakers of a fleet don't rotate nor probe in lockstep.
+func WithJitter(fraction float64) option {
+	return func(opt *optionsConfiguration) error {
+		if fraction < 0 || fraction >= 1 {
+			return fmt.Errorf("%w: fraction must be between 0 and 1", ErrInvalidJitter)
+		}
+		opt.jitter = fraction
+		return nil
+	}
+}
+
+// WithRandSource Sets the source the jitter, the chaos failures, the degraded shedding and
+// the job requeue backoffs draw from, the global math/rand source by default, so tests can
+// seed them deterministically. The source is locked once here, so the breakers built with
+// the option may share it but nothing else must draw from it.
+func WithRandSource(source rand.Source) option {
+	var locked rand.Source
+	if source != nil {
+		locked = &lockedSource{src: source}
+	}
+
+	return func(opt *optionsConfiguration) error {
+		if locked == nil {
+			return fmt.Errorf("%w: can't be <nil>", ErrInvalidRandSource)
+		}
+		opt.randSource = locked
+		return nil
+	}
+}
+
+// WithRecoveryCooldown Evaluates the trip predicate only on the calls made since a half-open
+// circuit closed for d, so the failures still in the window can't reopen it right away.
+func WithRecoveryCooldown(d time.Duration) option {
+	return func(opt *optionsConfiguration) error {
+		if d <= 0 {
+			return fmt.Errorf("%w: can't be less than equal zero", ErrInvalidRecoveryCooldown)
+		}
+		opt.recoveryCooldown = d
+		return nil
+	}
+}
+
+// WithFlapDetection Deems the circuit flapping once it opens opens times within the period,
+// emitting an EventFlapping and doubling the open duration at that open and every further
+// one within the period, up to maxOpenDuration. Only the opens of a closed circuit count, a
+// failed half-open probe reopening it is still the same outage.
+func WithFlapDetection(opens int, within, maxOpenDuration time.Duration) option {
+	return func(opt *optionsConfiguration) error {
+		if opens < 2 {
+			return fmt.Errorf("%w: opens can't be less than two", ErrInvalidFlapDetection)
+		}
+		if within <= 0 || maxOpenDuration <= 0 {
+			return fmt.Errorf("%w: period and max open duration can't be less than equal zero", ErrInvalidFlapDetection)
+		}
+		opt.flapOpens, opt.flapWithin, opt.flapMaxOpenDuration = opens, within, maxOpenDuration
+		return nil
+	}
+}
+
+// WithSeparateHalfOpenStats Leaves the half-open trial calls out of the window once the
+// circuit closes, instead of folding them into its last frame, so probing doesn't weigh on
+// the failure rate after recovery. The trial is kept apart, see LastTrial.
+func WithSeparateHalfOpenStats() option {
+	return func(opt *optionsConfiguration) error {
+		opt.separateTrials = true
+		return nil
+	}
+}
+
+// WithHalfOpenRate Admits half-open trial calls through a token bucket refilled with a
+// token every period up to burst, the calls without a token are rejected as if open.
+func WithHalfOpenRate(every time.Duration, burst int) option {
+	return func(opt *optionsConfiguration) error {
+		if every <= 0 || burst <= 0 {
+			return fmt.Errorf("%w: period and burst can't be less than equal zero", ErrInvalidHalfOpenRate)
+		}
+		opt.halfOpenRateEvery = every
+		opt.halfOpenRateBurst = burst
+		return nil
+	}
+}
+
+// WithLinearDecay Weighs the window frames the trip predicate is evaluated on by their
+// recency, from the full weight of the current frame down to 1/frames for the oldest. It is
+// ignored along a window strategy.
+func WithLinearDecay() option {
+	return func(opt *optionsConfiguration) error {
+		opt.decay = linearDecay
+		return nil
+	}
+}
+
+// WithExponentialDecay Weighs the window frames the trip predicate is evaluated on by factor
+// to the power of their age in frames, so failures fade out as they get older. It is ignored
+// along a window strategy.
+func WithExponentialDecay(factor float64) option {
+	return func(opt *optionsConfiguration) error {
+		if factor <= 0 || factor >= 1 {
+			return fmt.Errorf("%w: factor must be between 0 and 1", ErrInvalidDecay)
+		}
+		opt.decay = exponentialDecay(factor)
+		return nil
+	}
+}
+
+// WithWindowStrategy Evaluates the trip predicate on the counts accounted by strategy
+// instead of the frame window summary.
+func WithWindowStrategy(strategy WindowStrategy) option {
+	return func(opt *optionsConfiguration) error {
+		if strategy == nil {
+			return fmt.Errorf("%w: can't be <nil>", ErrInvalidWindowStrategy)
+		}
+		opt.strategy = strategy
+		return nil
+	}
+}
+
+// WithStore Accounts the window counters in store under the breaker name, taking over any
+// window strategy, breakers sharing a store and a name trip on their combined outcomes.
+// Store errors are reported as EventStoreError events.
+func WithStore(store Store) option {
+	return func(opt *optionsConfiguration) error {
+		if store == nil {
+			return fmt.Errorf("%w: can't be <nil>", ErrInvalidStore)
+		}
+		opt.store = store
+		return nil
+	}
+}
+
+// WithLeakyBucket Trips the circuit once more than capacity failures pile up in a bucket
+// draining a failure every period, a later WithCanTrip replaces the overflow predicate.
+func WithLeakyBucket(capacity int, drainEvery time.Duration) option {
+	return func(opt *optionsConfiguration) error {
+		if capacity <= 0 || drainEvery <= 0 {
+			return fmt.Errorf("%w: capacity and drain period can't be less than equal zero", ErrInvalidLeakyBucket)
+		}
+		opt.strategy = NewLeakyBucket(drainEvery)
+		opt.canTrip = func(summary Counts) bool { return summary.Fail > uint64(capacity) }
+		return nil
+	}
+}
+
+// WithCanTrip Trips the circuit once canTrip holds for the window summary, canTrip is called
+// by one returning call at a time.
 func WithCanTrip(canTrip canTrip) option {
 	return func(opt *optionsConfiguration) error {
 		if canTrip == nil {
-			return errors.New("can trip callback can't be <nil>")
+			return fmt.Errorf("%w: can't be <nil>", ErrInvalidCanTrip)
 		}
 		opt.canTrip = canTrip
 		return nil
 	}
 }
 
+// WithAdaptiveThreshold Trips the circuit once the failure rate reaches the baseline learned
+// from the completed frames plus k standard deviations, kept between minRate and maxRate
+// percent and at maxRate until the baseline settles. It takes over the WithCanTrip predicate.
+func WithAdaptiveThreshold(k, minRate, maxRate float64) option {
+	return func(opt *optionsConfiguration) error {
+		if k <= 0 {
+			return fmt.Errorf("%w: k can't be less than equal zero", ErrInvalidAdaptiveThreshold)
+		}
+		if minRate <= 0 || maxRate > 100 || minRate > maxRate {
+			return fmt.Errorf("%w: bounds must satisfy 0 < min <= max <= 100", ErrInvalidAdaptiveThreshold)
+		}
+		opt.adaptiveK, opt.adaptiveMinRate, opt.adaptiveMaxRate = k, minRate, maxRate
+		return nil
+	}
+}
+
+// WithAnomalyDetection Trips the circuit once the failure rate of the current frame lies z
+// standard deviations above the rates of the previous frames of the window, for dependencies
+// too variable for a fixed threshold. It takes over the trip predicate, deferring to it until
+// the window holds three frames with enough calls, so it suits windows of many frames.
+func WithAnomalyDetection(z float64) option {
+	return func(opt *optionsConfiguration) error {
+		if z <= 0 {
+			return fmt.Errorf("%w: z can't be less than equal zero", ErrInvalidAnomalyDetection)
+		}
+		opt.anomalyZ = z
+		return nil
+	}
+}
+
+// WithFailureSpike Trips the circuit, besides the trip predicate, once the current frame
+// fails multiplier times as often as the rest of the counts the trip predicate sees and at
+// least minRate percent of the time, catching abrupt outages within a frame.
+func WithFailureSpike(multiplier, minRate float64) option {
+	return func(opt *optionsConfiguration) error {
+		if multiplier <= 1 {
+			return fmt.Errorf("%w: multiplier must be greater than one", ErrInvalidFailureSpike)
+		}
+		if minRate <= 0 || minRate > 100 {
+			return fmt.Errorf("%w: min rate must be between 0 and 100", ErrInvalidFailureSpike)
+		}
+		opt.spike = &spike{multiplier: multiplier, minRate: minRate}
+		return nil
+	}
+}
+
+// WithThroughputCollapse Trips the circuit when a frame ends with fewer successful calls than
+// ratio times their mean over the previous frames of the window, as when calls hang rather
+// than fail. The window must average at least the minimum volume of successes.
+func WithThroughputCollapse(ratio float64) option {
+	return func(opt *optionsConfiguration) error {
+		if ratio <= 0 || ratio >= 1 {
+			return fmt.Errorf("%w: ratio must be between 0 and 1", ErrInvalidThroughputCollapse)
+		}
+		opt.collapseRatio = ratio
+		return nil
+	}
+}
+
+// WithProfiles Reconfigures the breaker with the thresholds of the first profile whose
+// schedule matches the minute of the breaker clock, checked every minute and applied only
+// when the matched profile changes. When none matches the thresholds in effect before the
+// profiles took over are restored. A manual Reconfigure holds until the matched profile
+// changes, and is the one restored once no profile matches.
+func WithProfiles(profiles []Profile) option {
+	return func(opt *optionsConfiguration) error {
+		scheduled := make([]scheduledProfile, 0, len(profiles))
+		for _, profile := range profiles {
+			s, err := parseSchedule(profile.Schedule)
+			if err != nil {
+				return err
+			}
+			if err = profile.Thresholds.validate(); err != nil {
+				return err
+			}
+			scheduled = append(scheduled, scheduledProfile{schedule: s, thresholds: profile.Thresholds})
+		}
+		opt.profiles = scheduled
+		return nil
+	}
+}
+
+// WithMeasuredCanTrip Trips the circuit on a predicate also given the measurements recorded
+// over the window, taking over the WithCanTrip one.
+func WithMeasuredCanTrip(measuredCanTrip measuredCanTrip) option {
+	return func(opt *optionsConfiguration) error {
+		if measuredCanTrip == nil {
+			return fmt.Errorf("%w: can't be <nil>", ErrInvalidCanTrip)
+		}
+		opt.measuredCanTrip = measuredCanTrip
+		return nil
+	}
+}
+
+// WithSummaryCanTrip Trips the circuit on the window summary along its derived statistics,
+// such as the rejection rate or the frame extremes.
+func WithSummaryCanTrip(summaryCanTrip summaryCanTrip) option {
+	return func(opt *optionsConfiguration) error {
+		if summaryCanTrip == nil {
+			return fmt.Errorf("%w: can't be <nil>", ErrInvalidCanTrip)
+		}
+		opt.summaryCanTrip = summaryCanTrip
+		return nil
+	}
+}
+
 func WithFromHalfOpenToState(fromHalfOpenToState fromHalfOpenToState) option {
 	return func(opt *optionsConfiguration) error {
 		if fromHalfOpenToState == nil {
-			return errors.New("half open state change callback can't be <nil>")
+			return fmt.Errorf("%w: can't be <nil>", ErrInvalidFromHalfOpenToState)
 		}
 		opt.fromHalfOpenToState = fromHalfOpenToState
 		return nil
 	}
 }
+
+// WithOnFrameRoll Registers a callback invoked after every frame rotation with the
+// frame that was just completed and the window summary after the rotation.
+func WithOnFrameRoll(onFrameRoll onFrameRoll) option {
+	return func(opt *optionsConfiguration) error {
+		if onFrameRoll == nil {
+			return fmt.Errorf("%w: can't be <nil>", ErrInvalidOnFrameRoll)
+		}
+		opt.onFrameRoll = onFrameRoll
+		return nil
+	}
+}
+
+// WithInterceptor Wraps every guarded call, it may be given multiple times and the
+// first interceptor given is the outermost one.
+func WithInterceptor(interceptor interceptor) option {
+	return func(opt *optionsConfiguration) error {
+		if interceptor == nil {
+			return fmt.Errorf("%w: can't be <nil>", ErrInvalidInterceptor)
+		}
+		opt.interceptors = append(opt.interceptors, interceptor)
+		return nil
+	}
+}
+
+// WithChaos Fails the given percentage of the guarded calls with ErrChaos and delays every
+// one by extraLatency, only while enabled with EnableChaos. It wraps the call inside the
+// interceptors, as close to the dependency as possible.
+func WithChaos(failureRate float64, extraLatency time.Duration) option {
+	return func(opt *optionsConfiguration) error {
+		if failureRate < 0 || failureRate > 100 {
+			return fmt.Errorf("%w: failure rate must be between 0 and 100", ErrInvalidChaos)
+		}
+		if extraLatency < 0 {
+			return fmt.Errorf("%w: extra latency can't be less than zero", ErrInvalidChaos)
+		}
+		opt.chaos = true
+		opt.chaosFailureRate, opt.chaosExtraLatency = failureRate, extraLatency
+		return nil
+	}
+}
+
+// WithActor Applies the counts, the frame rotations, the transitions and every other state
+// mutation on a single goroutine fed through a mailbox of the given size, which owns the
+// state without locking it, callers only taking the admission decision before handing the
+// rest of the call over. The counts and the state catch up with a call shortly after it
+// returns, Trip, Reset, SetMode and the like wait for the actor to apply them, and a full
+// mailbox holds the callers back.
+func WithActor(mailbox int) option {
+	return func(opt *optionsConfiguration) error {
+		if mailbox <= 0 {
+			return fmt.Errorf("%w: mailbox can't be less than equal zero", ErrInvalidActor)
+		}
+		opt.mailbox = mailbox
+		return nil
+	}
+}
+
+// WithObserver Notifies observer around the lifecycle of every call, it may be given multiple
+// times and observers are notified in the order given.
+func WithObserver(observer Observer) option {
+	return func(opt *optionsConfiguration) error {
+		if observer == nil {
+			return fmt.Errorf("%w: can't be <nil>", ErrInvalidObserver)
+		}
+		opt.observers = append(opt.observers, observer)
+		return nil
+	}
+}
+
+// WithMetricsSink Reports the calls, rejections and transitions to sink, see the Metric
+// constants for what is reported.
+func WithMetricsSink(sink MetricsSink) option {
+	return func(opt *optionsConfiguration) error {
+		if sink == nil {
+			return fmt.Errorf("%w: can't be <nil>", ErrInvalidMetricsSink)
+		}
+		opt.metricsSink = sink
+		return nil
+	}
+}
+
+// WithKeyFunc Resolves the key of the breaker a Group runs a call through from its context,
+// such as the shard or the customer an integration stored in it. Breakers ignore it.
+func WithKeyFunc(keyFunc keyFunc) option {
+	return func(opt *optionsConfiguration) error {
+		if keyFunc == nil {
+			return fmt.Errorf("%w: can't be <nil>", ErrInvalidKeyFunc)
+		}
+		opt.keyFunc = keyFunc
+		return nil
+	}
+}
+
+// WithClassifier Decides the outcome of every call from its error and latency, ignored calls
+// don't affect the circuit.
+func WithClassifier(classifier classifier) option {
+	return func(opt *optionsConfiguration) error {
+		if classifier == nil {
+			return fmt.Errorf("%w: can't be <nil>", ErrInvalidClassifier)
+		}
+		opt.classifier = classifier
+		return nil
+	}
+}
+
+// WithTripOnErrors Counts only the errors matching one of errs as failures, by errors.Is or,
+// for a typed nil pointer such as (*net.OpError)(nil), by errors.As on its type. Every other
+// error counts as a success. It narrows the classifier rather than replacing it.
+func WithTripOnErrors(errs ...error) option {
+	return func(opt *optionsConfiguration) error {
+		if len(errs) == 0 {
+			return fmt.Errorf("%w: at least one error is required", ErrInvalidTripOnErrors)
+		}
+		for _, err := range errs {
+			if err == nil {
+				return fmt.Errorf("%w: can't be <nil>", ErrInvalidTripOnErrors)
+			}
+		}
+		opt.tripOn = errs
+		return nil
+	}
+}
+
+// WithHealthPolicy Decides how SetExternalHealth combines with the live stats, defaults to
+// HealthExternalWins.
+func WithHealthPolicy(policy HealthPolicy) option {
+	return func(opt *optionsConfiguration) error {
+		if err := validHealthPolicy(policy); err != nil {
+			return err
+		}
+		opt.healthPolicy = policy
+		return nil
+	}
+}
+
+// WithClock Replaces the wall clock the breaker tells time and schedules its timers with.
+func WithClock(clock Clock) option {
+	return func(opt *optionsConfiguration) error {
+		if clock == nil {
+			return fmt.Errorf("%w: can't be <nil>", ErrInvalidClock)
+		}
+		opt.clock = clock
+		return nil
+	}
+}
+
+// WithEventQueue Bounds how many events and callbacks may wait for dispatch before new
+// ones are dropped.
+func WithEventQueue(size int) option {
+	return func(opt *optionsConfiguration) error {
+		if size <= 0 {
+			return fmt.Errorf("%w: can't be less than equal zero", ErrInvalidEventQueue)
+		}
+		opt.eventQueue = size
+		return nil
+	}
+}
+
+// WithRejectionError Builds the error returned while the circuit is open from the Rejection
+// describing it, the error still matches ErrOpenCircuit through errors.Is.
+func WithRejectionError(rejectionError rejectionErrorFunc) option {
+	return func(opt *optionsConfiguration) error {
+		if rejectionError == nil {
+			return fmt.Errorf("%w: can't be <nil>", ErrInvalidRejectionError)
+		}
+		opt.rejectionError = rejectionError
+		return nil
+	}
+}
+
+// WithFailSilent Makes rejections and failures return nil to the caller after handing
+// the error to recorder, for fire-and-forget calls.
+func WithFailSilent(recorder failSilentRecorder) option {
+	return func(opt *optionsConfiguration) error {
+		if recorder == nil {
+			return fmt.Errorf("%w: can't be <nil>", ErrInvalidFailSilent)
+		}
+		opt.failSilent = recorder
+		return nil
+	}
+}
+
+// WithErrorHistory Keeps the last size errors of the failed calls along with when they
+// happened, read through LastError and RecentErrors.
+func WithErrorHistory(size int) option {
+	return func(opt *optionsConfiguration) error {
+		if size <= 0 {
+			return fmt.Errorf("%w: size can't be less than equal zero", ErrInvalidErrorHistory)
+		}
+		opt.errorHistory = size
+		return nil
+	}
+}
+
+// WithDeadlineRejection Rejects context-aware calls whose remaining deadline is shorter than
+// the given percentile of the recently observed call latencies, without counting them as failures.
+func WithDeadlineRejection(percentile float64) option {
+	return func(opt *optionsConfiguration) error {
+		if percentile <= 0 || percentile > 100 {
+			return fmt.Errorf("%w: must be within (0, 100]", ErrInvalidDeadlinePercentile)
+		}
+		opt.deadlinePercentile = percentile
+		return nil
+	}
+}
+
+// WithPriorityShedding Sheds low and normal priority calls while closed once the window
+// failure rate reaches the given percentages, high priority calls are never shed.
+func WithPriorityShedding(lowRate, normalRate float64) option {
+	return func(opt *optionsConfiguration) error {
+		if lowRate <= 0 || normalRate > 100 || lowRate > normalRate {
+			return fmt.Errorf("%w: rates must satisfy 0 < low <= normal <= 100", ErrInvalidPriorityShedding)
+		}
+		opt.shedding = &shedding{low: lowRate, normal: normalRate}
+		return nil
+	}
+}
+
+// WithDegradedShedding Randomly rejects calls while closed once the window failure rate reaches
+// startRate, the rejected share grows with the failure rate up to maxShedRate percent.
+func WithDegradedShedding(startRate, maxShedRate float64) option {
+	return func(opt *optionsConfiguration) error {
+		if startRate < 0 || startRate >= 100 {
+			return fmt.Errorf("%w: start rate must be within [0, 100)", ErrInvalidDegradedShedding)
+		}
+		if maxShedRate <= 0 || maxShedRate > 100 {
+			return fmt.Errorf("%w: max shed rate must be within (0, 100]", ErrInvalidDegradedShedding)
+		}
+		opt.degraded = &degradedShedding{start: startRate, maxShed: maxShedRate}
+		return nil
+	}
+}
+
+// WithOpenQueue Lets up to max callers wait for at most maxWait for an open circuit to
+// half-open instead of being rejected right away, waiters are released in FIFO order.
+func WithOpenQueue(max int, maxWait time.Duration) option {
+	return func(opt *optionsConfiguration) error {
+		if max <= 0 {
+			return fmt.Errorf("%w: size can't be less than equal zero", ErrInvalidOpenQueue)
+		}
+		if maxWait <= 0 {
+			return fmt.Errorf("%w: max wait can't be less than equal zero", ErrInvalidOpenQueue)
+		}
+		opt.openQueueMax = max
+		opt.openQueueMaxWait = maxWait
+		return nil
+	}
+}
+
+// WithProbeCoalescing Collapses concurrent half-open calls sharing the same key into a single
+// downstream call whose result is shared, a <nil> key func coalesces every concurrent probe.
+func WithProbeCoalescing(key probeKey) option {
+	return func(opt *optionsConfiguration) error {
+		opt.probeCoalescing = true
+		opt.probeKey = key
+		return nil
+	}
+}
+
+// WithCancelOnTrip Cancels the context of every call in flight as soon as the breaker opens,
+// context.Cause reports ErrOpenCircuit for those calls.
+func WithCancelOnTrip() option {
+	return func(opt *optionsConfiguration) error {
+		opt.cancelOnTrip = true
+		return nil
+	}
+}
+
+// WithTrace Wraps every call in a runtime/trace task named after the breaker, logging how
+// the call was counted or rejected so go tool trace shows where guarded calls sit.
+func WithTrace() option {
+	return func(opt *optionsConfiguration) error {
+		opt.trace = true
+		return nil
+	}
+}
+
+// WithFleet Broadcasts the transitions of this breaker from node through the transport and preemptively
+// opens it once quorum distinct peers report the breaker with the same name open within the window roll.
+// The trips the fleet causes aren't broadcast, so peers only count the nodes that tripped on their own.
+func WithFleet(node string, transport FleetTransport, quorum int) option {
+	return func(opt *optionsConfiguration) error {
+		if node == "" {
+			return fmt.Errorf("%w: node can't be empty", ErrInvalidFleet)
+		}
+		if transport == nil {
+			return fmt.Errorf("%w: transport can't be <nil>", ErrInvalidFleet)
+		}
+		if quorum <= 0 {
+			return fmt.Errorf("%w: quorum can't be less than equal zero", ErrInvalidFleet)
+		}
+		opt.fleetNode = node
+		opt.fleetTransport = transport
+		opt.fleetQuorum = quorum
+		return nil
+	}
+}
+
+// WithSharedState Stores the open decisions of this breaker with a ttl of the half-open timeout,
+// and their release once it closes, and follows the decisions stored by the other instances sharing
+// its name. Only the release of the decision an instance followed closes it. Decisions are stored
+// one at a time in the order of the transitions, each bounded by a timeout.
+func WithSharedState(store SharedState) option {
+	return func(opt *optionsConfiguration) error {
+		if store == nil {
+			return fmt.Errorf("%w: can't be <nil>", ErrInvalidSharedState)
+		}
+		opt.sharedState = store
+		return nil
+	}
+}
+
+// WithDiscovery Publishes the state of this breaker into the discovery backend on creation
+// and on every transition, keyed by its name.
+func WithDiscovery(backend Discovery) option {
+	return func(opt *optionsConfiguration) error {
+		if backend == nil {
+			return fmt.Errorf("%w: can't be <nil>", ErrInvalidDiscovery)
+		}
+		opt.discovery = backend
+		return nil
+	}
+}
@@ -0,0 +1,57 @@
+package breaker
+
+import "time"
+
+type Outcome string
+
+const (
+	OutcomeSuccess Outcome = "success"
+	OutcomeFailure Outcome = "failure"
+	OutcomeSlow    Outcome = "slow"
+	OutcomeIgnored Outcome = "ignored"
+)
+
+func (o Outcome) counts() Counts {
+	switch o {
+	case OutcomeFailure:
+		return Counts{Total: 1, Fail: 1}
+	case OutcomeSlow:
+		return Counts{Total: 1, Slow: 1}
+	case OutcomeIgnored:
+		return Counts{Ignored: 1}
+	default:
+		return Counts{Total: 1, Success: 1}
+	}
+}
+
+type classifiedError struct {
+	outcome Outcome
+	err     error
+}
+
+func (c *classifiedError) Error() string {
+	if c.err == nil {
+		return string(c.outcome)
+	}
+	return c.err.Error()
+}
+
+func (c *classifiedError) Unwrap() error {
+	return c.err
+}
+
+// Classified Lets a call decide its own outcome, bypassing the classifier, when it knows
+// better than its error, such as a response carrying a failure status. The breaker hands
+// err back to the caller unchanged, nil included.
+func Classified(outcome Outcome, err error) error {
+	return &classifiedError{outcome: outcome, err: err}
+}
+
+// classify Resolves the outcome of a call, along with the error to hand back to the caller.
+func (c *CircuitBreaker) classify(err error, latency time.Duration) (Outcome, error) {
+	if classified, ok := err.(*classifiedError); ok {
+		return classified.outcome, classified.err
+	}
+
+	return c.classifier(err, latency), err
+}
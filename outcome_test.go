@@ -0,0 +1,76 @@
+package breaker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreakerClassifier(t *testing.T) {
+	cb, cancel, err := New(
+		WithClassifier(func(err error, latency time.Duration) Outcome {
+			switch {
+			case err == context.Canceled:
+				return OutcomeIgnored
+			case err != nil:
+				return OutcomeFailure
+			case latency > 5*time.Millisecond:
+				return OutcomeSlow
+			default:
+				return OutcomeSuccess
+			}
+		}),
+		WithCanTrip(func(summary Counts) bool { return summary.Slow >= 2 }),
+		WithErrorHistory(5),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	assert.NoError(t, cb.Execute(fixtureCircuitCall(nil)))
+	assert.ErrorIs(t, cb.Execute(fixtureCircuitCall(errCall)), errCall)
+	assert.ErrorIs(t, cb.Execute(fixtureCircuitCall(context.Canceled)), context.Canceled)
+	assert.NoError(t, cb.Execute(func() error {
+		time.Sleep(10 * time.Millisecond)
+		return nil
+	}))
+
+	assert.Equal(t, Counts{Total: 3, Fail: 1, Success: 1, Slow: 1, Ignored: 1}, cb.Counts())
+	require.Len(t, cb.RecentErrors(), 1)
+	assert.Equal(t, errCall, cb.RecentErrors()[0].Err)
+	assert.Equal(t, Closed, cb.State())
+
+	assert.NoError(t, cb.Execute(func() error {
+		time.Sleep(10 * time.Millisecond)
+		return nil
+	}))
+	assert.Equal(t, Open, cb.State())
+}
+
+func TestBreakerClassified(t *testing.T) {
+	cb, cancel, err := New()
+	require.NoError(t, err)
+	defer cancel()
+
+	tt := []struct {
+		outcome Outcome
+		err     error
+	}{
+		{outcome: OutcomeFailure, err: nil},
+		{outcome: OutcomeSuccess, err: errCall},
+		{outcome: OutcomeIgnored, err: errCall},
+		{outcome: OutcomeSlow, err: nil},
+	}
+	for _, tc := range tt {
+		got := cb.Execute(func() error { return Classified(tc.outcome, tc.err) })
+		assert.Equal(t, tc.err, got)
+	}
+
+	assert.Equal(t, Counts{Total: 3, Fail: 1, Success: 1, Slow: 1, Ignored: 1}, cb.Counts())
+	assert.Nil(t, cb.LastError())
+
+	cb.Pause()
+	assert.Equal(t, errCall, cb.Execute(func() error { return Classified(OutcomeSuccess, errCall) }))
+}
@@ -0,0 +1,80 @@
+package breaker
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+var ErrNoEndpoints = errors.New("no endpoints to pick from")
+
+// Picker Steers load across endpoints with a breaker per endpoint, skipping the endpoints
+// whose circuit is open and taking them back once their circuit half-opens. It is the
+// piece a load balancer consults before handing out a connection, breakergrpc.Balancer
+// plugs it into gRPC.
+type Picker struct {
+	breakers *breakerSet
+	next     atomic.Uint64
+}
+
+// NewPicker Creates every endpoint breaker from opts, naming it after the endpoint.
+func NewPicker(opts ...option) *Picker {
+	return &Picker{breakers: newBreakerSet(opts)}
+}
+
+// Pick Round-robins over the endpoints whose circuit isn't open, returning the chosen one
+// along with its breaker to run the call through so its outcome is recorded. When every
+// circuit is open the error wraps ErrOpenCircuit.
+func (p *Picker) Pick(endpoints []string) (string, *CircuitBreaker, error) {
+	if len(endpoints) == 0 {
+		return "", nil, ErrNoEndpoints
+	}
+
+	start := p.next.Add(1) - 1
+	for i := range endpoints {
+		endpoint := endpoints[(start+uint64(i))%uint64(len(endpoints))]
+		cb, err := p.breakers.get(endpoint)
+		if err != nil {
+			return "", nil, err
+		}
+		if cb.State() != Open {
+			return endpoint, cb, nil
+		}
+	}
+
+	return "", nil, fmt.Errorf("%w: every endpoint is open", ErrOpenCircuit)
+}
+
+// Available Filters endpoints down to those whose circuit isn't open, keeping their order.
+func (p *Picker) Available(endpoints []string) []string {
+	available := make([]string, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		if cb, ok := p.breakers.lookup(endpoint); ok && cb.State() == Open {
+			continue
+		}
+		available = append(available, endpoint)
+	}
+
+	return available
+}
+
+// Breaker Returns the breaker of endpoint, creating it on first use.
+func (p *Picker) Breaker(endpoint string) (*CircuitBreaker, error) {
+	return p.breakers.get(endpoint)
+}
+
+// Breakers Returns the breakers of every endpoint picked so far sorted by name.
+func (p *Picker) Breakers() []*CircuitBreaker {
+	return p.breakers.list()
+}
+
+// Prune Stops and forgets the breakers of the endpoints missing from endpoints, for when
+// they leave the resolver state, so a returning endpoint starts over with a fresh breaker.
+func (p *Picker) Prune(endpoints []string) {
+	p.breakers.retain(endpoints)
+}
+
+// Close Stops the breaker of every endpoint.
+func (p *Picker) Close() {
+	p.breakers.close()
+}
@@ -0,0 +1,54 @@
+package breaker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPicker(t *testing.T) {
+	p := NewPicker()
+	defer p.Close()
+
+	_, _, err := p.Pick(nil)
+	assert.ErrorIs(t, err, ErrNoEndpoints)
+
+	endpoints := []string{"10.0.0.1:443", "10.0.0.2:443", "10.0.0.3:443"}
+	picked := map[string]int{}
+	for i := 0; i < 6; i++ {
+		endpoint, cb, err := p.Pick(endpoints)
+		require.NoError(t, err)
+		assert.Equal(t, endpoint, cb.Name())
+		assert.NoError(t, cb.Execute(fixtureCircuitCall(nil)))
+		picked[endpoint]++
+	}
+	assert.Equal(t, map[string]int{"10.0.0.1:443": 2, "10.0.0.2:443": 2, "10.0.0.3:443": 2}, picked)
+
+	down, err := p.Breaker("10.0.0.2:443")
+	require.NoError(t, err)
+	down.Trip()
+	assert.Equal(t, []string{"10.0.0.1:443", "10.0.0.3:443", "10.0.0.4:443"}, p.Available(append(endpoints, "10.0.0.4:443")))
+	for i := 0; i < 6; i++ {
+		endpoint, _, err := p.Pick(endpoints)
+		require.NoError(t, err)
+		assert.NotEqual(t, "10.0.0.2:443", endpoint)
+	}
+
+	down.ProbeNow()
+	assert.Equal(t, endpoints, p.Available(endpoints))
+
+	for _, cb := range p.Breakers() {
+		cb.Trip()
+	}
+	_, _, err = p.Pick(endpoints)
+	assert.ErrorIs(t, err, ErrOpenCircuit)
+
+	p.Prune([]string{"10.0.0.1:443"})
+	require.Len(t, p.Breakers(), 1)
+	assert.Equal(t, "10.0.0.1:443", p.Breakers()[0].Name())
+
+	cb, err := p.Breaker("10.0.0.2:443")
+	require.NoError(t, err)
+	assert.Equal(t, Closed, cb.State())
+}
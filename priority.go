@@ -0,0 +1,45 @@
+package breaker
+
+import "context"
+
+type Priority int
+
+const (
+	Low Priority = iota
+	Normal
+	High
+)
+
+type shedding struct {
+	low    float64
+	normal float64
+}
+
+func (c *CircuitBreaker) ExecuteWithPriority(priority Priority, fn circuitCall) error {
+	return c.execute(context.Background(), callOptions{priority: priority}, func(context.Context) error {
+		return fn()
+	})
+}
+
+// canAdmitPriority Sheds low and then normal priority calls as the window failure rate
+// climbs, while high priority calls are only rejected once the circuit opens.
+func (c *CircuitBreaker) canAdmitPriority(priority Priority) error {
+	if c.cfg.shedding == nil || c.stateCopy() != Closed {
+		return nil
+	}
+
+	summary := c.summaryCopy()
+	if summary.Total <= _minimumVolume {
+		return nil
+	}
+
+	rate := summary.FailureRate()
+	switch {
+	case priority <= Low && rate >= c.cfg.shedding.low:
+		return ErrCallShed
+	case priority == Normal && rate >= c.cfg.shedding.normal:
+		return ErrCallShed
+	}
+
+	return nil
+}
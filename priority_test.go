@@ -0,0 +1,40 @@
+package breaker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreakerPriorityShedding(t *testing.T) {
+	cb, cancel, err := New(
+		WithPriorityShedding(30, 50),
+		WithCanTrip(func(summary Counts) bool { return summary.Total > 10 && summary.FailureRate() >= 90 }),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	executeAll := func() map[Priority]error {
+		got := map[Priority]error{}
+		for _, priority := range []Priority{Low, Normal, High} {
+			got[priority] = cb.ExecuteWithPriority(priority, fixtureCircuitCall(nil))
+		}
+		return got
+	}
+
+	// below the minimum volume nothing is shed
+	syncFeedCircuitBreakerHelper(cb, []error{errCall, errCall, errCall, errCall, errCall}, false)
+	assert.Equal(t, map[Priority]error{Low: nil, Normal: nil, High: nil}, executeAll())
+
+	// 5 failures out of 12 calls
+	syncFeedCircuitBreakerHelper(cb, []error{nil, nil, nil, nil}, false)
+	assert.Equal(t, map[Priority]error{Low: ErrCallShed, Normal: nil, High: nil}, executeAll())
+	assert.Equal(t, Counts{Total: 14, Fail: 5, Success: 9}, cb.summaryCopy())
+
+	// 9 failures out of 18 calls
+	syncFeedCircuitBreakerHelper(cb, []error{errCall, errCall, errCall, errCall}, false)
+	assert.Equal(t, map[Priority]error{Low: ErrCallShed, Normal: ErrCallShed, High: nil}, executeAll())
+	assert.Equal(t, Counts{Total: 19, Fail: 9, Success: 10}, cb.summaryCopy())
+	assert.Equal(t, Closed, cb.stateCopy())
+}
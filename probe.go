@@ -0,0 +1,63 @@
+package breaker
+
+import (
+	"context"
+	"sync"
+)
+
+type probeKey func(ctx context.Context) string
+
+type probeCall struct {
+	done chan struct{}
+	err  error
+}
+
+type probeGroup struct {
+	calls map[string]*probeCall
+	key   probeKey
+
+	mu sync.Mutex
+}
+
+func newProbeGroup(key probeKey) *probeGroup {
+	if key == nil {
+		key = func(context.Context) string { return "" }
+	}
+
+	return &probeGroup{
+		calls: make(map[string]*probeCall),
+		key:   key,
+	}
+}
+
+// do Runs call unless a probe with the same key is already in flight, in which case
+// its result is shared once it completes.
+func (g *probeGroup) do(ctx context.Context, call func() error) error {
+	key := g.key(ctx)
+
+	g.mu.Lock()
+	if p, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+
+		select {
+		case <-p.done:
+			return p.err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	p := &probeCall{done: make(chan struct{}), err: ErrCallPanic}
+	g.calls[key] = p
+	g.mu.Unlock()
+
+	defer func() {
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		close(p.done)
+	}()
+
+	p.err = call()
+	return p.err
+}
@@ -0,0 +1,86 @@
+package breaker
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type probeKeyCtx struct{}
+
+func TestBreakerProbeCoalescing(t *testing.T) {
+	cb, cancel, err := New(
+		WithOpenDuration(1),
+		WithCanTrip(func(summary Counts) bool { return summary.Fail > 0 }),
+		WithProbeCoalescing(func(ctx context.Context) string {
+			key, _ := ctx.Value(probeKeyCtx{}).(string)
+			return key
+		}),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	syncFeedCircuitBreakerHelper(cb, []error{errCall}, false)
+	time.Sleep(cb.cfg.openDuration + (time.Millisecond * 500))
+	require.Equal(t, HalfOpen, cb.stateCopy())
+
+	var downstream atomic.Int64
+	release := make(chan struct{})
+	probe := func(ctx context.Context) error {
+		downstream.Add(1)
+		<-release
+		return nil
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, 6)
+	wg.Add(len(errs))
+	for i := range errs {
+		key := "a"
+		if i%3 == 0 {
+			key = "b"
+		}
+		go func(i int, ctx context.Context) {
+			defer wg.Done()
+			errs[i] = cb.ExecuteContext(ctx, probe)
+		}(i, context.WithValue(context.Background(), probeKeyCtx{}, key))
+	}
+
+	time.Sleep(time.Millisecond * 100)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, make([]error, 6), errs)
+	assert.Equal(t, int64(2), downstream.Load())
+	assert.Equal(t, Counts{Total: 2, Success: 2}, cb.currentFrameCopy())
+	assert.Empty(t, cb.probes.calls)
+}
+
+func TestProbeGroupSharesPanic(t *testing.T) {
+	group := newProbeGroup(nil)
+	started, release := make(chan struct{}), make(chan struct{})
+
+	go func() {
+		defer func() { _ = recover() }()
+		_ = group.do(context.Background(), func() error {
+			close(started)
+			<-release
+			panic("boom")
+		})
+	}()
+	<-started
+
+	shared := make(chan error)
+	go func() {
+		shared <- group.do(context.Background(), func() error { return nil })
+	}()
+
+	time.Sleep(time.Millisecond * 50)
+	close(release)
+	assert.ErrorIs(t, <-shared, ErrCallPanic)
+}
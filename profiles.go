@@ -0,0 +1,180 @@
+package breaker
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrInvalidSchedule = errors.New("invalid schedule")
+
+// Profile Applies its thresholds during the minutes matching Schedule, a cron expression of
+// minute, hour, day of month, month and day of week fields supporting *, lists, ranges and
+// steps, such as "* 9-17 * * 1-5" for business hours. Both day fields must match.
+type Profile struct {
+	Schedule   string
+	Thresholds Thresholds
+}
+
+type schedule [5]map[int]bool
+
+var scheduleBounds = [5][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 7}}
+
+func parseSchedule(expr string) (schedule, error) {
+	var s schedule
+
+	fields := strings.Fields(expr)
+	if len(fields) != len(s) {
+		return s, fmt.Errorf("%w: %q must have %d fields", ErrInvalidSchedule, expr, len(s))
+	}
+
+	for i, field := range fields {
+		values, err := parseScheduleField(field, scheduleBounds[i][0], scheduleBounds[i][1])
+		if err != nil {
+			return s, fmt.Errorf("%w: %q: %w", ErrInvalidSchedule, expr, err)
+		}
+		s[i] = values
+	}
+
+	// Sunday is both 0 and 7.
+	if s[4][7] {
+		s[4][0] = true
+	}
+
+	return s, nil
+}
+
+func parseScheduleField(field string, min, max int) (map[int]bool, error) {
+	values := map[int]bool{}
+	for _, part := range strings.Split(field, ",") {
+		rng, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step <= 0 {
+				return nil, fmt.Errorf("bad step in %q", part)
+			}
+			rng = part[:i]
+		}
+
+		from, to := min, max
+		if rng != "*" {
+			bounds := strings.SplitN(rng, "-", 2)
+			var err error
+			if from, err = strconv.Atoi(bounds[0]); err != nil {
+				return nil, fmt.Errorf("bad value in %q", part)
+			}
+			to = from
+			if len(bounds) == 2 {
+				if to, err = strconv.Atoi(bounds[1]); err != nil {
+					return nil, fmt.Errorf("bad value in %q", part)
+				}
+			}
+		}
+		if from < min || to > max || from > to {
+			return nil, fmt.Errorf("%q out of %d-%d", part, min, max)
+		}
+
+		for v := from; v <= to; v += step {
+			values[v] = true
+		}
+	}
+
+	return values, nil
+}
+
+func (s schedule) matches(at time.Time) bool {
+	return s[0][at.Minute()] && s[1][at.Hour()] && s[2][at.Day()] && s[3][int(at.Month())] && s[4][int(at.Weekday())]
+}
+
+type scheduledProfile struct {
+	schedule   schedule
+	thresholds Thresholds
+}
+
+// profiles Reconfigures the breaker with the first profile matching the minute whenever
+// the matched profile changes, restoring the fallback thresholds when none matches.
+type profiles struct {
+	cb       *CircuitBreaker
+	profiles []scheduledProfile
+	// active Is the index of the profile matched last, -1 when none did.
+	active int
+	// applied Is the thresholds set last, telling a manual Reconfigure apart.
+	applied Thresholds
+	// fallback Is the thresholds restored when no profile matches, the constructor ones
+	// or the ones last set by hand.
+	fallback Thresholds
+	timer    Timer
+	stopped  bool
+
+	mu sync.Mutex
+}
+
+func newProfiles(cb *CircuitBreaker, scheduled []scheduledProfile) *profiles {
+	return &profiles{cb: cb, profiles: scheduled, active: -1}
+}
+
+func (p *profiles) start() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.cb.clock.Now()
+	p.apply(now)
+	p.timer = p.cb.clock.AfterFunc(untilNextMinute(now), p.tick)
+}
+
+func (p *profiles) tick() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.stopped {
+		return
+	}
+
+	now := p.cb.clock.Now()
+	p.apply(now)
+	p.timer.Reset(untilNextMinute(now))
+}
+
+// apply Reconfigures the breaker once the profile matching at differs from the one matched
+// last, first keeping the thresholds in effect as the fallback unless they are the ones a
+// profile set.
+func (p *profiles) apply(at time.Time) {
+	active := -1
+	for i, profile := range p.profiles {
+		if profile.schedule.matches(at) {
+			active = i
+			break
+		}
+	}
+	if active == p.active {
+		return
+	}
+
+	if current := p.cb.Thresholds(); p.active < 0 || current != p.applied {
+		p.fallback = current
+	}
+
+	thresholds := p.fallback
+	if active >= 0 {
+		thresholds = p.profiles[active].thresholds
+	}
+	p.active, p.applied = active, thresholds
+	_ = p.cb.Reconfigure(thresholds)
+}
+
+func (p *profiles) stop() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.stopped = true
+	if p.timer != nil {
+		p.timer.Stop()
+	}
+}
+
+func untilNextMinute(now time.Time) time.Duration {
+	return now.Truncate(time.Minute).Add(time.Minute).Sub(now)
+}
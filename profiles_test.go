@@ -0,0 +1,118 @@
+package breaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSchedule(t *testing.T) {
+	// Monday 2024-01-15.
+	monday := time.Date(2024, time.January, 15, 10, 30, 0, 0, time.UTC)
+	sunday := time.Date(2024, time.January, 14, 2, 0, 0, 0, time.UTC)
+
+	tt := []struct {
+		name     string
+		schedule string
+		at       time.Time
+		expected bool
+		err      bool
+	}{
+		{name: "every_minute", schedule: "* * * * *", at: monday, expected: true},
+		{name: "business_hours", schedule: "* 9-17 * * 1-5", at: monday, expected: true},
+		{name: "business_hours_on_sunday", schedule: "* 9-17 * * 1-5", at: sunday, expected: false},
+		{name: "nightly_batch", schedule: "* 0-4 * * *", at: sunday, expected: true},
+		{name: "sunday_as_seven", schedule: "* * * * 7", at: sunday, expected: true},
+		{name: "list", schedule: "0,30 * * * *", at: monday, expected: true},
+		{name: "step", schedule: "*/20 * * * *", at: monday, expected: false},
+		{name: "range_step", schedule: "0-30/15 * * * *", at: monday, expected: true},
+		{name: "month", schedule: "* * * 2 *", at: monday, expected: false},
+		{name: "too_few_fields", schedule: "* * * *", err: true},
+		{name: "out_of_range", schedule: "60 * * * *", err: true},
+		{name: "reversed_range", schedule: "* 17-9 * * *", err: true},
+		{name: "zero_step", schedule: "*/0 * * * *", err: true},
+		{name: "not_a_number", schedule: "* * * jan *", err: true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := parseSchedule(tc.schedule)
+			if tc.err {
+				assert.ErrorIs(t, err, ErrInvalidSchedule)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, s.matches(tc.at))
+		})
+	}
+}
+
+func TestBreakerProfiles(t *testing.T) {
+	business := Thresholds{FailureRate: 20, OpenDuration: time.Minute}
+	nightly := Thresholds{FailureRate: 90}
+
+	opts := &optionsConfiguration{}
+	require.NoError(t, WithProfiles([]Profile{
+		{Schedule: "* 9-17 * * 1-5", Thresholds: business},
+		{Schedule: "* 0-4 * * *", Thresholds: nightly},
+	})(opts))
+
+	cb, cancel, err := New()
+	require.NoError(t, err)
+	defer cancel()
+
+	p := newProfiles(cb, opts.profiles)
+
+	p.apply(time.Date(2024, time.January, 15, 10, 0, 0, 0, time.UTC))
+	assert.Equal(t, business, cb.Thresholds())
+
+	p.apply(time.Date(2024, time.January, 15, 2, 0, 0, 0, time.UTC))
+	assert.Equal(t, nightly, cb.Thresholds())
+
+	p.apply(time.Date(2024, time.January, 15, 20, 0, 0, 0, time.UTC))
+	assert.Equal(t, Thresholds{}, cb.Thresholds())
+
+	scheduled, cancel, err := New(WithProfiles([]Profile{{Schedule: "* * * * *", Thresholds: nightly}}))
+	require.NoError(t, err)
+	defer cancel()
+	assert.Equal(t, nightly, scheduled.Thresholds())
+
+	assert.Equal(t, 30*time.Second, untilNextMinute(time.Date(2024, time.January, 15, 20, 0, 30, 0, time.UTC)))
+}
+
+func TestBreakerProfilesManualReconfigure(t *testing.T) {
+	business := Thresholds{FailureRate: 20, OpenDuration: time.Minute}
+	manual := Thresholds{FailureRate: 50}
+	idle := Thresholds{FailureRate: 70}
+
+	opts := &optionsConfiguration{}
+	require.NoError(t, WithProfiles([]Profile{{Schedule: "* 9-17 * * 1-5", Thresholds: business}})(opts))
+
+	cb, cancel, err := New()
+	require.NoError(t, err)
+	defer cancel()
+
+	p := newProfiles(cb, opts.profiles)
+
+	p.apply(time.Date(2024, time.January, 15, 10, 0, 0, 0, time.UTC))
+	assert.Equal(t, business, cb.Thresholds())
+
+	require.NoError(t, cb.Reconfigure(manual))
+	p.apply(time.Date(2024, time.January, 15, 10, 1, 0, 0, time.UTC))
+	assert.Equal(t, manual, cb.Thresholds())
+
+	p.apply(time.Date(2024, time.January, 15, 20, 0, 0, 0, time.UTC))
+	assert.Equal(t, manual, cb.Thresholds())
+
+	require.NoError(t, cb.Reconfigure(idle))
+	p.apply(time.Date(2024, time.January, 15, 20, 1, 0, 0, time.UTC))
+	assert.Equal(t, idle, cb.Thresholds())
+
+	p.apply(time.Date(2024, time.January, 16, 10, 0, 0, 0, time.UTC))
+	assert.Equal(t, business, cb.Thresholds())
+
+	p.apply(time.Date(2024, time.January, 16, 20, 0, 0, 0, time.UTC))
+	assert.Equal(t, idle, cb.Thresholds())
+}
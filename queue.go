@@ -0,0 +1,99 @@
+package breaker
+
+import (
+	"sync"
+	"time"
+)
+
+type openQueue struct {
+	waiters []chan struct{}
+	max     int
+	maxWait time.Duration
+	clock   Clock
+
+	mu sync.Mutex
+}
+
+func newOpenQueue(max int, maxWait time.Duration, clock Clock) *openQueue {
+	if max <= 0 {
+		return nil
+	}
+
+	return &openQueue{
+		waiters: make([]chan struct{}, 0, max),
+		max:     max,
+		maxWait: maxWait,
+		clock:   clock,
+	}
+}
+
+// wait Blocks until the queue is released or maxWait elapses, isOpen is checked once
+// the waiter is enqueued so a release racing with the enqueue is never missed.
+func (q *openQueue) wait(isOpen func() bool) bool {
+	q.mu.Lock()
+	if len(q.waiters) >= q.max {
+		q.mu.Unlock()
+		return false
+	}
+	waiter := make(chan struct{})
+	q.waiters = append(q.waiters, waiter)
+	q.mu.Unlock()
+
+	if !isOpen() {
+		q.remove(waiter)
+		return true
+	}
+
+	expired := make(chan struct{})
+	timer := q.clock.AfterFunc(q.maxWait, func() { close(expired) })
+	defer timer.Stop()
+
+	select {
+	case <-waiter:
+		return true
+	case <-expired:
+		return !q.remove(waiter)
+	}
+}
+
+// remove Dequeues the waiter, reporting false when it was already released.
+func (q *openQueue) remove(waiter chan struct{}) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for i, w := range q.waiters {
+		if w == waiter {
+			q.waiters = append(q.waiters[:i], q.waiters[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// release Wakes every waiter in FIFO order.
+func (q *openQueue) release() {
+	if q == nil {
+		return
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for _, waiter := range q.waiters {
+		close(waiter)
+	}
+	q.waiters = q.waiters[:0]
+}
+
+func (c *CircuitBreaker) awaitOpenQueue(err error) error {
+	if c.openQueue == nil || err != ErrOpenCircuit {
+		return err
+	}
+
+	if !c.openQueue.wait(func() bool { return c.stateCopy() == Open }) {
+		return err
+	}
+
+	return c.canExecute()
+}
@@ -0,0 +1,62 @@
+package breaker
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreakerOpenQueueReleasesOnHalfOpen(t *testing.T) {
+	cb, cancel, err := New(
+		WithOpenDuration(1),
+		WithOpenQueue(2, time.Second*3),
+		WithCanTrip(func(summary Counts) bool { return summary.Fail > 0 }),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	syncFeedCircuitBreakerHelper(cb, []error{errCall}, false)
+	require.Equal(t, Open, cb.stateCopy())
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	wg.Add(3)
+	for i := 0; i < 3; i++ {
+		go func() {
+			defer wg.Done()
+			err := cb.Execute(fixtureCircuitCall(nil))
+			mu.Lock()
+			defer mu.Unlock()
+			errs = append(errs, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.ElementsMatch(t, []error{ErrOpenCircuit, nil, nil}, errs)
+	assert.Equal(t, HalfOpen, cb.stateCopy())
+}
+
+func TestBreakerOpenQueueTimesOut(t *testing.T) {
+	cb, cancel, err := New(
+		WithOpenDuration(2),
+		WithOpenQueue(1, time.Millisecond*100),
+		WithCanTrip(func(summary Counts) bool { return summary.Fail > 0 }),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	syncFeedCircuitBreakerHelper(cb, []error{errCall}, false)
+	require.Equal(t, Open, cb.stateCopy())
+
+	start := time.Now()
+	err = cb.Execute(fixtureCircuitCall(nil))
+	assert.ErrorIs(t, err, ErrOpenCircuit)
+	assert.GreaterOrEqual(t, time.Since(start), time.Millisecond*100)
+	assert.Empty(t, cb.openQueue.waiters)
+}
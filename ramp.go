@@ -0,0 +1,82 @@
+package breaker
+
+import (
+	"math/bits"
+	"sync"
+	"time"
+)
+
+// ramp Admits the half-open trial calls in doubling batches, one call per interval at
+// first, then two once it succeeded, then four, and closes the circuit once every step of
+// the ramp went through without a failure.
+type ramp struct {
+	interval time.Duration
+	steps    int
+	start    time.Time
+	limit    int
+	admitted int
+
+	mu sync.Mutex
+}
+
+// reset Starts the ramp over, used every time the circuit enters half-open.
+func (r *ramp) reset(now time.Time) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.start = now
+	r.limit = 1
+	r.admitted = 0
+}
+
+// take Admits a trial call while the current interval has room left. Each interval admits
+// the calls of the step its first call reached with successes, 2^k-1 of them reaching step k.
+func (r *ramp) take(now time.Time, successes uint64) bool {
+	if r == nil {
+		return true
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if now.Sub(r.start) >= r.interval {
+		step := min(bits.Len64(successes+1)-1, r.steps-1)
+		r.start = now
+		r.limit = 1 << step
+		r.admitted = 0
+	}
+
+	if r.admitted >= r.limit {
+		return false
+	}
+	r.admitted++
+	return true
+}
+
+// giveBack Returns the slot of a call take admitted but that was rejected after all.
+func (r *ramp) giveBack() {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.admitted = max(r.admitted-1, 0)
+}
+
+// evaluate Reopens the circuit on any trial failure and closes it once the trial calls of
+// every step succeeded.
+func (r *ramp) evaluate(summary Counts) State {
+	if summary.Fail > 0 {
+		return Open
+	}
+	if summary.Success >= (1<<r.steps)-1 {
+		return Closed
+	}
+	return HalfOpen
+}
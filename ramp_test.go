@@ -0,0 +1,70 @@
+package breaker
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreakerHalfOpenRamp(t *testing.T) {
+	cb, cancel, err := New(
+		WithOpenDuration(1),
+		WithHalfOpenRamp(200*time.Millisecond, 2),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	cb.Trip()
+	time.Sleep(cb.cfg.openDuration + (time.Millisecond * 100))
+	require.Equal(t, HalfOpen, cb.State())
+
+	assert.NoError(t, cb.Execute(fixtureCircuitCall(nil)))
+	assert.ErrorIs(t, cb.Execute(fixtureCircuitCall(nil)), ErrOpenCircuit)
+
+	time.Sleep(250 * time.Millisecond)
+	assert.NoError(t, cb.Execute(fixtureCircuitCall(nil)))
+	assert.NoError(t, cb.Execute(fixtureCircuitCall(nil)))
+	assert.Equal(t, Closed, cb.State())
+}
+
+func TestBreakerHalfOpenRampReopens(t *testing.T) {
+	cb, cancel, err := New(
+		WithOpenDuration(1),
+		WithHalfOpenRamp(time.Millisecond, 3),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	cb.Trip()
+	time.Sleep(cb.cfg.openDuration + (time.Millisecond * 100))
+	require.Equal(t, HalfOpen, cb.State())
+
+	assert.NoError(t, cb.Execute(fixtureCircuitCall(nil)))
+	assert.Equal(t, HalfOpen, cb.State())
+	time.Sleep(5 * time.Millisecond)
+	assert.Error(t, cb.Execute(fixtureCircuitCall(errors.New("failed"))))
+	assert.Equal(t, Open, cb.State())
+}
+
+func TestRampTake(t *testing.T) {
+	now := time.Now()
+	r := &ramp{interval: time.Second, steps: 3}
+	r.reset(now)
+
+	assert.True(t, r.take(now, 0))
+	assert.False(t, r.take(now, 0))
+
+	now = now.Add(time.Second)
+	assert.True(t, r.take(now, 1))
+	assert.True(t, r.take(now, 1))
+	assert.False(t, r.take(now, 3))
+
+	now = now.Add(time.Second)
+	for i := 0; i < 4; i++ {
+		assert.True(t, r.take(now, 3))
+	}
+	assert.False(t, r.take(now, 100))
+}
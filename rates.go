@@ -0,0 +1,39 @@
+package breaker
+
+// FailureRate Returns the percentage of failed calls, zero when no call was accounted.
+func (c Counts) FailureRate() float64 {
+	return rate(c.Fail, c.Total)
+}
+
+// SuccessRate Returns the percentage of successful calls, zero when no call was accounted.
+func (c Counts) SuccessRate() float64 {
+	return rate(c.Success, c.Total)
+}
+
+func rate(part, total uint64) float64 {
+	if total == 0 {
+		return 0
+	}
+
+	return (float64(part) / float64(total)) * 100
+}
+
+// FailureRate Returns the percentage of failed calls over the rolling window.
+func (c *CircuitBreaker) FailureRate() float64 {
+	return c.summaryCopy().FailureRate()
+}
+
+// SuccessRate Returns the percentage of successful calls over the rolling window.
+func (c *CircuitBreaker) SuccessRate() float64 {
+	return c.summaryCopy().SuccessRate()
+}
+
+// FrameFailureRate Returns the percentage of failed calls over the current frame.
+func (c *CircuitBreaker) FrameFailureRate() float64 {
+	return c.currentFrameCopy().FailureRate()
+}
+
+// FrameSuccessRate Returns the percentage of successful calls over the current frame.
+func (c *CircuitBreaker) FrameSuccessRate() float64 {
+	return c.currentFrameCopy().SuccessRate()
+}
@@ -0,0 +1,55 @@
+package breaker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountsRates(t *testing.T) {
+	tt := []struct {
+		name            string
+		input           Counts
+		expectedFailure float64
+		expectedSuccess float64
+	}{
+		{
+			name:            "zero_without_calls",
+			input:           Counts{},
+			expectedFailure: 0,
+			expectedSuccess: 0,
+		},
+		{
+			name:            "rates_over_total",
+			input:           Counts{Total: 4, Fail: 1, Success: 3},
+			expectedFailure: 25,
+			expectedSuccess: 75,
+		},
+		{
+			name:            "slow_calls_count_toward_total",
+			input:           Counts{Total: 4, Fail: 1, Success: 2, Slow: 1},
+			expectedFailure: 25,
+			expectedSuccess: 50,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedFailure, tc.input.FailureRate())
+			assert.Equal(t, tc.expectedSuccess, tc.input.SuccessRate())
+		})
+	}
+}
+
+func TestBreakerRates(t *testing.T) {
+	cb, cancel, err := New()
+	require.NoError(t, err)
+	defer cancel()
+
+	syncFeedCircuitBreakerHelper(cb, []error{errCall, nil, nil, nil}, false)
+
+	assert.Equal(t, float64(25), cb.FailureRate())
+	assert.Equal(t, float64(75), cb.SuccessRate())
+	assert.Equal(t, float64(25), cb.FrameFailureRate())
+	assert.Equal(t, float64(75), cb.FrameSuccessRate())
+}
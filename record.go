@@ -0,0 +1,27 @@
+package breaker
+
+// RecordSuccess Feeds a success obtained outside Execute, such as from an async callback,
+// into the window, driving the state transitions a guarded call would.
+func (c *CircuitBreaker) RecordSuccess() {
+	c.record(OutcomeSuccess, nil)
+}
+
+// RecordFailure Feeds a failure obtained outside Execute into the window, keeping err in the
+// error history when not nil.
+func (c *CircuitBreaker) RecordFailure(err error) {
+	c.record(OutcomeFailure, err)
+}
+
+// record Accounts an outcome as a guarded call would, unless the circuit is open, when the
+// outcome likely belongs to a call started before the trip, or the breaker is paused.
+func (c *CircuitBreaker) record(outcome Outcome, err error) {
+	if v := c.load(); v.state == Open || v.mode == ModePaused {
+		return
+	}
+
+	c.account(outcome, nil, 1, nil)
+	if outcome == OutcomeFailure && err != nil {
+		c.recordError(err)
+	}
+	c.settle()
+}
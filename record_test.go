@@ -0,0 +1,39 @@
+package breaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreakerRecord(t *testing.T) {
+	cb, cancel, err := New(
+		WithCanTrip(func(summary Counts) bool { return summary.Fail > 1 }),
+		WithOpenDuration(1),
+		WithHalfOpenEvaluation(1, 1),
+		WithErrorHistory(5),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	cb.RecordSuccess()
+	cb.RecordFailure(errCall)
+	assert.Equal(t, Closed, cb.State())
+	assert.Equal(t, Counts{Total: 2, Fail: 1, Success: 1}, cb.Counts())
+	require.Len(t, cb.RecentErrors(), 1)
+	assert.ErrorIs(t, cb.RecentErrors()[0].Err, errCall)
+
+	cb.RecordFailure(nil)
+	assert.Equal(t, Open, cb.State())
+	assert.Len(t, cb.RecentErrors(), 1)
+
+	cb.RecordFailure(errCall)
+	assert.Equal(t, Counts{Total: 3, Fail: 2, Success: 1}, cb.Counts())
+
+	time.Sleep(cb.cfg.openDuration + (time.Millisecond * 200))
+	require.Equal(t, HalfOpen, cb.State())
+	cb.RecordSuccess()
+	assert.Equal(t, Closed, cb.State())
+}
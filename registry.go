@@ -0,0 +1,131 @@
+package breaker
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Registry Indexes breakers by name for the tooling that manages them across a service.
+type Registry struct {
+	breakers map[string]*CircuitBreaker
+
+	mu sync.RWMutex
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		breakers: make(map[string]*CircuitBreaker),
+	}
+}
+
+func (r *Registry) Register(cb *CircuitBreaker) error {
+	if cb.name == "" {
+		return ErrUnnamedBreaker
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.breakers[cb.name]; ok {
+		return fmt.Errorf("%w: %s", ErrDuplicateBreaker, cb.name)
+	}
+	r.breakers[cb.name] = cb
+
+	return nil
+}
+
+func (r *Registry) Get(name string) (*CircuitBreaker, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	cb, ok := r.breakers[name]
+	return cb, ok
+}
+
+func (r *Registry) Remove(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.breakers, name)
+}
+
+// List Returns the registered breakers sorted by name.
+func (r *Registry) List() []*CircuitBreaker {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	breakers := make([]*CircuitBreaker, 0, len(r.breakers))
+	for _, cb := range r.breakers {
+		breakers = append(breakers, cb)
+	}
+	sort.Slice(breakers, func(i, j int) bool { return breakers[i].name < breakers[j].name })
+
+	return breakers
+}
+
+const _worstFailureRates = 5
+
+// RegistrySummary Answers at a glance whether anything is tripped across the registry.
+type RegistrySummary struct {
+	Breakers int    `json:"breakers"`
+	Counts   Counts `json:"counts"`
+	InFlight int64  `json:"in_flight"`
+	// Open Lists the names of the breakers currently open, sorted.
+	Open []string `json:"open"`
+	// HalfOpen Lists the names of the breakers currently probing, sorted.
+	HalfOpen []string `json:"half_open"`
+	// WorstFailureRates Ranks the breakers with failures by their failure rate, worst first.
+	WorstFailureRates []BreakerFailureRate `json:"worst_failure_rates"`
+}
+
+type BreakerFailureRate struct {
+	Name        string  `json:"name"`
+	FailureRate float64 `json:"failure_rate"`
+}
+
+// AggregateCounts Sums the window counts of every registered breaker.
+func (r *Registry) AggregateCounts() Counts {
+	total := Counts{}
+	for _, cb := range r.List() {
+		total = addCounts(total, cb.Counts())
+	}
+	return total
+}
+
+func (r *Registry) Summary() RegistrySummary {
+	breakers := r.List()
+	summary := RegistrySummary{
+		Breakers:          len(breakers),
+		Open:              []string{},
+		HalfOpen:          []string{},
+		WorstFailureRates: []BreakerFailureRate{},
+	}
+
+	for _, cb := range breakers {
+		counts := cb.Counts()
+		summary.Counts = addCounts(summary.Counts, counts)
+		summary.InFlight += cb.InFlight()
+
+		switch cb.load().state {
+		case Open:
+			summary.Open = append(summary.Open, cb.name)
+		case HalfOpen:
+			summary.HalfOpen = append(summary.HalfOpen, cb.name)
+		}
+
+		if counts.Fail > 0 {
+			summary.WorstFailureRates = append(summary.WorstFailureRates, BreakerFailureRate{
+				Name:        cb.name,
+				FailureRate: counts.FailureRate(),
+			})
+		}
+	}
+
+	sort.SliceStable(summary.WorstFailureRates, func(i, j int) bool {
+		return summary.WorstFailureRates[i].FailureRate > summary.WorstFailureRates[j].FailureRate
+	})
+	if len(summary.WorstFailureRates) > _worstFailureRates {
+		summary.WorstFailureRates = summary.WorstFailureRates[:_worstFailureRates]
+	}
+
+	return summary
+}
@@ -0,0 +1,116 @@
+package breaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	registry := NewRegistry()
+
+	unnamed, cancel, err := New()
+	require.NoError(t, err)
+	defer cancel()
+	assert.ErrorIs(t, registry.Register(unnamed), ErrUnnamedBreaker)
+
+	names := []string{"payments", "orders", "inventory"}
+	for _, name := range names {
+		cb, cancel, err := New(WithName(name))
+		require.NoError(t, err)
+		defer cancel()
+		require.NoError(t, registry.Register(cb))
+	}
+
+	duplicate, cancel, err := New(WithName("orders"))
+	require.NoError(t, err)
+	defer cancel()
+	assert.ErrorIs(t, registry.Register(duplicate), ErrDuplicateBreaker)
+
+	cb, ok := registry.Get("orders")
+	assert.True(t, ok)
+	assert.Equal(t, "orders", cb.Name())
+
+	registry.Remove("orders")
+	_, ok = registry.Get("orders")
+	assert.False(t, ok)
+
+	got := []string{}
+	for _, cb := range registry.List() {
+		got = append(got, cb.Name())
+	}
+	assert.Equal(t, []string{"inventory", "payments"}, got)
+}
+
+func TestRegistrySummary(t *testing.T) {
+	registry := NewRegistry()
+	assert.Equal(t, RegistrySummary{Open: []string{}, HalfOpen: []string{}, WorstFailureRates: []BreakerFailureRate{}}, registry.Summary())
+
+	feeds := map[string][]error{
+		"payments":  {errCall, errCall, nil, nil},
+		"orders":    {errCall, nil, nil, nil},
+		"inventory": {nil, nil},
+		"search":    {errCall},
+		"shipping":  {errCall, nil},
+		"reviews":   {errCall, nil, nil, nil, nil},
+		"users":     {errCall, nil, nil, nil, nil, nil, nil, nil, nil, nil},
+	}
+	for name, calls := range feeds {
+		cb, cancel, err := New(WithName(name))
+		require.NoError(t, err)
+		defer cancel()
+		require.NoError(t, registry.Register(cb))
+		syncFeedCircuitBreakerHelper(cb, calls, false)
+	}
+
+	search, _ := registry.Get("search")
+	search.Trip()
+	shipping, _ := registry.Get("shipping")
+	shipping.Trip()
+	shipping.ProbeNow()
+
+	assert.Equal(t, Counts{Total: 28, Fail: 7, Success: 21}, registry.AggregateCounts())
+	assert.Equal(t, RegistrySummary{
+		Breakers: 7,
+		Counts:   Counts{Total: 28, Fail: 7, Success: 21},
+		Open:     []string{"search"},
+		HalfOpen: []string{"shipping"},
+		WorstFailureRates: []BreakerFailureRate{
+			{Name: "search", FailureRate: 100},
+			{Name: "payments", FailureRate: 50},
+			{Name: "shipping", FailureRate: 50},
+			{Name: "orders", FailureRate: 25},
+			{Name: "reviews", FailureRate: 20},
+		},
+	}, registry.Summary())
+}
+
+func TestRegistrySummaryExpiresStaleFrames(t *testing.T) {
+	registry := NewRegistry()
+	cb, cancel, err := New(
+		WithName("payments"),
+		WithCanTrip(func(summary Counts) bool { return false }),
+		WithWindowFrameThreshold(10),
+		WithWindowRollThreshold(30),
+	)
+	require.NoError(t, err)
+	defer cancel()
+	require.NoError(t, registry.Register(cb))
+
+	syncFeedCircuitBreakerHelper(cb, []error{errCall, nil}, false)
+	cb.moveWindow()
+	assert.Equal(t, Counts{Total: 2, Fail: 1, Success: 1}, registry.Summary().Counts)
+
+	// Pretend the rotation stalled for over a roll since the last frame started.
+	cb.rollingWindow.mu.Lock()
+	for i := range cb.rollingWindow.window {
+		cb.rollingWindow.window[i].start = cb.rollingWindow.window[i].start.Add(-35 * time.Second)
+	}
+	cb.rollingWindow.mu.Unlock()
+
+	summary := registry.Summary()
+	assert.Equal(t, Counts{}, summary.Counts)
+	assert.Empty(t, summary.WorstFailureRates)
+}
@@ -0,0 +1,73 @@
+package breaker
+
+import (
+	"errors"
+	"time"
+)
+
+// Rejection Describes the breaker turning a call away, read from its published state so
+// building one on every rejection stays cheap.
+type Rejection struct {
+	Name    string
+	State   State
+	Mode    Mode
+	Summary Counts
+	Frame   Counts
+	// OpenUntil Is when the open circuit lets calls through again, zero when it isn't
+	// waiting on its open duration.
+	OpenUntil time.Time
+}
+
+type rejectionError struct {
+	err error
+}
+
+func (r *rejectionError) Error() string {
+	return r.err.Error()
+}
+
+func (r *rejectionError) Unwrap() []error {
+	return []error{r.err, ErrOpenCircuit}
+}
+
+// rejection Accounts the time saved by short-circuiting and replaces ErrOpenCircuit with
+// the configured rejection error, keeping it detectable through errors.Is.
+func (c *CircuitBreaker) rejection(err error) error {
+	if err != ErrOpenCircuit {
+		return err
+	}
+
+	c.timeSaved.add(c.latencyMean())
+	c.reject()
+
+	if c.rejectionError == nil {
+		return err
+	}
+
+	v := c.load()
+	custom := c.rejectionError(Rejection{
+		Name:      c.name,
+		State:     v.state,
+		Mode:      v.mode,
+		Summary:   v.summary,
+		Frame:     v.frame,
+		OpenUntil: v.halfOpenAt,
+	})
+	if custom == nil {
+		return err
+	}
+
+	return &rejectionError{err: custom}
+}
+
+// IsRejection Tells whether err is the breaker turning a call away without running it, as
+// it does on an open circuit, when shedding it, when its deadline is too short or once shut
+// down. ErrProbeCoalesced counts too, the transport bindings answer with it the calls that
+// shared the result of a half-open probe without running their own handler.
+func IsRejection(err error) bool {
+	return errors.Is(err, ErrOpenCircuit) ||
+		errors.Is(err, ErrCallShed) ||
+		errors.Is(err, ErrInsufficientDeadline) ||
+		errors.Is(err, ErrShutdown) ||
+		errors.Is(err, ErrProbeCoalesced)
+}
@@ -0,0 +1,62 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errUnavailable = errors.New("service unavailable")
+
+func TestBreakerRejectionError(t *testing.T) {
+	var got Rejection
+	cb, cancel, err := New(
+		WithName("payments"),
+		WithRejectionError(func(rejection Rejection) error {
+			got = rejection
+			return errUnavailable
+		}),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	assert.NoError(t, cb.Execute(fixtureCircuitCall(nil)))
+
+	cb.Trip()
+	err = cb.Execute(fixtureCircuitCall(nil))
+	assert.ErrorIs(t, err, ErrOpenCircuit)
+	assert.ErrorIs(t, err, errUnavailable)
+	assert.Equal(t, errUnavailable.Error(), err.Error())
+	assert.Equal(t, "payments", got.Name)
+	assert.Equal(t, Open, got.State)
+	assert.Equal(t, Counts{Total: 1, Success: 1}, got.Summary)
+	assert.WithinDuration(t, cb.clock.Now().Add(cb.cfg.openDuration), got.OpenUntil, time.Second)
+
+	err = cb.ExecuteAsync(fixtureCircuitCall(nil)).Wait(context.Background())
+	assert.ErrorIs(t, err, ErrOpenCircuit)
+	assert.ErrorIs(t, err, errUnavailable)
+}
+
+func TestBreakerRejectionErrorNil(t *testing.T) {
+	cb, cancel, err := New(
+		WithRejectionError(func(Rejection) error { return nil }),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	cb.Trip()
+	assert.Equal(t, ErrOpenCircuit, cb.Execute(fixtureCircuitCall(nil)))
+}
+
+func TestIsRejection(t *testing.T) {
+	for _, err := range []error{ErrOpenCircuit, ErrCallShed, ErrInsufficientDeadline, ErrShutdown, &rejectionError{err: errors.New("custom")}} {
+		assert.True(t, IsRejection(fmt.Errorf("wrapped: %w", err)), err)
+	}
+	assert.False(t, IsRejection(errCall))
+	assert.False(t, IsRejection(nil))
+}
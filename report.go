@@ -0,0 +1,34 @@
+package breaker
+
+import (
+	"context"
+	"time"
+)
+
+// Report Describes how the breaker handled a call, for telemetry finer than its error.
+type Report struct {
+	// State Is the breaker state when the call arrived.
+	State State
+	// ShortCircuited Tells the call was rejected without running.
+	ShortCircuited bool
+	// Duration Is how long the call ran, zero when short-circuited or passed through a
+	// paused breaker.
+	Duration time.Duration
+	// Outcome Is how the call was counted, empty when it wasn't.
+	Outcome Outcome
+	// Retried Tells an earlier call on the same context went through the breaker before this
+	// one, as when a client retries the request.
+	Retried bool
+	// FellBack Tells a fallback or a stale value answered in place of the call.
+	FellBack bool
+}
+
+// ExecuteReport Runs fn like Execute, reporting how the breaker handled it.
+func (c *CircuitBreaker) ExecuteReport(fn func() error) (Report, error) {
+	report := Report{ShortCircuited: true}
+	err := c.execute(context.Background(), callOptions{priority: Normal, report: &report}, func(context.Context) error {
+		return fn()
+	})
+
+	return report, err
+}
@@ -0,0 +1,38 @@
+package breaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreakerExecuteReport(t *testing.T) {
+	cb, cancel, err := New(WithCanTrip(func(summary Counts) bool { return summary.Fail > 0 }))
+	require.NoError(t, err)
+	defer cancel()
+
+	report, err := cb.ExecuteReport(func() error {
+		time.Sleep(time.Millisecond * 5)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, Closed, report.State)
+	assert.False(t, report.ShortCircuited)
+	assert.Equal(t, OutcomeSuccess, report.Outcome)
+	assert.GreaterOrEqual(t, report.Duration, time.Millisecond*5)
+
+	report, err = cb.ExecuteReport(fixtureCircuitCall(errCall))
+	assert.ErrorIs(t, err, errCall)
+	assert.Equal(t, Report{State: Closed, Duration: report.Duration, Outcome: OutcomeFailure}, report)
+
+	report, err = cb.ExecuteReport(fixtureCircuitCall(nil))
+	assert.ErrorIs(t, err, ErrOpenCircuit)
+	assert.Equal(t, Report{State: Open, ShortCircuited: true}, report)
+
+	cb.Pause()
+	report, err = cb.ExecuteReport(fixtureCircuitCall(nil))
+	assert.NoError(t, err)
+	assert.Equal(t, Report{State: Open}, report)
+}
@@ -0,0 +1,163 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/publicsuffix"
+)
+
+var ErrIPLiteral = errors.New("host is an ip literal")
+
+// HostResolver Is the part of net.Resolver the Resolver guards.
+type HostResolver interface {
+	LookupHost(ctx context.Context, host string) ([]string, error)
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+// Resolver Guards DNS lookups with a breaker per zone, so a failing nameserver makes lookups
+// fail fast instead of every caller waiting out its timeouts. While a zone breaker rejects
+// lookups, the answers obtained within the stale window are served in place of the rejection.
+// IP literals need no nameserver, they are looked up without a breaker.
+type Resolver struct {
+	resolver HostResolver
+	breakers *breakerSet
+	stale    time.Duration
+	answers  map[string]answer
+
+	mu sync.RWMutex
+}
+
+type answer struct {
+	value any
+	at    time.Time
+}
+
+// NewResolver Wraps resolver, net.DefaultResolver when nil, creating every zone breaker from
+// opts and naming it after the zone. Answers up to stale old are served while open, none when
+// zero.
+func NewResolver(resolver HostResolver, stale time.Duration, opts ...option) *Resolver {
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+
+	return &Resolver{
+		resolver: resolver,
+		breakers: newBreakerSet(opts),
+		stale:    stale,
+		answers:  make(map[string]answer),
+	}
+}
+
+func (r *Resolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	addrs, err := lookup(ctx, r, "host:", host, r.resolver.LookupHost)
+	if err != nil {
+		return nil, err
+	}
+	return addrs, nil
+}
+
+func (r *Resolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	addrs, err := lookup(ctx, r, "ip:", host, r.resolver.LookupIPAddr)
+	if err != nil {
+		return nil, err
+	}
+	return addrs, nil
+}
+
+// lookup Runs fn through the host zone breaker, keeping its answer to serve while it rejects
+// lookups. A missing host is an answer from a healthy nameserver, so it doesn't count as a
+// failure.
+func lookup[T any](ctx context.Context, r *Resolver, kind, host string, fn func(ctx context.Context, host string) (T, error)) (T, error) {
+	var value T
+	cb, err := r.Breaker(host)
+	if errors.Is(err, ErrIPLiteral) {
+		return fn(ctx, host)
+	}
+	if err != nil {
+		return value, err
+	}
+
+	err = cb.ExecuteContext(ctx, func(ctx context.Context) error {
+		var err error
+		value, err = fn(ctx, host)
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			return Classified(OutcomeSuccess, err)
+		}
+		return err
+	})
+
+	switch {
+	case err == nil:
+		r.keep(kind+host, value, cb.clock.Now())
+	case IsRejection(err):
+		if kept, ok := r.kept(kind+host, cb.clock.Now()); ok {
+			return kept.(T), nil
+		}
+	}
+
+	return value, err
+}
+
+func (r *Resolver) keep(key string, value any, now time.Time) {
+	if r.stale <= 0 {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.answers[key] = answer{value: value, at: now}
+}
+
+func (r *Resolver) kept(key string, now time.Time) (any, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	a, ok := r.answers[key]
+	if !ok || now.Sub(a.at) > r.stale {
+		return nil, false
+	}
+	return a.value, true
+}
+
+// Breaker Returns the breaker guarding the lookups of host, shared by its zone and created
+// on first use, ErrIPLiteral for the IP literals which have none.
+func (r *Resolver) Breaker(host string) (*CircuitBreaker, error) {
+	z, ok := zone(host)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrIPLiteral, host)
+	}
+	return r.breakers.get(z)
+}
+
+// Breakers Returns the breakers of every zone looked up so far sorted by name.
+func (r *Resolver) Breakers() []*CircuitBreaker {
+	return r.breakers.list()
+}
+
+// Close Stops the breaker of every zone.
+func (r *Resolver) Close() {
+	r.breakers.close()
+}
+
+// zone Returns the registrable domain of host, such as example.co.uk for api.example.co.uk,
+// which the names served by the same nameservers usually share. A host without one, such as
+// localhost, is its own zone, and IP literals have none.
+func zone(host string) (string, bool) {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if _, err := netip.ParseAddr(host); err == nil {
+		return "", false
+	}
+
+	if z, err := publicsuffix.EffectiveTLDPlusOne(host); err == nil {
+		return z, true
+	}
+	return host, true
+}
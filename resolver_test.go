@@ -0,0 +1,125 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeResolver struct {
+	err     error
+	lookups map[string]int
+}
+
+func (f *fakeResolver) LookupHost(_ context.Context, host string) ([]string, error) {
+	f.lookups[host]++
+	if f.err != nil {
+		return nil, f.err
+	}
+	return []string{"10.0.0.1"}, nil
+}
+
+func (f *fakeResolver) LookupIPAddr(_ context.Context, host string) ([]net.IPAddr, error) {
+	f.lookups[host]++
+	if f.err != nil {
+		return nil, f.err
+	}
+	return []net.IPAddr{{IP: net.IPv4(10, 0, 0, 1)}}, nil
+}
+
+func TestResolver(t *testing.T) {
+	fake := &fakeResolver{lookups: map[string]int{}}
+	r := NewResolver(fake, time.Minute, WithCanTrip(func(summary Counts) bool { return summary.Fail >= 2 }))
+	defer r.Close()
+
+	addrs, err := r.LookupHost(context.Background(), "api.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.1"}, addrs)
+
+	fake.err = &net.DNSError{Err: "no such host", Name: "gone.example.com", IsNotFound: true}
+	_, err = r.LookupHost(context.Background(), "gone.example.com")
+	assert.Error(t, err)
+
+	fake.err = &net.DNSError{Err: "i/o timeout", IsTimeout: true}
+	for i := 0; i < 2; i++ {
+		_, err = r.LookupIPAddr(context.Background(), "db.example.com")
+		assert.Error(t, err)
+	}
+
+	cb, err := r.Breaker("www.example.com.")
+	require.NoError(t, err)
+	assert.Equal(t, "example.com", cb.Name())
+	require.Equal(t, Open, cb.State())
+
+	addrs, err = r.LookupHost(context.Background(), "api.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.1"}, addrs)
+	assert.Equal(t, 1, fake.lookups["api.example.com"])
+
+	_, err = r.LookupIPAddr(context.Background(), "api.example.com")
+	assert.ErrorIs(t, err, ErrOpenCircuit)
+
+	_, err = r.LookupHost(context.Background(), "other.org")
+	assert.True(t, errors.As(err, new(*net.DNSError)))
+}
+
+func TestResolverRejections(t *testing.T) {
+	fake := &fakeResolver{lookups: map[string]int{}}
+	r := NewResolver(fake, time.Minute)
+	defer r.Close()
+
+	_, err := r.LookupHost(context.Background(), "api.example.com")
+	require.NoError(t, err)
+
+	cb, err := r.Breaker("api.example.com")
+	require.NoError(t, err)
+	require.NoError(t, cb.Shutdown(context.Background()))
+
+	addrs, err := r.LookupHost(context.Background(), "api.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.1"}, addrs)
+	assert.Equal(t, 1, fake.lookups["api.example.com"])
+}
+
+func TestResolverIPLiteral(t *testing.T) {
+	fake := &fakeResolver{lookups: map[string]int{}}
+	r := NewResolver(fake, time.Minute)
+	defer r.Close()
+
+	_, err := r.Breaker("10.0.0.1")
+	assert.ErrorIs(t, err, ErrIPLiteral)
+
+	addrs, err := r.LookupHost(context.Background(), "10.0.0.1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.1"}, addrs)
+	assert.Empty(t, r.Breakers())
+}
+
+func TestZone(t *testing.T) {
+	tt := []struct {
+		host     string
+		expected string
+		ok       bool
+	}{
+		{host: "api.eu.example.com", expected: "example.com", ok: true},
+		{host: "Example.COM.", expected: "example.com", ok: true},
+		{host: "api.example.co.uk", expected: "example.co.uk", ok: true},
+		{host: "shop.other.co.uk", expected: "other.co.uk", ok: true},
+		{host: "localhost", expected: "localhost", ok: true},
+		{host: "192.168.0.1", ok: false},
+		{host: "fe80::1%eth0", ok: false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.host, func(t *testing.T) {
+			z, ok := zone(tc.host)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.expected, z)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package breaker
+
+import "sync"
+
+type ring[T any] struct {
+	items []T
+	next  int
+	full  bool
+
+	mu sync.RWMutex
+}
+
+func newRing[T any](size int) *ring[T] {
+	if size <= 0 {
+		return nil
+	}
+
+	return &ring[T]{
+		items: make([]T, size),
+	}
+}
+
+func (r *ring[T]) push(item T) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.items[r.next] = item
+	r.next = (r.next + 1) % len(r.items)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// copy Returns the ring items from the oldest to the newest.
+func (r *ring[T]) copy() []T {
+	if r == nil {
+		return nil
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if !r.full {
+		return append(make([]T, 0, r.next), r.items[:r.next]...)
+	}
+
+	items := make([]T, 0, len(r.items))
+	items = append(items, r.items[r.next:]...)
+	return append(items, r.items[:r.next]...)
+}
+
+func (r *ring[T]) last() (item T, ok bool) {
+	if r == nil {
+		return item, false
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if !r.full && r.next == 0 {
+		return item, false
+	}
+
+	return r.items[(r.next+len(r.items)-1)%len(r.items)], true
+}
@@ -0,0 +1,60 @@
+package breaker
+
+import (
+	"errors"
+	"path"
+)
+
+// Rule Overrides thresholds of the breakers whose name matches Match, a path.Match pattern
+// such as "payments.*", zero fields keep the breaker own settings.
+type Rule struct {
+	Match string
+	// FailureRate Is the percentage of failed calls over the window tripping the circuit.
+	FailureRate float64
+	// OpenDuration Is how many seconds the circuit stays open before probing.
+	OpenDuration int
+}
+
+func (r Rule) validate() error {
+	if r.Match == "" {
+		return errors.New("rule has no pattern")
+	}
+	if _, err := path.Match(r.Match, ""); err != nil {
+		return err
+	}
+	if r.FailureRate < 0 || r.FailureRate > 100 {
+		return errors.New("failure rate must be between 0 and 100")
+	}
+	if r.OpenDuration < 0 {
+		return errors.New("open duration can't be less than zero")
+	}
+
+	return nil
+}
+
+func (r Rule) matches(name string) bool {
+	ok, _ := path.Match(r.Match, name)
+	return ok
+}
+
+// applyRules Applies the first rule matching the breaker name, once every option is known
+// so rules win over the options they were given alongside.
+func (opt *optionsConfiguration) applyRules() {
+	for _, rule := range opt.rules {
+		if !rule.matches(opt.name) {
+			continue
+		}
+
+		if rule.FailureRate > 0 {
+			rate := rule.FailureRate
+			opt.canTrip = func(summary Counts) bool {
+				return summary.Total > _minimumVolume && summary.FailureRate() >= rate
+			}
+		}
+		if rule.OpenDuration > 0 {
+			opt.openDuration = rule.OpenDuration
+		}
+
+		return
+	}
+}
@@ -0,0 +1,61 @@
+package breaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRules(t *testing.T) {
+	rules := WithRules([]Rule{
+		{Match: "payments.*", FailureRate: 30, OpenDuration: 5},
+		{Match: "*", OpenDuration: 90},
+	})
+
+	tt := []struct {
+		name         string
+		failures     int
+		expected     State
+		openDuration time.Duration
+	}{
+		{name: "payments.charge", failures: 4, expected: Open, openDuration: 5 * time.Second},
+		{name: "search.query", failures: 4, expected: Closed, openDuration: 90 * time.Second},
+		{name: "search.query", failures: 8, expected: Open, openDuration: 90 * time.Second},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			cb, cancel, err := New(rules, WithName(tc.name))
+			require.NoError(t, err)
+			defer cancel()
+
+			calls := make([]error, 0, 12)
+			for i := 0; i < 12; i++ {
+				if i < tc.failures {
+					calls = append(calls, errCall)
+					continue
+				}
+				calls = append(calls, nil)
+			}
+			syncFeedCircuitBreakerHelper(cb, calls, false)
+
+			assert.Equal(t, tc.expected, cb.State())
+			assert.Equal(t, tc.openDuration, cb.Snapshot().Config.OpenDuration)
+		})
+	}
+}
+
+func TestRulesThroughManager(t *testing.T) {
+	m := NewManager(WithRules([]Rule{{Match: "*/payments.*", OpenDuration: 5}}))
+	defer m.Close()
+
+	payments, err := m.Get("acme", "payments.charge")
+	require.NoError(t, err)
+	search, err := m.Get("acme", "search.query")
+	require.NoError(t, err)
+
+	assert.Equal(t, 5*time.Second, payments.Snapshot().Config.OpenDuration)
+	assert.Equal(t, _openDuration*time.Second, search.Snapshot().Config.OpenDuration)
+}
@@ -0,0 +1,44 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var ErrRunSkipped = errors.New("scheduled run skipped")
+
+// Scheduled Wraps a periodic task, such as a cron job, so its runs are skipped while the
+// circuit is open rather than piling onto a down dependency, counting them in the snapshot.
+// When probe isn't nil it runs in place of the skipped task, outside the breaker, and a
+// success moves the circuit to half-open so the task runs as the trial call. A skipped run
+// error wraps ErrRunSkipped and ErrOpenCircuit.
+func (c *CircuitBreaker) Scheduled(task, probe func(ctx context.Context) error) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		if c.load().state == Open {
+			if probe == nil {
+				return c.skipRun(ErrOpenCircuit)
+			}
+			if err := probe(ctx); err != nil {
+				return c.skipRun(fmt.Errorf("%w: probe failed: %w", ErrOpenCircuit, err))
+			}
+			c.ProbeNow()
+		}
+
+		err := c.ExecuteContext(ctx, task)
+		if errors.Is(err, ErrOpenCircuit) {
+			return c.skipRun(err)
+		}
+		return err
+	}
+}
+
+func (c *CircuitBreaker) skipRun(err error) error {
+	c.skippedRuns.Add(1)
+	return fmt.Errorf("%w: %w", ErrRunSkipped, err)
+}
+
+// SkippedRuns Returns how many scheduled runs were skipped because the circuit was open.
+func (c *CircuitBreaker) SkippedRuns() uint64 {
+	return c.skippedRuns.Load()
+}
@@ -0,0 +1,39 @@
+package breaker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreakerScheduled(t *testing.T) {
+	cb, cancel, err := New(WithOpenDuration(30), WithHalfOpenEvaluation(1, 1))
+	require.NoError(t, err)
+	defer cancel()
+
+	runs := 0
+	task := func(ctx context.Context) error {
+		runs++
+		return nil
+	}
+
+	require.NoError(t, cb.Scheduled(task, nil)(context.Background()))
+	cb.Trip()
+
+	err = cb.Scheduled(task, nil)(context.Background())
+	assert.ErrorIs(t, err, ErrRunSkipped)
+	assert.ErrorIs(t, err, ErrOpenCircuit)
+
+	err = cb.Scheduled(task, func(ctx context.Context) error { return errCall })(context.Background())
+	assert.ErrorIs(t, err, ErrRunSkipped)
+	assert.ErrorIs(t, err, errCall)
+	assert.Equal(t, 1, runs)
+	assert.Equal(t, uint64(2), cb.Snapshot().SkippedRuns)
+
+	require.NoError(t, cb.Scheduled(task, func(ctx context.Context) error { return nil })(context.Background()))
+	assert.Equal(t, 2, runs)
+	assert.Equal(t, Closed, cb.State())
+	assert.Equal(t, uint64(2), cb.SkippedRuns())
+}
@@ -0,0 +1,183 @@
+package breaker
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// SharedState Stores the trip decisions of named breakers so every instance of a deployment
+// follows them, Watch must deliver the current state first and stop calling fn once ctx is done.
+type SharedState interface {
+	Store(ctx context.Context, name string, state State, ttl time.Duration) error
+	Watch(ctx context.Context, name string, fn func(state State)) error
+}
+
+// _sharedStoreTimeout Bounds every store of a decision, so an unreachable store can't hold
+// back the decisions queued after it.
+const _sharedStoreTimeout = time.Second * 5
+
+type sharedState struct {
+	cb    *CircuitBreaker
+	store SharedState
+	// owned Tells this instance stored the live open decision, which only it releases.
+	owned atomic.Bool
+	// following Tells the circuit opened on the shared decision rather than on its own calls,
+	// so the release of that decision closes it.
+	following atomic.Bool
+
+	pending []sharedDecision
+	wake    chan struct{}
+	mu      sync.Mutex
+}
+
+type sharedDecision struct {
+	state State
+	ttl   time.Duration
+}
+
+func newSharedState(cb *CircuitBreaker, store SharedState) *sharedState {
+	return &sharedState{cb: cb, store: store, wake: make(chan struct{}, 1)}
+}
+
+// publish Queues the open decisions of this instance and the closing of those it stored,
+// half-open is left to each instance probing and the transitions following the shared
+// decision aren't stored back. It runs with the transition, so the queue keeps their order.
+func (s *sharedState) publish(state State, reason Reason) {
+	switch {
+	case reason == ReasonSharedState, state == HalfOpen:
+		return
+	case state == Open:
+		s.owned.Store(true)
+		s.following.Store(false)
+	case state == Closed:
+		s.following.Store(false)
+		if !s.owned.Swap(false) {
+			return
+		}
+	}
+
+	s.mu.Lock()
+	s.pending = append(s.pending, sharedDecision{state: state, ttl: s.cb.openDuration()})
+	s.mu.Unlock()
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run Stores the queued decisions one at a time in the order of their transitions, so a
+// slow Open can't land after the Close following it, until ctx is done.
+func (s *sharedState) run(ctx context.Context) {
+	for {
+		select {
+		case <-s.wake:
+		case <-ctx.Done():
+			return
+		}
+
+		for {
+			s.mu.Lock()
+			if len(s.pending) == 0 {
+				s.mu.Unlock()
+				break
+			}
+			decision := s.pending[0]
+			s.pending = s.pending[1:]
+			s.mu.Unlock()
+
+			storeCtx, cancel := context.WithTimeout(ctx, _sharedStoreTimeout)
+			_ = s.store.Store(storeCtx, s.cb.name, decision.state, decision.ttl)
+			cancel()
+		}
+	}
+}
+
+func (s *sharedState) watch() (stop func(), err error) {
+	ctx, stop := context.WithCancel(context.Background())
+	if err = s.store.Watch(ctx, s.cb.name, s.apply); err != nil {
+		stop()
+		return nil, err
+	}
+	go s.run(ctx)
+
+	return stop, nil
+}
+
+// apply Opens the circuit on a shared open decision, and closes it on the release only when
+// it opened following that decision, an instance tripped on its own calls probing instead.
+func (s *sharedState) apply(state State) {
+	switch state {
+	case Open:
+		if s.cb.stateCopy() != Open {
+			s.following.Store(true)
+			s.cb.trip(ReasonSharedState)
+		}
+	case Closed:
+		if s.following.Swap(false) {
+			s.cb.untrip(ReasonSharedState)
+		}
+	}
+}
+
+// MemoryState Is an in-process SharedState, useful to share decisions between breakers
+// of the same process and as a reference for networked implementations.
+type MemoryState struct {
+	states   map[string]memoryStateEntry
+	watchers map[string]map[*func(state State)]struct{}
+
+	mu sync.Mutex
+}
+
+type memoryStateEntry struct {
+	state   State
+	expires time.Time
+}
+
+func NewMemoryState() *MemoryState {
+	return &MemoryState{
+		states:   make(map[string]memoryStateEntry),
+		watchers: make(map[string]map[*func(state State)]struct{}),
+	}
+}
+
+func (m *MemoryState) Store(ctx context.Context, name string, state State, ttl time.Duration) error {
+	m.mu.Lock()
+	m.states[name] = memoryStateEntry{state: state, expires: time.Now().Add(ttl)}
+	watchers := make([]func(state State), 0, len(m.watchers[name]))
+	for fn := range m.watchers[name] {
+		watchers = append(watchers, *fn)
+	}
+	m.mu.Unlock()
+
+	for _, fn := range watchers {
+		fn(state)
+	}
+
+	return nil
+}
+
+func (m *MemoryState) Watch(ctx context.Context, name string, fn func(state State)) error {
+	m.mu.Lock()
+	if m.watchers[name] == nil {
+		m.watchers[name] = make(map[*func(state State)]struct{})
+	}
+	m.watchers[name][&fn] = struct{}{}
+	entry, ok := m.states[name]
+	m.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		delete(m.watchers[name], &fn)
+	}()
+
+	if ok && time.Now().Before(entry.expires) {
+		fn(entry.state)
+	}
+
+	return nil
+}
@@ -0,0 +1,156 @@
+package breaker
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreakerSharedState(t *testing.T) {
+	store := NewMemoryState()
+	newBreaker := func() *CircuitBreaker {
+		cb, cancel, err := New(
+			WithName("payments"),
+			WithOpenDuration(1),
+			WithSharedState(store),
+			WithCanTrip(func(summary Counts) bool { return summary.Fail > 0 }),
+			WithFromHalfOpenToState(func(summary Counts) State { return Closed }),
+		)
+		require.NoError(t, err)
+		t.Cleanup(cancel)
+		return cb
+	}
+
+	a, b := newBreaker(), newBreaker()
+	syncFeedCircuitBreakerHelper(a, []error{errCall}, false)
+
+	require.Equal(t, Open, a.stateCopy())
+	assert.Eventually(t, func() bool { return b.stateCopy() == Open }, time.Second, time.Millisecond*10)
+
+	// instances started while the decision is live follow it
+	c := newBreaker()
+	assert.Equal(t, Open, c.stateCopy())
+
+	time.Sleep(a.cfg.openDuration + (time.Millisecond * 100))
+	require.NoError(t, a.Execute(fixtureCircuitCall(nil)))
+	require.Equal(t, Closed, a.stateCopy())
+
+	for _, cb := range []*CircuitBreaker{b, c} {
+		assert.Eventually(t, func() bool { return cb.stateCopy() == Closed }, time.Second, time.Millisecond*10)
+	}
+}
+
+func TestBreakerSharedStateReleasesOnlyItsOwnDecision(t *testing.T) {
+	store := NewMemoryState()
+	newBreaker := func() *CircuitBreaker {
+		cb, cancel, err := New(
+			WithName("payments"),
+			WithOpenDuration(1),
+			WithSharedState(store),
+			WithCanTrip(func(summary Counts) bool { return summary.Fail > 0 }),
+			WithFromHalfOpenToState(func(summary Counts) State { return Closed }),
+		)
+		require.NoError(t, err)
+		t.Cleanup(cancel)
+		return cb
+	}
+
+	a, b := newBreaker(), newBreaker()
+	syncFeedCircuitBreakerHelper(a, []error{errCall}, false)
+	syncFeedCircuitBreakerHelper(b, []error{errCall}, false)
+	require.Equal(t, Open, a.stateCopy())
+	require.Equal(t, Open, b.stateCopy())
+
+	time.Sleep(a.cfg.openDuration + (time.Millisecond * 100))
+	require.NoError(t, a.Execute(fixtureCircuitCall(nil)))
+	require.Equal(t, Closed, a.stateCopy())
+
+	time.Sleep(time.Millisecond * 50)
+	assert.Equal(t, HalfOpen, b.stateCopy(), "b tripped on its own calls, it probes rather than follow a")
+
+	// instances following a decision never store it back, so a closing follower releases nothing
+	c := newBreaker()
+	syncFeedCircuitBreakerHelper(c, []error{errCall}, false)
+	d := newBreaker()
+	assert.Eventually(t, func() bool { return d.stateCopy() == Open }, time.Second, time.Millisecond*10)
+	d.Reset()
+	time.Sleep(time.Millisecond * 50)
+	assert.Equal(t, Open, c.stateCopy())
+}
+
+// slowSharedState Takes longer to store open decisions than closed ones, recording the
+// order they land in.
+type slowSharedState struct {
+	stored    []State
+	deadlines bool
+	mu        sync.Mutex
+}
+
+func (s *slowSharedState) Store(ctx context.Context, name string, state State, ttl time.Duration) error {
+	if state == Open {
+		time.Sleep(time.Millisecond * 20)
+	}
+	_, deadline := ctx.Deadline()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stored = append(s.stored, state)
+	s.deadlines = deadline
+	return nil
+}
+
+func (s *slowSharedState) Watch(ctx context.Context, name string, fn func(state State)) error {
+	return nil
+}
+
+func (s *slowSharedState) decisions() ([]State, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]State{}, s.stored...), s.deadlines
+}
+
+func TestBreakerSharedStateStoresInTransitionOrder(t *testing.T) {
+	store := &slowSharedState{}
+	cb, cancel, err := New(WithName("payments"), WithSharedState(store))
+	require.NoError(t, err)
+	defer cancel()
+
+	cb.Trip()
+	cb.Reset()
+	cb.Trip()
+	cb.Reset()
+
+	expected := []State{Open, Closed, Open, Closed}
+	assert.Eventually(t, func() bool {
+		stored, _ := store.decisions()
+		return assert.ObjectsAreEqual(expected, stored)
+	}, time.Second, time.Millisecond*10)
+
+	_, deadline := store.decisions()
+	assert.True(t, deadline, "every store is bounded by a timeout")
+}
+
+func TestMemoryStateExpiresAndStopsWatching(t *testing.T) {
+	store := NewMemoryState()
+	require.NoError(t, store.Store(context.Background(), "payments", Open, time.Millisecond))
+	time.Sleep(time.Millisecond * 5)
+
+	got := make(chan State, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	require.NoError(t, store.Watch(ctx, "payments", func(state State) { got <- state }))
+	assert.Empty(t, got)
+
+	require.NoError(t, store.Store(context.Background(), "payments", Closed, time.Second))
+	assert.Equal(t, Closed, <-got)
+
+	cancel()
+	assert.Eventually(t, func() bool {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		return len(store.watchers["payments"]) == 0
+	}, time.Second, time.Millisecond*10)
+}
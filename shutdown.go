@@ -0,0 +1,64 @@
+package breaker
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+const _drainClosed = 1 << 62
+
+// drain Admits guarded calls until closed, counting the ones still running without locking
+// as every call goes through it. Once closed the count carries _drainClosed on top.
+type drain struct {
+	calls   atomic.Int64
+	drained chan struct{}
+	once    sync.Once
+}
+
+func (d *drain) enter() bool {
+	for {
+		calls := d.calls.Load()
+		if calls >= _drainClosed {
+			return false
+		}
+		if d.calls.CompareAndSwap(calls, calls+1) {
+			return true
+		}
+	}
+}
+
+func (d *drain) leave() {
+	if d.calls.Add(-1) == _drainClosed {
+		close(d.drained)
+	}
+}
+
+func (d *drain) close() {
+	d.once.Do(func() {
+		d.drained = make(chan struct{})
+		if d.calls.Add(_drainClosed) == _drainClosed {
+			close(d.drained)
+		}
+	})
+}
+
+func (d *drain) wait(ctx context.Context) error {
+	select {
+	case <-d.drained:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Shutdown Stops admitting calls, rejecting them with ErrShutdown, waits for the ones in
+// flight to return and releases the breaker background work. When ctx is done first the
+// background work is released anyway and the context error returned, the calls still
+// running being counted as they return.
+func (c *CircuitBreaker) Shutdown(ctx context.Context) error {
+	c.drain.close()
+	err := c.drain.wait(ctx)
+	c.stop()
+	return err
+}
@@ -0,0 +1,67 @@
+package breaker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreakerShutdown(t *testing.T) {
+	cb, cancel, err := New()
+	require.NoError(t, err)
+	defer cancel()
+
+	started, release := make(chan struct{}), make(chan struct{})
+	result := make(chan error, 1)
+	go func() {
+		result <- cb.Execute(func() error {
+			close(started)
+			<-release
+			return nil
+		})
+	}()
+	<-started
+
+	shutdown := make(chan error, 1)
+	go func() { shutdown <- cb.Shutdown(context.Background()) }()
+
+	require.Eventually(t, func() bool {
+		return cb.Execute(fixtureCircuitCall(nil)) == ErrShutdown
+	}, time.Second, time.Millisecond)
+
+	select {
+	case <-shutdown:
+		t.Fatal("shutdown returned with a call in flight")
+	default:
+	}
+
+	close(release)
+	assert.NoError(t, <-result)
+	assert.NoError(t, <-shutdown)
+	assert.Equal(t, Counts{Total: 1, Success: 1}, cb.Counts())
+}
+
+func TestBreakerShutdownDeadline(t *testing.T) {
+	cb, cancel, err := New()
+	require.NoError(t, err)
+	defer cancel()
+
+	started, release := make(chan struct{}), make(chan struct{})
+	defer close(release)
+	go func() {
+		_ = cb.Execute(func() error {
+			close(started)
+			<-release
+			return nil
+		})
+	}()
+	<-started
+
+	ctx, stop := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer stop()
+	assert.ErrorIs(t, cb.Shutdown(ctx), context.DeadlineExceeded)
+	assert.ErrorIs(t, cb.Execute(fixtureCircuitCall(nil)), ErrShutdown)
+}
@@ -0,0 +1,12 @@
+package breaker
+
+// silence Hands err to the fail-silent recorder and hides it from the caller when the
+// breaker runs fail-silent.
+func (c *CircuitBreaker) silence(err error) error {
+	if err == nil || c.failSilent == nil {
+		return err
+	}
+
+	c.failSilent(err)
+	return nil
+}
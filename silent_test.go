@@ -0,0 +1,31 @@
+package breaker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreakerFailSilent(t *testing.T) {
+	var recorded []error
+	cb, cancel, err := New(
+		WithFailSilent(func(err error) { recorded = append(recorded, err) }),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	assert.NoError(t, cb.Execute(fixtureCircuitCall(nil)))
+	assert.NoError(t, cb.Execute(fixtureCircuitCall(errCall)))
+	assert.Equal(t, Counts{Total: 2, Fail: 1, Success: 1}, cb.Counts())
+
+	cb.Trip()
+	assert.NoError(t, cb.Execute(fixtureCircuitCall(nil)))
+	assert.NoError(t, cb.ExecuteAsync(fixtureCircuitCall(nil)).Wait(context.Background()))
+
+	require.Len(t, recorded, 3)
+	assert.ErrorIs(t, recorded[0], errCall)
+	assert.ErrorIs(t, recorded[1], ErrOpenCircuit)
+	assert.ErrorIs(t, recorded[2], ErrOpenCircuit)
+}
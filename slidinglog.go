@@ -0,0 +1,86 @@
+package breaker
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+type logEntry struct {
+	outcome Outcome
+	at      time.Time
+	weight  uint64
+}
+
+// SlidingLog Is a WindowStrategy keeping every outcome with its timestamp and weight, giving
+// exact counts over any lookback at the cost of memory bounded by max entries.
+type SlidingLog struct {
+	lookback time.Duration
+	max      int
+	entries  []logEntry
+
+	mu sync.RWMutex
+}
+
+func NewSlidingLog(lookback time.Duration, max int) (*SlidingLog, error) {
+	if lookback <= 0 || max <= 0 {
+		return nil, fmt.Errorf("%w: lookback and max can't be less than equal zero", ErrInvalidSlidingLog)
+	}
+
+	return &SlidingLog{
+		lookback: lookback,
+		max:      max,
+		entries:  make([]logEntry, 0, max),
+	}, nil
+}
+
+func (l *SlidingLog) Record(outcome Outcome, at time.Time, weight uint64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.entries = append(l.entries, logEntry{outcome: outcome, at: at, weight: weight})
+	l.compact(at)
+}
+
+// compact Drops the entries past the lookback and the oldest ones over max, the backing
+// array is reallocated once the dropped entries outgrow the kept ones.
+func (l *SlidingLog) compact(at time.Time) {
+	i := 0
+	for i < len(l.entries) && !l.entries[i].at.After(at.Add(-l.lookback)) {
+		i++
+	}
+	i = max(i, len(l.entries)-l.max)
+	l.entries = l.entries[i:]
+
+	if cap(l.entries) > 2*max(l.max, len(l.entries)) {
+		l.entries = append(make([]logEntry, 0, l.max), l.entries...)
+	}
+}
+
+func (l *SlidingLog) Counts(at time.Time) Counts {
+	return l.CountsOver(at, l.lookback)
+}
+
+// CountsOver Returns the counts of the outcomes recorded within lookback before at, the
+// lookback can't reach further than the one the log was created with.
+func (l *SlidingLog) CountsOver(at time.Time, lookback time.Duration) Counts {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	var counts Counts
+	since := at.Add(-lookback)
+	for i := len(l.entries) - 1; i >= 0 && l.entries[i].at.After(since); i-- {
+		if l.entries[i].at.After(at) {
+			continue
+		}
+		counts = addCounts(counts, scaleCounts(l.entries[i].outcome.counts(), l.entries[i].weight))
+	}
+
+	return counts
+}
+
+func (l *SlidingLog) Reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = l.entries[:0]
+}
@@ -0,0 +1,100 @@
+package breaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlidingLog(t *testing.T) {
+	start := time.Now()
+	log, err := NewSlidingLog(10*time.Second, 5)
+	require.NoError(t, err)
+
+	log.Record(OutcomeFailure, start, 1)
+	log.Record(OutcomeSuccess, start.Add(2*time.Second), 1)
+	log.Record(OutcomeSlow, start.Add(4*time.Second), 1)
+	log.Record(OutcomeIgnored, start.Add(6*time.Second), 1)
+
+	tt := []struct {
+		name     string
+		at       time.Time
+		lookback time.Duration
+		expected Counts
+	}{
+		{
+			name:     "counts_whole_lookback",
+			at:       start.Add(6 * time.Second),
+			lookback: 10 * time.Second,
+			expected: Counts{Total: 3, Fail: 1, Success: 1, Slow: 1, Ignored: 1},
+		},
+		{
+			name:     "counts_shorter_lookback",
+			at:       start.Add(6 * time.Second),
+			lookback: 3 * time.Second,
+			expected: Counts{Total: 1, Slow: 1, Ignored: 1},
+		},
+		{
+			name:     "skips_entries_after_at",
+			at:       start.Add(3 * time.Second),
+			lookback: 10 * time.Second,
+			expected: Counts{Total: 2, Fail: 1, Success: 1},
+		},
+		{
+			name:     "expires_entries_past_lookback",
+			at:       start.Add(11 * time.Second),
+			lookback: 10 * time.Second,
+			expected: Counts{Total: 2, Success: 1, Slow: 1, Ignored: 1},
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, log.CountsOver(tc.at, tc.lookback))
+		})
+	}
+
+	for i := 0; i < 10; i++ {
+		log.Record(OutcomeSuccess, start.Add(7*time.Second), 1)
+	}
+	assert.Len(t, log.entries, 5)
+	assert.Equal(t, Counts{Total: 5, Success: 5}, log.Counts(start.Add(7*time.Second)))
+
+	log.Record(OutcomeFailure, start.Add(30*time.Second), 1)
+	assert.Len(t, log.entries, 1)
+
+	log.Reset()
+	assert.Equal(t, Counts{}, log.Counts(start.Add(30*time.Second)))
+}
+
+func TestNewSlidingLogValidation(t *testing.T) {
+	_, err := NewSlidingLog(0, 5)
+	assert.ErrorIs(t, err, ErrInvalidSlidingLog)
+
+	_, err = NewSlidingLog(time.Second, 0)
+	assert.ErrorIs(t, err, ErrInvalidSlidingLog)
+}
+
+func TestBreakerWindowStrategy(t *testing.T) {
+	log, err := NewSlidingLog(time.Second, 100)
+	require.NoError(t, err)
+	cb, cancel, err := New(
+		WithWindowStrategy(log),
+		WithCanTrip(func(summary Counts) bool { return summary.Fail >= 2 }),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	syncFeedCircuitBreakerHelper(cb, []error{errCall}, false)
+	time.Sleep(1100 * time.Millisecond)
+	syncFeedCircuitBreakerHelper(cb, []error{errCall}, false)
+	assert.Equal(t, Closed, cb.State())
+	assert.Equal(t, Counts{Total: 2, Fail: 2}, cb.Counts())
+
+	syncFeedCircuitBreakerHelper(cb, []error{errCall}, false)
+	assert.Equal(t, Open, cb.State())
+
+	cb.Reset()
+	assert.Equal(t, Counts{}, log.Counts(time.Now()))
+}
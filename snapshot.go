@@ -0,0 +1,73 @@
+package breaker
+
+import "time"
+
+// Snapshot Is a point in time copy of everything the breaker knows, safe to keep around
+// and hand to other goroutines.
+type Snapshot struct {
+	Name    string            `json:"name"`
+	Labels  map[string]string `json:"labels,omitempty"`
+	State   State             `json:"state"`
+	Mode    Mode              `json:"mode"`
+	Summary Counts            `json:"summary"`
+	Frame   Counts            `json:"frame"`
+	Window  []Frame           `json:"window"`
+	Config  Config            `json:"config"`
+	Uptime  time.Duration     `json:"uptime"`
+
+	TimeInState    time.Duration `json:"time_in_state"`
+	LastTransition *Transition   `json:"last_transition,omitempty"`
+
+	ShortCircuits uint64        `json:"short_circuits"`
+	TimeSaved     time.Duration `json:"time_saved"`
+	InFlight      int64         `json:"in_flight"`
+	SkippedRuns   uint64        `json:"skipped_runs"`
+
+	Measurements Measurements `json:"measurements,omitempty"`
+}
+
+// Config Is the effective configuration of the breaker.
+type Config struct {
+	WindowRoll         time.Duration `json:"window_roll"`
+	WindowFrame        time.Duration `json:"window_frame"`
+	OpenDuration       time.Duration `json:"open_duration"`
+	DeadlinePercentile float64       `json:"deadline_percentile,omitempty"`
+}
+
+func (c *CircuitBreaker) Snapshot() Snapshot {
+	c.expireStale()
+	v := c.load()
+
+	shortCircuits, saved := c.timeSaved.copy()
+	snapshot := Snapshot{
+		Name:    c.name,
+		Labels:  c.Labels(),
+		State:   v.state,
+		Mode:    v.mode,
+		Summary: v.summary,
+		Frame:   v.frame,
+		Window:  c.WindowSnapshot(),
+		Config: Config{
+			WindowRoll:         c.cfg.windowRoll,
+			WindowFrame:        c.cfg.windowFrame,
+			OpenDuration:       c.openDuration(),
+			DeadlinePercentile: c.cfg.deadlinePercentile,
+		},
+		Uptime: c.clock.Now().Sub(c.created),
+
+		ShortCircuits: shortCircuits,
+		TimeSaved:     saved,
+		InFlight:      c.InFlight(),
+		SkippedRuns:   c.SkippedRuns(),
+
+		Measurements: c.Measurements(),
+	}
+
+	snapshot.TimeInState = snapshot.Uptime
+	if transition, ok := c.transitions.last(); ok {
+		snapshot.LastTransition = &transition
+		snapshot.TimeInState = c.clock.Now().Sub(transition.Time)
+	}
+
+	return snapshot
+}
@@ -0,0 +1,74 @@
+package breaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreakerSnapshot(t *testing.T) {
+	cb, cancel, err := New(
+		WithName("inventory"),
+		WithWindowFrameThreshold(10),
+		WithWindowRollThreshold(30),
+		WithOpenDuration(5),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	got := cb.Snapshot()
+	assert.Equal(t, "inventory", got.Name)
+	assert.Equal(t, Closed, got.State)
+	assert.Equal(t, ModeNormal, got.Mode)
+	assert.Nil(t, got.LastTransition)
+	assert.Equal(t, Config{
+		WindowRoll:   30 * time.Second,
+		WindowFrame:  10 * time.Second,
+		OpenDuration: 5 * time.Second,
+	}, got.Config)
+
+	syncFeedCircuitBreakerHelper(cb, []error{errCall, nil}, false)
+	cb.Trip()
+
+	got = cb.Snapshot()
+	assert.Equal(t, Open, got.State)
+	assert.Equal(t, Counts{Total: 2, Fail: 1, Success: 1}, got.Summary)
+	assert.Equal(t, Counts{Total: 2, Fail: 1, Success: 1}, got.Frame)
+	require.Len(t, got.Window, 3)
+	assert.Equal(t, got.Frame, got.Window[2].Counts)
+	require.NotNil(t, got.LastTransition)
+	assert.Equal(t, ReasonManualTrip, got.LastTransition.Reason)
+	assert.Greater(t, got.Uptime, time.Duration(0))
+	assert.LessOrEqual(t, got.TimeInState, got.Uptime)
+
+	cb.Reset()
+	syncFeedCircuitBreakerHelper(cb, []error{nil}, false)
+	assert.Equal(t, Counts{Total: 2, Fail: 1, Success: 1}, got.Window[2].Counts)
+}
+
+func TestBreakerLabels(t *testing.T) {
+	labels := map[string]string{"team": "checkout", "tier": "1"}
+	cb, cancel, err := New(WithName("payments"), WithLabels(labels))
+	require.NoError(t, err)
+	defer cancel()
+
+	labels["tier"] = "3"
+	expected := map[string]string{"team": "checkout", "tier": "1"}
+	assert.Equal(t, expected, cb.Labels())
+	assert.Equal(t, expected, cb.Snapshot().Labels)
+
+	cb.Labels()["team"] = "search"
+	assert.Equal(t, expected, cb.Labels())
+
+	events, unsubscribe := cb.Subscribe(1)
+	defer unsubscribe()
+	cb.Trip()
+	select {
+	case event := <-events:
+		assert.Equal(t, expected, event.Labels)
+	case <-time.After(time.Second):
+		t.Fatal("no event delivered")
+	}
+}
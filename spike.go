@@ -0,0 +1,38 @@
+package breaker
+
+// spike Trips on a current frame failing multiplier times as often as the rest of the trip
+// counts, and at least minRate percent of the time.
+type spike struct {
+	multiplier float64
+	minRate    float64
+}
+
+// failureSpiked Reports whether the current frame holds a failure spike, catching an abrupt
+// outage long before it moves the window average. The baseline is what the trip counts hold
+// besides the current frame, so it follows the recovery cooldown, the decay and the window
+// strategy. Frames under the minimum volume, current or baseline, never spike.
+func (c *CircuitBreaker) failureSpiked(counts Counts) bool {
+	if c.spike == nil {
+		return false
+	}
+
+	c.rollingWindow.mu.RLock()
+	window := c.rollingWindow.window
+	if len(window) == 0 {
+		c.rollingWindow.mu.RUnlock()
+		return false
+	}
+	current := window[len(window)-1].Counts
+	c.rollingWindow.mu.RUnlock()
+
+	if current.Total < _minimumVolume || counts.Total < current.Total+_minimumVolume {
+		return false
+	}
+	baseline := Counts{Total: counts.Total - current.Total}
+	if counts.Fail > current.Fail {
+		baseline.Fail = counts.Fail - current.Fail
+	}
+
+	rate := current.FailureRate()
+	return rate >= c.spike.minRate && rate >= baseline.FailureRate()*c.spike.multiplier
+}
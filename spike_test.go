@@ -0,0 +1,65 @@
+package breaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreakerFailureSpike(t *testing.T) {
+	log, err := NewSlidingLog(time.Minute, 12)
+	require.NoError(t, err)
+
+	tt := []struct {
+		name     string
+		input    []option
+		current  []error
+		expected State
+	}{
+		{
+			name:     "ignores_spikes_by_default",
+			current:  append(make([]error, 8), errCall, errCall, errCall, errCall),
+			expected: Closed,
+		},
+		{
+			name:     "tolerates_the_baseline",
+			input:    []option{WithFailureSpike(3, 20)},
+			current:  append(make([]error, 11), errCall),
+			expected: Closed,
+		},
+		{
+			name:     "trips_on_spike",
+			input:    []option{WithFailureSpike(3, 20)},
+			current:  append(make([]error, 8), errCall, errCall, errCall, errCall),
+			expected: Open,
+		},
+		{
+			name:     "follows_the_window_strategy",
+			input:    []option{WithFailureSpike(3, 20), WithWindowStrategy(log)},
+			current:  append(make([]error, 8), errCall, errCall, errCall, errCall),
+			expected: Closed,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			cb, cancel, err := New(append([]option{
+				WithCanTrip(func(summary Counts) bool { return false }),
+				WithWindowFrameThreshold(10),
+				WithWindowRollThreshold(30),
+			}, tc.input...)...)
+			require.NoError(t, err)
+			defer cancel()
+
+			for i := 0; i < 2; i++ {
+				syncFeedCircuitBreakerHelper(cb, append(make([]error, 19), errCall), false)
+				cb.moveWindow()
+			}
+
+			syncFeedCircuitBreakerHelper(cb, tc.current, false)
+			assert.Equal(t, tc.expected, cb.State())
+		})
+	}
+}
@@ -0,0 +1,297 @@
+package breaker
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// _storeFlushInterval Spaces the batched writes of the outcomes to the store.
+const _storeFlushInterval = time.Millisecond * 100
+
+// _storeTimeout Bounds every call to the store, so an unreachable store can't hold up the
+// calls reading the window nor the flushes.
+const _storeTimeout = time.Second
+
+// Store Keeps the window counters outside the breaker, so workers of the same job sharing
+// a store and a breaker name trip together. Counters are kept per key and frame start, Add
+// receives the outcomes batched per frame and Sum is read once per frame.
+type Store interface {
+	Add(ctx context.Context, key string, frame time.Time, delta Counts, ttl time.Duration) error
+	Sum(ctx context.Context, key string, frames []time.Time) (Counts, error)
+	Clear(ctx context.Context, key string) error
+}
+
+// storeWindow Is the WindowStrategy accounting in a Store, frames are aligned on the frame
+// duration so every worker addresses the same counters. The outcomes are buffered and
+// flushed periodically, and the window sums read once per frame on top of which the local
+// outcomes add up. The store is only called from the flushes, Counts answering from the
+// sums last read while a new frame's are fetched and Reset clearing the key on the next
+// flush. Store errors fail open: the outcome is dropped or the window reads empty until
+// the next frame, and are reported to onError.
+type storeWindow struct {
+	store   Store
+	key     string
+	frame   time.Duration
+	frames  int
+	clock   Clock
+	onError func(err error)
+
+	pending  map[time.Time]Counts
+	cached   Counts
+	cachedAt time.Time
+	// wanted Is the last frame Counts asked the sums of, read on the next flush.
+	wanted time.Time
+	// dirty Tells the key must be cleared from the store on the next flush.
+	dirty bool
+	// generation Is bumped by every Reset, so a flush in flight doesn't account what it
+	// read or wrote before it into the cleared window.
+	generation uint64
+	flushing   Timer
+	scheduled  bool
+	stopped    bool
+
+	mu sync.Mutex
+	// io Serializes the flushes, so a read never sees an outcome both in the store and
+	// still pending.
+	io sync.Mutex
+}
+
+func newStoreWindow(store Store, key string, frame time.Duration, frames int, clock Clock, onError func(err error)) *storeWindow {
+	return &storeWindow{
+		store:   store,
+		key:     key,
+		frame:   frame,
+		frames:  frames,
+		clock:   clock,
+		onError: onError,
+		pending: make(map[time.Time]Counts),
+	}
+}
+
+func (s *storeWindow) Record(outcome Outcome, at time.Time, weight uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	frame := at.Truncate(s.frame)
+	s.pending[frame] = addCounts(s.pending[frame], scaleCounts(outcome.counts(), weight))
+	s.schedule()
+}
+
+// schedule Arms the next flush unless already armed or stopped, the lock must be held.
+func (s *storeWindow) schedule() {
+	if s.scheduled || s.stopped {
+		return
+	}
+	s.scheduled = true
+	if s.flushing == nil {
+		s.flushing = s.clock.AfterFunc(_storeFlushInterval, s.flush)
+		return
+	}
+	s.flushing.Reset(_storeFlushInterval)
+}
+
+// flush Clears the key when reset, reads the sums of the frame Counts last asked for, then
+// adds the pending outcomes to the store one frame at a time moving each into the cached
+// sums once stored.
+func (s *storeWindow) flush() {
+	s.io.Lock()
+	defer s.io.Unlock()
+
+	s.mu.Lock()
+	s.scheduled = false
+	generation, clear := s.generation, s.dirty
+	s.dirty = false
+	batch := make(map[time.Time]Counts, len(s.pending))
+	for frame, delta := range s.pending {
+		batch[frame] = delta
+	}
+	s.mu.Unlock()
+
+	if clear {
+		ctx, cancel := context.WithTimeout(context.Background(), _storeTimeout)
+		err := s.store.Clear(ctx, s.key)
+		cancel()
+		if err != nil {
+			s.onError(err)
+		}
+	}
+	s.refresh(generation)
+
+	ttl := s.frame * time.Duration(s.frames+1)
+	for frame, delta := range batch {
+		ctx, cancel := context.WithTimeout(context.Background(), _storeTimeout)
+		err := s.store.Add(ctx, s.key, frame, delta, ttl)
+		cancel()
+		if err != nil {
+			s.onError(err)
+		}
+
+		s.mu.Lock()
+		if s.generation == generation {
+			if remaining := subCounts(s.pending[frame], delta); remaining == (Counts{}) {
+				delete(s.pending, frame)
+			} else {
+				s.pending[frame] = remaining
+			}
+			if err == nil && s.inWindow(s.cachedAt, frame) {
+				s.cached = addCounts(s.cached, delta)
+			}
+		}
+		s.mu.Unlock()
+	}
+}
+
+// Counts Returns the sums last read from the store plus the outcomes still pending. Once
+// the current frame moves past the one the sums were read for, the next flush reads them
+// again, the sums read meanwhile until then as long as their window overlaps the current.
+func (s *storeWindow) Counts(at time.Time) Counts {
+	current := at.Truncate(s.frame)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.cachedAt.Equal(current) && !s.wanted.Equal(current) {
+		s.wanted = current
+		s.schedule()
+	}
+
+	counts := Counts{}
+	if s.inWindow(current, s.cachedAt) {
+		counts = s.cached
+	}
+	for frame, delta := range s.pending {
+		if s.inWindow(current, frame) {
+			counts = addCounts(counts, delta)
+		}
+	}
+	return counts
+}
+
+// refresh Reads the sums of the window ending with the frame Counts last asked for from
+// the store, unless already read or reset since generation.
+func (s *storeWindow) refresh(generation uint64) {
+	s.mu.Lock()
+	current := s.wanted
+	fresh := current.IsZero() || s.cachedAt.Equal(current)
+	s.mu.Unlock()
+	if fresh {
+		return
+	}
+
+	frames := make([]time.Time, s.frames)
+	for i := range frames {
+		frames[i] = current.Add(-s.frame * time.Duration(s.frames-1-i))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), _storeTimeout)
+	counts, err := s.store.Sum(ctx, s.key, frames)
+	cancel()
+	if err != nil {
+		s.onError(err)
+		counts = Counts{}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.generation == generation {
+		s.cached, s.cachedAt = counts, current
+	}
+}
+
+// inWindow Tells whether frame belongs to the window ending with the current frame.
+func (s *storeWindow) inWindow(current, frame time.Time) bool {
+	oldest := current.Add(-s.frame * time.Duration(s.frames-1))
+	return !frame.Before(oldest) && !frame.After(current)
+}
+
+// Reset Empties the local window right away and clears the key from the store on the next
+// flush.
+func (s *storeWindow) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pending = make(map[time.Time]Counts)
+	s.cached, s.cachedAt, s.wanted = Counts{}, time.Time{}, time.Time{}
+	s.dirty = true
+	s.generation++
+	s.schedule()
+}
+
+// stop Flushes what is left and stops flushing.
+func (s *storeWindow) stop() {
+	s.mu.Lock()
+	s.stopped = true
+	if s.flushing != nil {
+		s.flushing.Stop()
+	}
+	s.mu.Unlock()
+
+	s.flush()
+}
+
+type memoryStoreEntry struct {
+	counts  Counts
+	expires time.Time
+}
+
+// MemoryStore Is an in-process Store, sharing counters between the breakers of a process
+// and a reference for networked implementations such as Redis hashes with expiring keys.
+// Expiry is told from the frame times the breakers pass, so it follows the breaker clock.
+type MemoryStore struct {
+	counters map[string]map[time.Time]memoryStoreEntry
+
+	mu sync.Mutex
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{counters: make(map[string]map[time.Time]memoryStoreEntry)}
+}
+
+func (m *MemoryStore) Add(ctx context.Context, key string, frame time.Time, delta Counts, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.counters[key] == nil {
+		m.counters[key] = make(map[time.Time]memoryStoreEntry)
+	}
+	for at, entry := range m.counters[key] {
+		if frame.After(entry.expires) {
+			delete(m.counters[key], at)
+		}
+	}
+
+	entry := m.counters[key][frame]
+	m.counters[key][frame] = memoryStoreEntry{counts: addCounts(entry.counts, delta), expires: frame.Add(ttl)}
+
+	return nil
+}
+
+func (m *MemoryStore) Sum(ctx context.Context, key string, frames []time.Time) (Counts, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var latest time.Time
+	for _, frame := range frames {
+		if frame.After(latest) {
+			latest = frame
+		}
+	}
+
+	sum := Counts{}
+	for _, frame := range frames {
+		if entry, ok := m.counters[key][frame]; ok && latest.Before(entry.expires) {
+			sum = addCounts(sum, entry.counts)
+		}
+	}
+
+	return sum, nil
+}
+
+func (m *MemoryStore) Clear(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.counters, key)
+	return nil
+}
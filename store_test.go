@@ -0,0 +1,182 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreakerStoreSharesCounters(t *testing.T) {
+	store := NewMemoryStore()
+	newWorker := func() *CircuitBreaker {
+		cb, cancel, err := New(
+			WithName("payments"),
+			WithStore(store),
+			WithCanTrip(func(summary Counts) bool { return summary.Fail >= 4 }),
+		)
+		require.NoError(t, err)
+		t.Cleanup(cancel)
+		return cb
+	}
+
+	a, b := newWorker(), newWorker()
+	syncFeedCircuitBreakerHelper(a, []error{errCall, errCall, nil}, false)
+	a.strategy.(*storeWindow).flush()
+	syncFeedCircuitBreakerHelper(b, []error{errCall}, false)
+	b.strategy.(*storeWindow).flush()
+	assert.Equal(t, Closed, a.State())
+	assert.Equal(t, Closed, b.State())
+	assert.Equal(t, Counts{Total: 4, Fail: 3, Success: 1}, b.tripCounts())
+
+	syncFeedCircuitBreakerHelper(b, []error{errCall}, false)
+	assert.Equal(t, Open, b.State())
+	assert.Equal(t, Counts{Total: 2, Fail: 2}, b.Counts())
+
+	a.ResetCounts()
+	assert.Equal(t, Counts{}, a.tripCounts())
+}
+
+type recordingStore struct {
+	*MemoryStore
+	adds, sums  int
+	clears      int
+	err         error
+	hasDeadline bool
+}
+
+func (r *recordingStore) Add(ctx context.Context, key string, frame time.Time, delta Counts, ttl time.Duration) error {
+	r.adds++
+	_, r.hasDeadline = ctx.Deadline()
+	if r.err != nil {
+		return r.err
+	}
+	return r.MemoryStore.Add(ctx, key, frame, delta, ttl)
+}
+
+func (r *recordingStore) Sum(ctx context.Context, key string, frames []time.Time) (Counts, error) {
+	r.sums++
+	if r.err != nil {
+		return Counts{}, r.err
+	}
+	return r.MemoryStore.Sum(ctx, key, frames)
+}
+
+func (r *recordingStore) Clear(ctx context.Context, key string) error {
+	r.clears++
+	return r.MemoryStore.Clear(ctx, key)
+}
+
+// idleClock Never fires the flush timer, so the tests flush the store window themselves.
+type idleClock struct {
+	realClock
+}
+
+func (idleClock) AfterFunc(d time.Duration, f func()) Timer {
+	return time.AfterFunc(time.Hour, f)
+}
+
+func TestStoreWindow(t *testing.T) {
+	store := &recordingStore{MemoryStore: NewMemoryStore()}
+	var errs []error
+	window := newStoreWindow(store, "payments", time.Second, 3, idleClock{}, func(err error) { errs = append(errs, err) })
+	defer window.stop()
+	start := time.Unix(60, 0)
+
+	window.Record(OutcomeFailure, start, 1)
+	window.Record(OutcomeSuccess, start.Add(time.Millisecond), 1)
+	window.Record(OutcomeFailure, start.Add(time.Second), 1)
+	assert.Equal(t, Counts{Total: 3, Fail: 2, Success: 1}, window.Counts(start.Add(time.Second)))
+	assert.Equal(t, 0, store.adds)
+
+	window.flush()
+	assert.Equal(t, 2, store.adds)
+	assert.Equal(t, 1, store.sums)
+	assert.True(t, store.hasDeadline)
+	assert.Equal(t, Counts{Total: 3, Fail: 2, Success: 1}, window.Counts(start.Add(time.Second)))
+	assert.Equal(t, 1, store.sums)
+
+	assert.Equal(t, Counts{Total: 3, Fail: 2, Success: 1}, window.Counts(start.Add(3*time.Second)))
+	assert.Equal(t, 1, store.sums)
+	window.flush()
+	assert.Equal(t, 2, store.sums)
+	assert.Equal(t, Counts{Total: 1, Fail: 1}, window.Counts(start.Add(3*time.Second)))
+
+	assert.Equal(t, Counts{}, window.Counts(start.Add(10*time.Second)))
+
+	store.err = errors.New("store down")
+	window.Record(OutcomeFailure, start.Add(4*time.Second), 1)
+	window.flush()
+	assert.Equal(t, Counts{}, window.Counts(start.Add(4*time.Second)))
+	assert.Equal(t, []error{store.err, store.err}, errs)
+}
+
+func TestStoreWindowReset(t *testing.T) {
+	store := &recordingStore{MemoryStore: NewMemoryStore()}
+	window := newStoreWindow(store, "payments", time.Second, 3, idleClock{}, func(err error) { t.Error(err) })
+	defer window.stop()
+	start := time.Unix(60, 0)
+
+	window.Record(OutcomeFailure, start, 1)
+	assert.Equal(t, Counts{Total: 1, Fail: 1}, window.Counts(start))
+	window.flush()
+	window.Record(OutcomeFailure, start, 1)
+	assert.Equal(t, Counts{Total: 2, Fail: 2}, window.Counts(start))
+
+	window.Reset()
+	assert.Equal(t, Counts{}, window.Counts(start))
+	assert.Equal(t, 0, store.clears)
+
+	window.flush()
+	assert.Equal(t, 1, store.clears)
+	assert.Equal(t, Counts{}, window.Counts(start))
+	sum, err := store.Sum(context.Background(), "payments", []time.Time{start})
+	require.NoError(t, err)
+	assert.Equal(t, Counts{}, sum)
+}
+
+func TestBreakerStoreErrorEvents(t *testing.T) {
+	store := &recordingStore{MemoryStore: NewMemoryStore(), err: errors.New("store down")}
+	cb, cancel, err := New(WithName("payments"), WithStore(store))
+	require.NoError(t, err)
+	defer cancel()
+
+	events, unsubscribe := cb.Subscribe(10)
+	defer unsubscribe()
+
+	assert.NoError(t, cb.Execute(fixtureCircuitCall(nil)))
+	event := <-events
+	assert.Equal(t, EventStoreError, event.Type)
+	assert.Equal(t, "store down", event.Error)
+}
+
+func TestMemoryStore(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	frame := time.Unix(60, 0)
+
+	require.NoError(t, store.Add(ctx, "payments", frame, Counts{Total: 1, Fail: 1}, time.Minute))
+	require.NoError(t, store.Add(ctx, "payments", frame, Counts{Total: 1, Success: 1}, time.Minute))
+	require.NoError(t, store.Add(ctx, "payments", frame.Add(time.Second), Counts{Total: 1, Fail: 1}, time.Second))
+	require.NoError(t, store.Add(ctx, "orders", frame, Counts{Total: 1, Fail: 1}, time.Minute))
+
+	sum, err := store.Sum(ctx, "payments", []time.Time{frame, frame.Add(time.Second)})
+	require.NoError(t, err)
+	assert.Equal(t, Counts{Total: 3, Fail: 2, Success: 1}, sum)
+
+	sum, err = store.Sum(ctx, "payments", []time.Time{frame, frame.Add(time.Second), frame.Add(2 * time.Second)})
+	require.NoError(t, err)
+	assert.Equal(t, Counts{Total: 2, Fail: 1, Success: 1}, sum)
+
+	require.NoError(t, store.Clear(ctx, "payments"))
+	sum, err = store.Sum(ctx, "payments", []time.Time{frame})
+	require.NoError(t, err)
+	assert.Equal(t, Counts{}, sum)
+
+	sum, err = store.Sum(ctx, "orders", []time.Time{frame})
+	require.NoError(t, err)
+	assert.Equal(t, Counts{Total: 1, Fail: 1}, sum)
+}
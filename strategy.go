@@ -0,0 +1,71 @@
+package breaker
+
+import "time"
+
+// WindowStrategy Accounts the call outcomes the trip predicate is evaluated on in place of
+// the frame window summary, the frame window is still kept for snapshots and events. Record
+// receives the weight of the call, the outcome counting weight times.
+type WindowStrategy interface {
+	Record(outcome Outcome, at time.Time, weight uint64)
+	Counts(at time.Time) Counts
+	Reset()
+}
+
+// tripCounts Returns the counts the trip predicate is evaluated on, only the calls since the
+// circuit recovered while cooling down.
+func (c *CircuitBreaker) tripCounts() Counts {
+	if c.cooldown != nil {
+		if counts, ok := c.cooldown.active(c.clock.Now()); ok {
+			return counts
+		}
+	}
+
+	if c.strategy == nil {
+		if c.decay != nil {
+			return c.decayedCounts()
+		}
+		return c.summaryCopy()
+	}
+
+	return c.strategy.Counts(c.clock.Now())
+}
+
+// mayTrip Tells from the published view whether the closed circuit may trip, so the calls
+// that can't skip the state lock and the trip evaluation. No predicate trips a window
+// without failed or slow calls, unless the trip counts or the measurements it is given
+// come from elsewhere.
+func (c *CircuitBreaker) mayTrip(v *view) bool {
+	if c.strategy != nil || c.decay != nil || c.measuredCanTrip != nil {
+		return true
+	}
+	return v.summary.Fail > 0 || v.summary.Slow > 0
+}
+
+// shouldTrip Evaluates whether the closed circuit trips on the trip counts. An external health
+// verdict holding the circuit closed takes precedence over everything, then a failure spike
+// trips on its own, then the first one set among the runtime failure rate of Reconfigure,
+// the WithMeasuredCanTrip, WithSummaryCanTrip and WithCanTrip predicates decides.
+func (c *CircuitBreaker) shouldTrip() bool {
+	if c.external.holdsClosed() {
+		return false
+	}
+
+	counts := c.tripCounts()
+	if c.failureSpiked(counts) {
+		return true
+	}
+
+	if t := c.thresholds.Load(); t != nil && t.FailureRate > 0 {
+		return counts.Total > _minimumVolume && counts.FailureRate() >= t.FailureRate
+	}
+
+	if c.measuredCanTrip != nil {
+		return c.measuredCanTrip(counts, c.Measurements())
+	}
+
+	if c.summaryCanTrip != nil {
+		return c.summaryCanTrip(c.summarize(counts))
+	}
+
+	return c.canTrip(counts)
+}
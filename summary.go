@@ -0,0 +1,76 @@
+package breaker
+
+import "time"
+
+// Summary Is the rolling window counts along the statistics derived from them, so callers
+// don't walk the window to get them. The frame extremes only consider the frames with calls.
+type Summary struct {
+	Counts
+	Rejected uint64        `json:"rejected"`
+	Frames   int           `json:"frames"`
+	Coverage time.Duration `json:"coverage"`
+
+	MinFrameTotal       uint64  `json:"min_frame_total"`
+	MaxFrameTotal       uint64  `json:"max_frame_total"`
+	MinFrameFailureRate float64 `json:"min_frame_failure_rate"`
+	MaxFrameFailureRate float64 `json:"max_frame_failure_rate"`
+}
+
+// RejectionRate Returns the percentage of the calls rejected by the open circuit over the
+// calls attempted, zero when none was.
+func (s Summary) RejectionRate() float64 {
+	return rate(s.Rejected, s.Total+s.Rejected)
+}
+
+// Summary Returns the rolling window counts and their derived statistics.
+func (c *CircuitBreaker) Summary() Summary {
+	c.expireStale()
+	return c.summarize(c.summaryCopy())
+}
+
+// summarize Derives the statistics of the window around counts, which are the summary or
+// the counts the trip predicate is evaluated on.
+func (c *CircuitBreaker) summarize(counts Counts) Summary {
+	c.rollingWindow.mu.RLock()
+	defer c.rollingWindow.mu.RUnlock()
+
+	window := c.rollingWindow.window
+	summary := Summary{Counts: counts, Frames: len(window)}
+	if len(window) == 0 {
+		return summary
+	}
+	summary.Coverage = c.clock.Now().Sub(window[0].start)
+
+	seen := false
+	for _, f := range window {
+		summary.Rejected += f.rejected.Load()
+		if f.Total == 0 {
+			continue
+		}
+
+		failureRate := f.FailureRate()
+		if !seen {
+			summary.MinFrameTotal, summary.MaxFrameTotal = f.Total, f.Total
+			summary.MinFrameFailureRate, summary.MaxFrameFailureRate = failureRate, failureRate
+			seen = true
+			continue
+		}
+		summary.MinFrameTotal = min(summary.MinFrameTotal, f.Total)
+		summary.MaxFrameTotal = max(summary.MaxFrameTotal, f.Total)
+		summary.MinFrameFailureRate = min(summary.MinFrameFailureRate, failureRate)
+		summary.MaxFrameFailureRate = max(summary.MaxFrameFailureRate, failureRate)
+	}
+
+	return summary
+}
+
+// reject Accounts a rejected call on the current frame, on its atomic counter under the read
+// lock so the rejections of an open circuit don't contend on the window.
+func (c *CircuitBreaker) reject() {
+	c.rollingWindow.mu.RLock()
+	defer c.rollingWindow.mu.RUnlock()
+
+	if window := c.rollingWindow.window; len(window) > 0 {
+		window[len(window)-1].rejected.Add(1)
+	}
+}
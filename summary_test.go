@@ -0,0 +1,99 @@
+package breaker
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreakerSummary(t *testing.T) {
+	cb, cancel, err := New(
+		WithCanTrip(func(summary Counts) bool { return false }),
+		WithWindowFrameThreshold(10),
+		WithWindowRollThreshold(30),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	feedFrameHelper(cb, 1, 9)
+	feedFrameHelper(cb, 5, 5)
+
+	summary := cb.Summary()
+	assert.Equal(t, Counts{Total: 20, Fail: 6, Success: 14}, summary.Counts)
+	assert.Equal(t, float64(30), summary.FailureRate())
+	assert.Equal(t, 3, summary.Frames)
+	assert.Equal(t, uint64(10), summary.MinFrameTotal)
+	assert.Equal(t, uint64(10), summary.MaxFrameTotal)
+	assert.Equal(t, float64(10), summary.MinFrameFailureRate)
+	assert.Equal(t, float64(50), summary.MaxFrameFailureRate)
+	assert.Zero(t, summary.RejectionRate())
+
+	cb.Trip()
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.ErrorIs(t, cb.Execute(fixtureCircuitCall(nil)), ErrOpenCircuit)
+		}()
+	}
+	wg.Wait()
+	summary = cb.Summary()
+	assert.Equal(t, uint64(5), summary.Rejected)
+	assert.Equal(t, float64(20), summary.RejectionRate())
+}
+
+func TestBreakerSummaryCanTrip(t *testing.T) {
+	cb, cancel, err := New(
+		WithSummaryCanTrip(func(summary Summary) bool { return summary.MaxFrameFailureRate >= 50 }),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	feedFrameHelper(cb, 0, 10)
+	require.Equal(t, Closed, cb.State())
+
+	syncFeedCircuitBreakerHelper(cb, []error{nil, errCall}, false)
+	assert.Equal(t, Open, cb.State())
+}
+
+func TestBreakerSummaryHalfOpenRejections(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  []option
+		last     error
+		expected State
+	}{
+		{name: "closed", last: nil, expected: Closed},
+		{name: "closed_separate_trials", options: []option{WithSeparateHalfOpenStats()}, last: nil, expected: Closed},
+		{name: "reopened", last: errCall, expected: Open},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cb, cancel, err := New(append([]option{
+				WithOpenDuration(1),
+				WithHalfOpenEvaluation(2, 1),
+				WithHalfOpenRate(200*time.Millisecond, 1),
+			}, tt.options...)...)
+			require.NoError(t, err)
+			defer cancel()
+
+			cb.Trip()
+			time.Sleep(cb.cfg.openDuration + (time.Millisecond * 100))
+			require.Equal(t, HalfOpen, cb.State())
+
+			assert.NoError(t, cb.Execute(fixtureCircuitCall(nil)))
+			assert.ErrorIs(t, cb.Execute(fixtureCircuitCall(nil)), ErrOpenCircuit)
+			assert.ErrorIs(t, cb.Execute(fixtureCircuitCall(nil)), ErrOpenCircuit)
+
+			time.Sleep(250 * time.Millisecond)
+			_ = cb.Execute(fixtureCircuitCall(tt.last))
+			require.Equal(t, tt.expected, cb.State())
+			assert.Equal(t, uint64(2), cb.Summary().Rejected)
+		})
+	}
+}
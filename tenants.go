@@ -0,0 +1,149 @@
+package breaker
+
+import (
+	"errors"
+	"sort"
+	"sync"
+)
+
+var ErrUnnamedTenant = errors.New("tenant has no id")
+
+// Manager Isolates the breakers of every tenant, each one created on first use from the
+// default options layered with the tenant overrides.
+type Manager struct {
+	defaults  []option
+	overrides map[string][]option
+	tenants   map[string]*breakerSet
+
+	mu sync.RWMutex
+}
+
+func NewManager(defaults ...option) *Manager {
+	return &Manager{
+		defaults:  defaults,
+		overrides: make(map[string][]option),
+		tenants:   make(map[string]*breakerSet),
+	}
+}
+
+// Override Layers opts over the defaults for the breakers of tenant created from now on.
+func (m *Manager) Override(tenant string, opts ...option) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.overrides[tenant] = opts
+	if set, ok := m.tenants[tenant]; ok {
+		set.configure(m.options(tenant))
+	}
+}
+
+// Get Returns the tenant breaker guarding name, creating it on first use under the
+// name "<tenant>/<name>".
+func (m *Manager) Get(tenant, name string) (*CircuitBreaker, error) {
+	if tenant == "" {
+		return nil, ErrUnnamedTenant
+	}
+	if name == "" {
+		return nil, ErrUnnamedBreaker
+	}
+
+	return m.tenant(tenant).get(name)
+}
+
+// tenant Returns the breaker set of tenant, creating it on first use.
+func (m *Manager) tenant(tenant string) *breakerSet {
+	m.mu.RLock()
+	set, ok := m.tenants[tenant]
+	m.mu.RUnlock()
+	if ok {
+		return set
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if set, ok := m.tenants[tenant]; ok {
+		return set
+	}
+	set = newBreakerSet(m.options(tenant))
+	set.prefix = tenant + "/"
+	m.tenants[tenant] = set
+
+	return set
+}
+
+func (m *Manager) options(tenant string) []option {
+	return append(append([]option{}, m.defaults...), m.overrides[tenant]...)
+}
+
+// Breakers Returns the breakers of tenant sorted by name.
+func (m *Manager) Breakers(tenant string) []*CircuitBreaker {
+	m.mu.RLock()
+	set, ok := m.tenants[tenant]
+	m.mu.RUnlock()
+	if !ok {
+		return []*CircuitBreaker{}
+	}
+
+	return set.list()
+}
+
+// Tenants Returns the ids of the tenants with at least one breaker, sorted.
+func (m *Manager) Tenants() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	tenants := make([]string, 0, len(m.tenants))
+	for tenant, set := range m.tenants {
+		if set.len() > 0 {
+			tenants = append(tenants, tenant)
+		}
+	}
+	sort.Strings(tenants)
+
+	return tenants
+}
+
+func (m *Manager) TripTenant(tenant string) {
+	for _, cb := range m.Breakers(tenant) {
+		cb.Trip()
+	}
+}
+
+func (m *Manager) ResetTenant(tenant string) {
+	for _, cb := range m.Breakers(tenant) {
+		cb.Reset()
+	}
+}
+
+func (m *Manager) Snapshots(tenant string) []Snapshot {
+	breakers := m.Breakers(tenant)
+	snapshots := make([]Snapshot, 0, len(breakers))
+	for _, cb := range breakers {
+		snapshots = append(snapshots, cb.Snapshot())
+	}
+	return snapshots
+}
+
+// RemoveTenant Stops and forgets every breaker of tenant along with its overrides.
+func (m *Manager) RemoveTenant(tenant string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if set, ok := m.tenants[tenant]; ok {
+		set.close()
+	}
+	delete(m.tenants, tenant)
+	delete(m.overrides, tenant)
+}
+
+// Close Stops every breaker of every tenant.
+func (m *Manager) Close() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for tenant, set := range m.tenants {
+		set.close()
+		delete(m.tenants, tenant)
+	}
+}
@@ -0,0 +1,70 @@
+package breaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManager(t *testing.T) {
+	manager := NewManager(WithOpenDuration(10))
+	defer manager.Close()
+
+	manager.Override("acme", WithOpenDuration(20))
+
+	_, err := manager.Get("", "payments")
+	assert.ErrorIs(t, err, ErrUnnamedTenant)
+	_, err = manager.Get("acme", "")
+	assert.ErrorIs(t, err, ErrUnnamedBreaker)
+
+	payments, err := manager.Get("acme", "payments")
+	require.NoError(t, err)
+	again, err := manager.Get("acme", "payments")
+	require.NoError(t, err)
+	assert.Same(t, payments, again)
+	assert.Equal(t, "acme/payments", payments.Name())
+	assert.Equal(t, 20*time.Second, payments.cfg.openDuration)
+
+	_, err = manager.Get("acme", "orders")
+	require.NoError(t, err)
+	other, err := manager.Get("globex", "payments")
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Second, other.cfg.openDuration)
+
+	assert.Equal(t, []string{"acme", "globex"}, manager.Tenants())
+
+	manager.Override("acme", WithOpenDuration(30))
+	refunds, err := manager.Get("acme", "refunds")
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Second, refunds.cfg.openDuration)
+	assert.Equal(t, 20*time.Second, payments.cfg.openDuration)
+
+	manager.TripTenant("acme")
+	snapshots := manager.Snapshots("acme")
+	require.Len(t, snapshots, 3)
+	assert.Equal(t, "acme/orders", snapshots[0].Name)
+	assert.Equal(t, Open, snapshots[0].State)
+	assert.Equal(t, Open, snapshots[1].State)
+	assert.Equal(t, Closed, other.State())
+
+	manager.ResetTenant("acme")
+	for _, cb := range manager.Breakers("acme") {
+		assert.Equal(t, Closed, cb.State())
+	}
+
+	manager.RemoveTenant("acme")
+	assert.Empty(t, manager.Breakers("acme"))
+	assert.Equal(t, []string{"globex"}, manager.Tenants())
+}
+
+func TestManagerInvalidOverride(t *testing.T) {
+	manager := NewManager()
+	defer manager.Close()
+
+	manager.Override("acme", WithOpenDuration(0))
+	_, err := manager.Get("acme", "payments")
+	assert.ErrorIs(t, err, ErrInvalidOpenDuration)
+	assert.Empty(t, manager.Tenants())
+}
@@ -0,0 +1,58 @@
+package breaker
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrInvalidThresholds = errors.New("invalid thresholds")
+
+// Thresholds Overrides at runtime what trips and reopens the circuit, zero fields keep the
+// configured behaviour.
+type Thresholds struct {
+	// FailureRate Is the percentage of failed calls over the window tripping the circuit,
+	// in place of the trip predicate.
+	FailureRate float64
+	// OpenDuration Is how long the circuit rejects calls once open before probing.
+	OpenDuration time.Duration
+}
+
+func (t Thresholds) validate() error {
+	if t.FailureRate < 0 || t.FailureRate > 100 {
+		return fmt.Errorf("%w: failure rate must be between 0 and 100", ErrInvalidThresholds)
+	}
+	if t.OpenDuration < 0 {
+		return fmt.Errorf("%w: open duration can't be less than zero", ErrInvalidThresholds)
+	}
+	return nil
+}
+
+// Reconfigure Replaces the runtime thresholds, Thresholds{} restoring the configured ones. An
+// open circuit keeps the open duration it was opened with. Under WithProfiles they hold until
+// the matched profile changes.
+func (c *CircuitBreaker) Reconfigure(t Thresholds) error {
+	if err := t.validate(); err != nil {
+		return err
+	}
+
+	c.thresholds.Store(&t)
+	return nil
+}
+
+// Thresholds Returns the runtime thresholds in effect, zero fields meaning the configured ones.
+func (c *CircuitBreaker) Thresholds() Thresholds {
+	if t := c.thresholds.Load(); t != nil {
+		return *t
+	}
+	return Thresholds{}
+}
+
+// openDuration Returns the open duration in effect, escalated while flapping.
+func (c *CircuitBreaker) openDuration() time.Duration {
+	d := c.cfg.openDuration
+	if t := c.thresholds.Load(); t != nil && t.OpenDuration > 0 {
+		d = t.OpenDuration
+	}
+	return c.flaps.escalate(d, c.clock.Now())
+}
@@ -0,0 +1,38 @@
+package breaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreakerReconfigure(t *testing.T) {
+	cb, cancel, err := New(
+		WithCanTrip(func(summary Counts) bool { return false }),
+		WithOpenDuration(1),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	assert.ErrorIs(t, cb.Reconfigure(Thresholds{FailureRate: 101}), ErrInvalidThresholds)
+	assert.ErrorIs(t, cb.Reconfigure(Thresholds{OpenDuration: -1}), ErrInvalidThresholds)
+	assert.Equal(t, Thresholds{}, cb.Thresholds())
+
+	require.NoError(t, cb.Reconfigure(Thresholds{FailureRate: 50, OpenDuration: time.Minute}))
+	assert.Equal(t, Thresholds{FailureRate: 50, OpenDuration: time.Minute}, cb.Thresholds())
+	assert.Equal(t, time.Minute, cb.Snapshot().Config.OpenDuration)
+
+	calls := make([]error, 2*_minimumVolume)
+	for i := _minimumVolume; i < len(calls); i++ {
+		calls[i] = errCall
+	}
+	syncFeedCircuitBreakerHelper(cb, calls[:len(calls)-1], false)
+	assert.Equal(t, Closed, cb.State())
+	syncFeedCircuitBreakerHelper(cb, calls[len(calls)-1:], false)
+	assert.Equal(t, Open, cb.State())
+
+	require.NoError(t, cb.Reconfigure(Thresholds{}))
+	assert.Equal(t, time.Second, cb.Snapshot().Config.OpenDuration)
+}
@@ -0,0 +1,46 @@
+package breaker
+
+// Throughput Returns the calls per second of a complete frame, zero for the current one.
+func (f Frame) Throughput() float64 {
+	if !f.Complete || !f.End.After(f.Start) {
+		return 0
+	}
+	return float64(f.Total) / f.End.Sub(f.Start).Seconds()
+}
+
+// throughputCollapsed Reports whether the successful calls of the current frame fell under
+// the collapse ratio of their mean over the previous frames, catching a dependency whose
+// calls hang rather than fail. A baseline under the minimum volume never collapses.
+func (c *CircuitBreaker) throughputCollapsed() bool {
+	if c.collapseRatio == 0 {
+		return false
+	}
+
+	c.rollingWindow.mu.RLock()
+	defer c.rollingWindow.mu.RUnlock()
+
+	window := c.rollingWindow.window
+	if len(window) < 2 {
+		return false
+	}
+
+	var successes uint64
+	for _, f := range window[:len(window)-1] {
+		successes += f.Success
+	}
+	baseline := float64(successes) / float64(len(window)-1)
+	if baseline < _minimumVolume {
+		return false
+	}
+
+	return float64(window[len(window)-1].Success) < baseline*c.collapseRatio
+}
+
+// collapse Opens a closed circuit whose throughput collapsed, unless a mode overrides it.
+func (c *CircuitBreaker) collapse() {
+	defer c.lockState()()
+
+	if c.state.mode == ModeNormal && c.state.s == Closed && !c.external.holdsClosed() {
+		c.open(ReasonThroughputCollapse)
+	}
+}
@@ -0,0 +1,64 @@
+package breaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrameThroughput(t *testing.T) {
+	start := time.Date(2024, time.January, 15, 10, 0, 0, 0, time.UTC)
+
+	complete := Frame{Counts: Counts{Total: 50}, Start: start, End: start.Add(10 * time.Second), Complete: true}
+	assert.Equal(t, float64(5), complete.Throughput())
+
+	current := Frame{Counts: Counts{Total: 50}, Start: start}
+	assert.Equal(t, float64(0), current.Throughput())
+}
+
+func TestBreakerThroughputCollapse(t *testing.T) {
+	tt := []struct {
+		name     string
+		input    []option
+		expected State
+	}{
+		{
+			name:     "ignores_collapse_by_default",
+			input:    nil,
+			expected: Closed,
+		},
+		{
+			name:     "trips_on_collapse",
+			input:    []option{WithThroughputCollapse(0.5)},
+			expected: Open,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			cb, cancel, err := New(append([]option{
+				WithCanTrip(func(summary Counts) bool { return false }),
+				WithWindowFrameThreshold(10),
+				WithWindowRollThreshold(30),
+			}, tc.input...)...)
+			require.NoError(t, err)
+			defer cancel()
+
+			feedFrameHelper(cb, 0, 20)
+			feedFrameHelper(cb, 0, 20)
+
+			syncFeedCircuitBreakerHelper(cb, make([]error, 20), false)
+			cb.renewFrame()
+			require.Equal(t, Closed, cb.State())
+
+			syncFeedCircuitBreakerHelper(cb, make([]error, 2), false)
+			cb.renewFrame()
+			assert.Equal(t, tc.expected, cb.State())
+			if tc.expected == Open {
+				assert.Equal(t, ReasonThroughputCollapse, cb.Transitions(1)[0].Reason)
+			}
+		})
+	}
+}
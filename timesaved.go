@@ -0,0 +1,36 @@
+package breaker
+
+import (
+	"sync"
+	"time"
+)
+
+type timeSaved struct {
+	shortCircuits uint64
+	saved         time.Duration
+
+	mu sync.RWMutex
+}
+
+// add Accounts a short-circuited call as having saved the given latency.
+func (t *timeSaved) add(latency time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.shortCircuits++
+	t.saved += latency
+}
+
+func (t *timeSaved) copy() (uint64, time.Duration) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.shortCircuits, t.saved
+}
+
+// TimeSaved Estimates the latency avoided by failing fast, every rejection while open is
+// accounted with the mean latency of the recent calls.
+func (c *CircuitBreaker) TimeSaved() time.Duration {
+	_, saved := c.timeSaved.copy()
+	return saved
+}
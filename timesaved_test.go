@@ -0,0 +1,33 @@
+package breaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreakerTimeSaved(t *testing.T) {
+	cb, cancel, err := New()
+	require.NoError(t, err)
+	defer cancel()
+
+	for i := 0; i < 2; i++ {
+		require.NoError(t, cb.Execute(func() error {
+			time.Sleep(10 * time.Millisecond)
+			return nil
+		}))
+	}
+	assert.Zero(t, cb.TimeSaved())
+
+	cb.Trip()
+	for i := 0; i < 3; i++ {
+		assert.ErrorIs(t, cb.Execute(fixtureCircuitCall(nil)), ErrOpenCircuit)
+	}
+
+	snapshot := cb.Snapshot()
+	assert.Equal(t, uint64(3), snapshot.ShortCircuits)
+	assert.GreaterOrEqual(t, snapshot.TimeSaved, 30*time.Millisecond)
+	assert.Equal(t, snapshot.TimeSaved, cb.TimeSaved())
+}
@@ -0,0 +1,30 @@
+package breaker
+
+import (
+	"bytes"
+	"runtime/trace"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreakerTrace(t *testing.T) {
+	cb, cancel, err := New(
+		WithName("payments"),
+		WithTrace(),
+		WithCanTrip(func(summary Counts) bool { return summary.Fail > 0 }),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	var buf bytes.Buffer
+	require.NoError(t, trace.Start(&buf))
+	syncFeedCircuitBreakerHelper(cb, []error{nil, errCall, nil}, false)
+	trace.Stop()
+
+	captured := buf.Bytes()
+	for _, expected := range []string{"breaker: payments", string(OutcomeSuccess), string(OutcomeFailure), "rejected", ErrOpenCircuit.Error()} {
+		assert.True(t, bytes.Contains(captured, []byte(expected)), "trace misses %q", expected)
+	}
+}
@@ -0,0 +1,38 @@
+package breaker
+
+import "time"
+
+type Reason string
+
+const (
+	ReasonFailureRate         Reason = "failure-rate"
+	ReasonHalfOpenFailure     Reason = "half-open-failure"
+	ReasonProbeSuccess        Reason = "probe-success"
+	ReasonOpenDurationElapsed Reason = "open-duration-elapsed"
+	ReasonManualTrip          Reason = "manual-trip"
+	ReasonManualReset         Reason = "manual-reset"
+	ReasonManualProbe         Reason = "manual-probe"
+	ReasonFleetQuorum         Reason = "fleet-quorum"
+	ReasonSharedState         Reason = "shared-state"
+	ReasonThroughputCollapse  Reason = "throughput-collapse"
+	ReasonExternalHealth      Reason = "external-health"
+)
+
+type Transition struct {
+	From    State     `json:"from"`
+	To      State     `json:"to"`
+	Reason  Reason    `json:"reason"`
+	Time    time.Time `json:"time"`
+	Frame   Counts    `json:"frame"`
+	Summary Counts    `json:"summary"`
+}
+
+// Transitions Returns up to limit of the most recent transitions from the oldest to the
+// newest, a limit less than equal zero returns the whole history.
+func (c *CircuitBreaker) Transitions(limit int) []Transition {
+	transitions := c.transitions.copy()
+	if limit > 0 && limit < len(transitions) {
+		transitions = transitions[len(transitions)-limit:]
+	}
+	return transitions
+}
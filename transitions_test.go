@@ -0,0 +1,68 @@
+package breaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreakerTransitions(t *testing.T) {
+	cb, cancel, err := New()
+	require.NoError(t, err)
+	defer cancel()
+
+	assert.Empty(t, cb.Transitions(0))
+
+	syncFeedCircuitBreakerHelper(cb, []error{nil, errCall}, false)
+	cb.Trip()
+	cb.Reset()
+	cb.Trip()
+
+	got := cb.Transitions(0)
+	require.Len(t, got, 3)
+	assert.Equal(t, Closed, got[0].From)
+	assert.Equal(t, Open, got[0].To)
+	assert.Equal(t, ReasonManualTrip, got[0].Reason)
+	assert.Equal(t, Counts{Total: 2, Fail: 1, Success: 1}, got[0].Summary)
+	assert.Equal(t, Open, got[1].From)
+	assert.Equal(t, Closed, got[1].To)
+	assert.Equal(t, ReasonManualReset, got[1].Reason)
+	assert.Equal(t, Counts{}, got[2].Summary)
+	assert.False(t, got[1].Time.Before(got[0].Time))
+
+	tt := []struct {
+		name     string
+		limit    int
+		expected []Transition
+	}{
+		{name: "returns_all_when_limit_is_zero", limit: 0, expected: got},
+		{name: "returns_most_recent", limit: 2, expected: got[1:]},
+		{name: "returns_all_when_limit_exceeds_history", limit: 10, expected: got},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, cb.Transitions(tc.limit))
+		})
+	}
+}
+
+func TestBreakerTransitionReasons(t *testing.T) {
+	cb, cancel, err := New(
+		WithOpenDuration(1),
+		WithCanTrip(func(summary Counts) bool { return summary.Fail > 0 }),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	syncFeedCircuitBreakerHelper(cb, []error{errCall}, false)
+	time.Sleep(cb.cfg.openDuration + (time.Millisecond * 200))
+	syncFeedCircuitBreakerHelper(cb, []error{errCall}, false)
+
+	got := make([]Reason, 0, 3)
+	for _, transition := range cb.Transitions(0) {
+		got = append(got, transition.Reason)
+	}
+	assert.Equal(t, []Reason{ReasonFailureRate, ReasonOpenDurationElapsed, ReasonHalfOpenFailure}, got)
+}
@@ -0,0 +1,34 @@
+package breaker
+
+import (
+	"errors"
+	"reflect"
+	"time"
+)
+
+// tripOnErrors Narrows classifier down to the errors matching one of targets, the other
+// errors counting as successes.
+func tripOnErrors(targets []error, classifier classifier) classifier {
+	return func(err error, latency time.Duration) Outcome {
+		if err != nil && !matchesAny(err, targets) {
+			return OutcomeSuccess
+		}
+		return classifier(err, latency)
+	}
+}
+
+// matchesAny Reports whether err matches a target by errors.Is, or by errors.As when the
+// target is a nil pointer standing for its type.
+func matchesAny(err error, targets []error) bool {
+	for _, target := range targets {
+		if errors.Is(err, target) {
+			return true
+		}
+
+		v := reflect.ValueOf(target)
+		if v.Kind() == reflect.Pointer && v.IsNil() && errors.As(err, reflect.New(v.Type()).Interface()) {
+			return true
+		}
+	}
+	return false
+}
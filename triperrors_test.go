@@ -0,0 +1,52 @@
+package breaker
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTripOnErrors(t *testing.T) {
+	classify := tripOnErrors([]error{syscall.ECONNREFUSED, (*net.OpError)(nil)}, defaultClassifier)
+
+	tt := []struct {
+		name     string
+		err      error
+		expected Outcome
+	}{
+		{name: "success", err: nil, expected: OutcomeSuccess},
+		{name: "matching_value", err: syscall.ECONNREFUSED, expected: OutcomeFailure},
+		{name: "wrapped_matching_value", err: fmt.Errorf("dial: %w", syscall.ECONNREFUSED), expected: OutcomeFailure},
+		{name: "matching_type", err: fmt.Errorf("dial: %w", &net.OpError{Op: "dial", Err: errCall}), expected: OutcomeFailure},
+		{name: "other_error", err: errCall, expected: OutcomeSuccess},
+		{name: "other_error_of_the_value_type", err: errors.New("connection refused"), expected: OutcomeSuccess},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, classify(tc.err, time.Millisecond))
+		})
+	}
+}
+
+func TestBreakerTripOnErrors(t *testing.T) {
+	cb, cancel, err := New(
+		WithCanTrip(func(summary Counts) bool { return summary.Fail > 0 }),
+		WithTripOnErrors(syscall.ECONNREFUSED),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	assert.ErrorIs(t, cb.Execute(fixtureCircuitCall(errCall)), errCall)
+	assert.Equal(t, Closed, cb.State())
+	assert.Equal(t, Counts{Total: 1, Success: 1}, cb.Counts())
+
+	assert.ErrorIs(t, cb.Execute(fixtureCircuitCall(syscall.ECONNREFUSED)), syscall.ECONNREFUSED)
+	assert.Equal(t, Open, cb.State())
+}
@@ -0,0 +1,75 @@
+package breaker
+
+import (
+	"math"
+	"sync"
+)
+
+const (
+	_tunerAlpha  = 0.05
+	_tunerWarmup = 10
+)
+
+// tuner Learns the baseline failure rate of the dependency from the completed frames holding
+// enough calls, as exponentially weighted mean and variance, and trips at the baseline plus
+// k standard deviations kept within bounds.
+type tuner struct {
+	k        float64
+	minRate  float64
+	maxRate  float64
+	mean     float64
+	variance float64
+	observed int
+
+	mu sync.RWMutex
+}
+
+func newTuner(k, minRate, maxRate float64) *tuner {
+	return &tuner{k: k, minRate: minRate, maxRate: maxRate}
+}
+
+func (t *tuner) observe(frame Counts) {
+	if frame.Total < _minimumVolume {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	rate := frame.FailureRate()
+	if t.observed == 0 {
+		t.mean = rate
+	} else {
+		diff := rate - t.mean
+		incr := _tunerAlpha * diff
+		t.mean += incr
+		t.variance = (1 - _tunerAlpha) * (t.variance + diff*incr)
+	}
+	t.observed++
+}
+
+// threshold Returns the failure rate tripping the circuit, the upper bound until enough
+// frames were observed to trust the baseline.
+func (t *tuner) threshold() float64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.observed < _tunerWarmup {
+		return t.maxRate
+	}
+
+	return math.Min(t.maxRate, math.Max(t.minRate, t.mean+t.k*math.Sqrt(t.variance)))
+}
+
+func (t *tuner) canTrip(summary Counts) bool {
+	return summary.Total > _minimumVolume && summary.FailureRate() >= t.threshold()
+}
+
+// TripThreshold Returns the failure rate currently tripping an adaptive breaker, zero when
+// the breaker wasn't created with WithAdaptiveThreshold.
+func (c *CircuitBreaker) TripThreshold() float64 {
+	if c.tuner == nil {
+		return 0
+	}
+	return c.tuner.threshold()
+}
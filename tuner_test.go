@@ -0,0 +1,82 @@
+package breaker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func feedFrameHelper(cb *CircuitBreaker, failures, successes int) {
+	calls := make([]error, 0, failures+successes)
+	for i := 0; i < failures; i++ {
+		calls = append(calls, errCall)
+	}
+	for i := 0; i < successes; i++ {
+		calls = append(calls, nil)
+	}
+	syncFeedCircuitBreakerHelper(cb, calls, false)
+	cb.moveWindow()
+}
+
+func TestTunerThreshold(t *testing.T) {
+	tt := []struct {
+		name     string
+		frames   []Counts
+		expected float64
+	}{
+		{name: "upper_bound_while_warming_up", frames: repeatCounts(Counts{Total: 20, Fail: 2}, _tunerWarmup-1), expected: 80},
+		{name: "ignores_low_volume_frames", frames: repeatCounts(Counts{Total: 5, Fail: 5}, 50), expected: 80},
+		{name: "lower_bound_on_pristine_dependency", frames: repeatCounts(Counts{Total: 20}, 50), expected: 5},
+		{name: "baseline_on_steady_dependency", frames: repeatCounts(Counts{Total: 20, Fail: 6}, 50), expected: 30},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			tuner := newTuner(3, 5, 80)
+			for _, frame := range tc.frames {
+				tuner.observe(frame)
+			}
+			assert.InDelta(t, tc.expected, tuner.threshold(), 0.001)
+		})
+	}
+
+	noisy := newTuner(3, 5, 80)
+	for i := 0; i < 50; i++ {
+		noisy.observe(Counts{Total: 20, Fail: uint64(2 + (i%2)*2)})
+	}
+	assert.InDelta(t, 30, noisy.threshold(), 2)
+}
+
+func repeatCounts(c Counts, n int) []Counts {
+	counts := make([]Counts, n)
+	for i := range counts {
+		counts[i] = c
+	}
+	return counts
+}
+
+func TestBreakerAdaptiveThreshold(t *testing.T) {
+	cb, cancel, err := New(
+		WithAdaptiveThreshold(3, 5, 80),
+		WithWindowFrameThreshold(10),
+		WithWindowRollThreshold(30),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	assert.Equal(t, float64(80), cb.TripThreshold())
+	for i := 0; i < _tunerWarmup; i++ {
+		feedFrameHelper(cb, 0, 20)
+	}
+	assert.Equal(t, float64(5), cb.TripThreshold())
+	assert.Equal(t, Closed, cb.State())
+
+	syncFeedCircuitBreakerHelper(cb, []error{errCall, errCall, errCall}, false)
+	assert.Equal(t, Open, cb.State())
+
+	other, cancel, err := New()
+	require.NoError(t, err)
+	defer cancel()
+	assert.Equal(t, float64(0), other.TripThreshold())
+}
@@ -0,0 +1,28 @@
+package breaker
+
+import "time"
+
+// view Is the immutable state readers load without locking, writers publish a modified
+// copy while holding the lock guarding the fields they change.
+type view struct {
+	state      State
+	mode       Mode
+	summary    Counts
+	frame      Counts
+	halfOpenAt time.Time
+}
+
+func (c *CircuitBreaker) publish(update func(v *view)) {
+	for {
+		current := c.view.Load()
+		next := *current
+		update(&next)
+		if c.view.CompareAndSwap(current, &next) {
+			return
+		}
+	}
+}
+
+func (c *CircuitBreaker) load() *view {
+	return c.view.Load()
+}
@@ -0,0 +1,55 @@
+package breaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreakerReadersDontContendWithWriters(t *testing.T) {
+	cb, cancel, err := New()
+	require.NoError(t, err)
+	defer cancel()
+
+	syncFeedCircuitBreakerHelper(cb, []error{errCall, nil}, false)
+
+	cb.state.mu.Lock()
+	defer cb.state.mu.Unlock()
+
+	done := make(chan Snapshot)
+	go func() {
+		assert.NoError(t, cb.canExecute())
+		assert.Equal(t, Closed, cb.State())
+		assert.Equal(t, ModeNormal, cb.Mode())
+		done <- cb.Snapshot()
+	}()
+
+	select {
+	case got := <-done:
+		assert.Equal(t, Counts{Total: 2, Fail: 1, Success: 1}, got.Summary)
+		assert.Equal(t, Counts{Total: 2, Fail: 1, Success: 1}, got.Frame)
+	case <-time.After(time.Second):
+		t.Fatal("readers blocked on the state lock")
+	}
+}
+
+func TestBreakerViewFollowsWriters(t *testing.T) {
+	cb, cancel, err := New()
+	require.NoError(t, err)
+	defer cancel()
+
+	syncFeedCircuitBreakerHelper(cb, []error{errCall, nil, nil}, false)
+	assert.Equal(t, &view{
+		state:   Closed,
+		mode:    ModeNormal,
+		summary: Counts{Total: 3, Fail: 1, Success: 2},
+		frame:   Counts{Total: 3, Fail: 1, Success: 2},
+	}, cb.load())
+
+	require.NoError(t, cb.SetMode(ModeForcedClosed))
+	cb.Trip()
+	cb.Reset()
+	assert.Equal(t, &view{state: Closed, mode: ModeForcedClosed}, cb.load())
+}
@@ -0,0 +1,21 @@
+package breaker
+
+import "context"
+
+// ExecuteWeighted Runs fn as weight calls, such as the items of a batch, so its outcome
+// weighs on the window in proportion to its cost. A zero weight counts as one call.
+func (c *CircuitBreaker) ExecuteWeighted(weight uint64, fn circuitCall) error {
+	return c.execute(context.Background(), callOptions{priority: Normal, weight: weight}, func(context.Context) error {
+		return fn()
+	})
+}
+
+func scaleCounts(c Counts, weight uint64) Counts {
+	return Counts{
+		Total:   c.Total * weight,
+		Fail:    c.Fail * weight,
+		Success: c.Success * weight,
+		Slow:    c.Slow * weight,
+		Ignored: c.Ignored * weight,
+	}
+}
@@ -0,0 +1,41 @@
+package breaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreakerExecuteWeighted(t *testing.T) {
+	cb, cancel, err := New()
+	require.NoError(t, err)
+	defer cancel()
+
+	syncFeedCircuitBreakerHelper(cb, make([]error, 20), false)
+	assert.NoError(t, cb.ExecuteWeighted(0, fixtureCircuitCall(nil)))
+	assert.Equal(t, Counts{Total: 21, Success: 21}, cb.Counts())
+
+	assert.ErrorIs(t, cb.ExecuteWeighted(40, fixtureCircuitCall(errCall)), errCall)
+	assert.Equal(t, Counts{Total: 61, Fail: 40, Success: 21}, cb.Counts())
+	assert.Equal(t, Open, cb.State())
+}
+
+func TestBreakerExecuteWeightedStrategy(t *testing.T) {
+	log, err := NewSlidingLog(time.Minute, 100)
+	require.NoError(t, err)
+	cb, cancel, err := New(
+		WithWindowStrategy(log),
+		WithCanTrip(func(summary Counts) bool { return false }),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	assert.ErrorIs(t, cb.ExecuteWeighted(3, fixtureCircuitCall(errCall)), errCall)
+	assert.Equal(t, Counts{Total: 3, Fail: 3}, cb.tripCounts())
+	assert.Len(t, log.entries, 1)
+
+	assert.NoError(t, cb.ExecuteWeighted(500, fixtureCircuitCall(nil)))
+	assert.Equal(t, Counts{Total: 503, Fail: 3, Success: 500}, cb.tripCounts())
+}
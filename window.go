@@ -0,0 +1,98 @@
+package breaker
+
+import "time"
+
+type Frame struct {
+	Counts
+	Labels       LabelCounts  `json:"labels,omitempty"`
+	Measurements Measurements `json:"measurements,omitempty"`
+	Start        time.Time    `json:"start"`
+	End          time.Time    `json:"end"`
+	Complete     bool         `json:"complete"`
+}
+
+// expireStaleFrames Clears the counts of the frames that ended over a roll ago, which only
+// happens when the frame rotation stalled, so they stop weighing on the summary. The rolling
+// window lock must be held.
+func (c *CircuitBreaker) expireStaleFrames(now time.Time) {
+	window := c.rollingWindow.window
+	for i := 0; i < c.staleFrames(now); i++ {
+		if window[i].Counts == (Counts{}) {
+			continue
+		}
+		c.summary.counts = subCounts(c.summary.counts, window[i].Counts)
+		window[i] = newFrame(window[i].start)
+	}
+}
+
+// staleFrames Returns how many of the oldest frames ended over a roll ago, the roll stretched
+// by the jitter since jittered frames may outlast it, the rolling window lock must be held,
+// for reading at least.
+func (c *CircuitBreaker) staleFrames(now time.Time) int {
+	window := c.rollingWindow.window
+	cutoff := now.Add(-c.jitter.stretch(c.cfg.windowRoll))
+	i := 0
+	for i < len(window)-1 && !window[i+1].start.After(cutoff) {
+		i++
+	}
+	return i
+}
+
+// expireStale Clears the frames that ended over a roll ago on read, as calls do before being
+// accounted, so an idle breaker whose frame rotation stalled reports fresh counts. The write
+// lock is only taken when a stale frame still holds counts.
+func (c *CircuitBreaker) expireStale() {
+	now := c.clock.Now()
+	if !c.holdsStaleCounts(now) {
+		return
+	}
+
+	c.rollingWindow.mu.Lock()
+	defer c.rollingWindow.mu.Unlock()
+
+	c.expireStaleFrames(now)
+	c.publishSummary()
+}
+
+func (c *CircuitBreaker) holdsStaleCounts(now time.Time) bool {
+	c.rollingWindow.mu.RLock()
+	defer c.rollingWindow.mu.RUnlock()
+
+	for _, f := range c.rollingWindow.window[:c.staleFrames(now)] {
+		if f.Counts != (Counts{}) {
+			return true
+		}
+	}
+	return false
+}
+
+// WindowSnapshot Returns the rolling window frames from the oldest to the current one.
+// Every frame but the current is complete and ends where its successor starts.
+func (c *CircuitBreaker) WindowSnapshot() []Frame {
+	c.rollingWindow.mu.RLock()
+	defer c.rollingWindow.mu.RUnlock()
+
+	window := c.rollingWindow.window
+	snapshot := make([]Frame, len(window), cap(window))
+	for i, f := range window {
+		snapshot[i] = Frame{
+			Counts:       f.Counts,
+			Labels:       f.labels.copy(),
+			Measurements: f.measurements.copy(),
+			Start:        f.start,
+		}
+		if i < (len(window) - 1) {
+			snapshot[i].End = window[i+1].start
+			snapshot[i].Complete = true
+		}
+	}
+
+	return snapshot
+}
+
+// History Returns the complete frames kept after they left the rolling window, from the
+// oldest to the most recent, nil unless built with WithHistoryRetention. Followed by
+// WindowSnapshot, it tells how the failure rate evolved before a trip.
+func (c *CircuitBreaker) History() []Frame {
+	return c.frameHistory.copy()
+}
@@ -0,0 +1,217 @@
+package breaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreakerWindowSnapshot(t *testing.T) {
+	calls := []error{errCall, nil, nil}
+
+	before := time.Now()
+	cb, cancel, err := New(
+		WithWindowFrameThreshold(1),
+		WithWindowRollThreshold(3),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	syncFeedCircuitBreakerHelper(cb, calls, false)
+	time.Sleep(cb.cfg.windowFrame + (time.Millisecond * 500))
+	syncFeedCircuitBreakerHelper(cb, calls[:1], false)
+
+	snapshot := cb.WindowSnapshot()
+	require.Len(t, snapshot, 3)
+
+	for i, f := range snapshot[:2] {
+		assert.True(t, f.Complete)
+		assert.Equal(t, snapshot[i+1].Start, f.End)
+		assert.Equal(t, cb.cfg.windowFrame, f.End.Sub(f.Start).Round(time.Second))
+	}
+
+	current := snapshot[2]
+	assert.False(t, current.Complete)
+	assert.True(t, current.End.IsZero())
+	assert.True(t, current.Start.After(before))
+	assert.Equal(t, Counts{Total: 1, Fail: 1}, current.Counts)
+	assert.Equal(t, Counts{Total: 3, Fail: 1, Success: 2}, snapshot[1].Counts)
+}
+
+func TestBreakerOnFrameRoll(t *testing.T) {
+	type roll struct {
+		completed Counts
+		summary   Counts
+	}
+
+	calls := []error{errCall, nil, nil}
+	rolls := make(chan roll, 2)
+
+	cb, cancel, err := New(
+		WithWindowFrameThreshold(1),
+		WithWindowRollThreshold(2),
+		WithOnFrameRoll(func(completed Counts, summary Counts) {
+			rolls <- roll{completed: completed, summary: summary}
+		}),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	syncFeedCircuitBreakerHelper(cb, calls, false)
+	assert.Equal(t, roll{
+		completed: Counts{Total: 3, Fail: 1, Success: 2},
+		summary:   Counts{Total: 3, Fail: 1, Success: 2},
+	}, <-rolls)
+
+	syncFeedCircuitBreakerHelper(cb, calls[1:], false)
+	assert.Equal(t, roll{
+		completed: Counts{Total: 2, Success: 2},
+		summary:   Counts{Total: 2, Success: 2},
+	}, <-rolls)
+}
+
+func TestBreakerWindowRollsAfterRecovery(t *testing.T) {
+	cb, cancel, err := New(
+		WithWindowFrameThreshold(1),
+		WithWindowRollThreshold(3),
+		WithOpenDuration(1),
+		WithCanTrip(func(summary Counts) bool { return summary.Fail > 0 }),
+		WithHalfOpenEvaluation(1, 1),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	syncFeedCircuitBreakerHelper(cb, []error{errCall}, false)
+	require.Equal(t, Open, cb.State())
+
+	time.Sleep(2500 * time.Millisecond)
+	syncFeedCircuitBreakerHelper(cb, []error{nil}, false)
+	require.Equal(t, Closed, cb.State())
+	assert.Equal(t, Counts{Total: 2, Fail: 1, Success: 1}, cb.Counts())
+	assert.Len(t, cb.WindowSnapshot(), 3)
+
+	time.Sleep(time.Second)
+	assert.Equal(t, Counts{Total: 1, Success: 1}, cb.Counts())
+}
+
+func TestBreakerWithWindowRolls(t *testing.T) {
+	cb, cancel, err := New(WithWindow(600*time.Millisecond, 3))
+	require.NoError(t, err)
+	defer cancel()
+
+	syncFeedCircuitBreakerHelper(cb, []error{errCall, nil}, false)
+	assert.Equal(t, Counts{Total: 2, Fail: 1, Success: 1}, cb.Counts())
+
+	time.Sleep(700 * time.Millisecond)
+	assert.Equal(t, Counts{}, cb.Counts())
+	assert.Len(t, cb.WindowSnapshot(), 3)
+}
+
+func TestBreakerExpireStaleFrames(t *testing.T) {
+	cb, cancel, err := New(
+		WithCanTrip(func(summary Counts) bool { return false }),
+		WithWindowFrameThreshold(10),
+		WithWindowRollThreshold(30),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	syncFeedCircuitBreakerHelper(cb, []error{errCall, errCall}, false)
+	cb.moveWindow()
+	syncFeedCircuitBreakerHelper(cb, []error{nil}, false)
+	cb.moveWindow()
+	assert.Equal(t, Counts{Total: 3, Fail: 2, Success: 1}, cb.Counts())
+
+	// Reading fresh frames doesn't wait on the window write lock.
+	cb.rollingWindow.mu.RLock()
+	assert.Equal(t, Counts{Total: 3, Fail: 2, Success: 1}, cb.Counts())
+	assert.Equal(t, uint64(3), cb.Summary().Total)
+	assert.Equal(t, uint64(3), cb.Snapshot().Summary.Total)
+	cb.rollingWindow.mu.RUnlock()
+
+	// Pretend the rotation stalled for over a roll since the last frame started.
+	cb.rollingWindow.mu.Lock()
+	for i := range cb.rollingWindow.window {
+		cb.rollingWindow.window[i].start = cb.rollingWindow.window[i].start.Add(-35 * time.Second)
+	}
+	cb.rollingWindow.mu.Unlock()
+
+	assert.Equal(t, Counts{}, cb.Counts())
+	assert.Equal(t, make([]Counts, 3, 5), windowCountsHelper(cb))
+
+	syncFeedCircuitBreakerHelper(cb, []error{nil}, false)
+	assert.Equal(t, Counts{Total: 1, Success: 1}, cb.Counts())
+}
+
+func TestBreakerExpireStaleFramesJitter(t *testing.T) {
+	cb, cancel, err := New(
+		WithCanTrip(func(summary Counts) bool { return false }),
+		WithWindowFrameThreshold(10),
+		WithWindowRollThreshold(30),
+		WithJitter(0.2),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	syncFeedCircuitBreakerHelper(cb, []error{errCall, errCall}, false)
+	cb.moveWindow()
+	syncFeedCircuitBreakerHelper(cb, []error{nil}, false)
+	cb.moveWindow()
+
+	shift := func(d time.Duration) {
+		cb.rollingWindow.mu.Lock()
+		defer cb.rollingWindow.mu.Unlock()
+		for i := range cb.rollingWindow.window {
+			cb.rollingWindow.window[i].start = cb.rollingWindow.window[i].start.Add(d)
+		}
+	}
+
+	// Jittered frames may outlast the roll by up to a fifth.
+	shift(-33 * time.Second)
+	assert.Equal(t, Counts{Total: 3, Fail: 2, Success: 1}, cb.Counts())
+
+	shift(-5 * time.Second)
+	assert.Equal(t, Counts{}, cb.Counts())
+}
+
+func TestBreakerHistory(t *testing.T) {
+	cb, cancel, err := New(
+		WithWindowFrameThreshold(60),
+		WithWindowRollThreshold(120),
+		WithHistoryRetention(2),
+	)
+	require.NoError(t, err)
+	defer cancel()
+
+	for i := 1; i <= 5; i++ {
+		feedFrameHelper(cb, 0, i)
+	}
+
+	history := cb.History()
+	require.Len(t, history, 2)
+	assert.Equal(t, Counts{Total: 3, Success: 3}, history[0].Counts)
+	assert.Equal(t, Counts{Total: 4, Success: 4}, history[1].Counts)
+	assert.Equal(t, history[1].Start, history[0].End)
+	assert.True(t, history[1].Complete)
+	assert.Equal(t, Counts{Total: 5, Success: 5}, cb.WindowSnapshot()[0].Counts)
+}
+
+func TestBreakerHistoryDisabled(t *testing.T) {
+	cb, cancel, err := New()
+	require.NoError(t, err)
+	defer cancel()
+
+	feedFrameHelper(cb, 1, 1)
+	assert.Nil(t, cb.History())
+}
+
+func windowCountsHelper(cb *CircuitBreaker) []Counts {
+	snapshot := cb.WindowSnapshot()
+	counts := make([]Counts, len(snapshot), cap(snapshot))
+	for i, f := range snapshot {
+		counts[i] = f.Counts
+	}
+	return counts
+}